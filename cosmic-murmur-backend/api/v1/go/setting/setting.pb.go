@@ -0,0 +1,2075 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.21.12
+// source: setting.proto
+
+package grpcSetting
+
+import (
+	common "github.com/polis-interactive/2023-CosmicMurmur/api/v1/go/common"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Transition struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DurationInMs int64  `protobuf:"varint,1,opt,name=DurationInMs,proto3" json:"DurationInMs,omitempty"`
+	Easing       string `protobuf:"bytes,2,opt,name=Easing,proto3" json:"Easing,omitempty"`
+	MaskShader   string `protobuf:"bytes,3,opt,name=MaskShader,proto3" json:"MaskShader,omitempty"`
+}
+
+func (x *Transition) Reset() {
+	*x = Transition{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_setting_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Transition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transition) ProtoMessage() {}
+
+func (x *Transition) ProtoReflect() protoreflect.Message {
+	mi := &file_setting_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transition.ProtoReflect.Descriptor instead.
+func (*Transition) Descriptor() ([]byte, []int) {
+	return file_setting_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Transition) GetDurationInMs() int64 {
+	if x != nil {
+		return x.DurationInMs
+	}
+	return 0
+}
+
+func (x *Transition) GetEasing() string {
+	if x != nil {
+		return x.Easing
+	}
+	return ""
+}
+
+func (x *Transition) GetMaskShader() string {
+	if x != nil {
+		return x.MaskShader
+	}
+	return ""
+}
+
+type GraphicsSettings struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Shaders        []string    `protobuf:"bytes,1,rep,name=Shaders,proto3" json:"Shaders,omitempty"`
+	RunningShader  string      `protobuf:"bytes,2,opt,name=RunningShader,proto3" json:"RunningShader,omitempty"`
+	RefreshInMs    int64       `protobuf:"varint,3,opt,name=RefreshInMs,proto3" json:"RefreshInMs,omitempty"`
+	ReloadOnUpdate bool        `protobuf:"varint,4,opt,name=ReloadOnUpdate,proto3" json:"ReloadOnUpdate,omitempty"`
+	Transition     *Transition `protobuf:"bytes,5,opt,name=Transition,proto3" json:"Transition,omitempty"`
+	// empty unless a playlist is running; playlists themselves are managed over the http api
+	ActivePlaylist string `protobuf:"bytes,6,opt,name=ActivePlaylist,proto3" json:"ActivePlaylist,omitempty"`
+}
+
+func (x *GraphicsSettings) Reset() {
+	*x = GraphicsSettings{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_setting_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GraphicsSettings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GraphicsSettings) ProtoMessage() {}
+
+func (x *GraphicsSettings) ProtoReflect() protoreflect.Message {
+	mi := &file_setting_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GraphicsSettings.ProtoReflect.Descriptor instead.
+func (*GraphicsSettings) Descriptor() ([]byte, []int) {
+	return file_setting_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GraphicsSettings) GetShaders() []string {
+	if x != nil {
+		return x.Shaders
+	}
+	return nil
+}
+
+func (x *GraphicsSettings) GetRunningShader() string {
+	if x != nil {
+		return x.RunningShader
+	}
+	return ""
+}
+
+func (x *GraphicsSettings) GetRefreshInMs() int64 {
+	if x != nil {
+		return x.RefreshInMs
+	}
+	return 0
+}
+
+func (x *GraphicsSettings) GetReloadOnUpdate() bool {
+	if x != nil {
+		return x.ReloadOnUpdate
+	}
+	return false
+}
+
+func (x *GraphicsSettings) GetTransition() *Transition {
+	if x != nil {
+		return x.Transition
+	}
+	return nil
+}
+
+func (x *GraphicsSettings) GetActivePlaylist() string {
+	if x != nil {
+		return x.ActivePlaylist
+	}
+	return ""
+}
+
+type GraphicsSettingsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status   *common.SuccessFailure `protobuf:"bytes,1,opt,name=Status,proto3" json:"Status,omitempty"`
+	Settings *GraphicsSettings      `protobuf:"bytes,2,opt,name=Settings,proto3" json:"Settings,omitempty"`
+}
+
+func (x *GraphicsSettingsResponse) Reset() {
+	*x = GraphicsSettingsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_setting_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GraphicsSettingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GraphicsSettingsResponse) ProtoMessage() {}
+
+func (x *GraphicsSettingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_setting_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GraphicsSettingsResponse.ProtoReflect.Descriptor instead.
+func (*GraphicsSettingsResponse) Descriptor() ([]byte, []int) {
+	return file_setting_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GraphicsSettingsResponse) GetStatus() *common.SuccessFailure {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *GraphicsSettingsResponse) GetSettings() *GraphicsSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+type SetGraphicsSettingsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ShaderName     string `protobuf:"bytes,1,opt,name=ShaderName,proto3" json:"ShaderName,omitempty"`
+	RefreshInMs    int64  `protobuf:"varint,2,opt,name=RefreshInMs,proto3" json:"RefreshInMs,omitempty"`
+	ReloadOnUpdate bool   `protobuf:"varint,3,opt,name=ReloadOnUpdate,proto3" json:"ReloadOnUpdate,omitempty"`
+	// left unset, the current transition is kept
+	Transition *Transition `protobuf:"bytes,4,opt,name=Transition,proto3" json:"Transition,omitempty"`
+}
+
+func (x *SetGraphicsSettingsRequest) Reset() {
+	*x = SetGraphicsSettingsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_setting_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetGraphicsSettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetGraphicsSettingsRequest) ProtoMessage() {}
+
+func (x *SetGraphicsSettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_setting_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetGraphicsSettingsRequest.ProtoReflect.Descriptor instead.
+func (*SetGraphicsSettingsRequest) Descriptor() ([]byte, []int) {
+	return file_setting_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SetGraphicsSettingsRequest) GetShaderName() string {
+	if x != nil {
+		return x.ShaderName
+	}
+	return ""
+}
+
+func (x *SetGraphicsSettingsRequest) GetRefreshInMs() int64 {
+	if x != nil {
+		return x.RefreshInMs
+	}
+	return 0
+}
+
+func (x *SetGraphicsSettingsRequest) GetReloadOnUpdate() bool {
+	if x != nil {
+		return x.ReloadOnUpdate
+	}
+	return false
+}
+
+func (x *SetGraphicsSettingsRequest) GetTransition() *Transition {
+	if x != nil {
+		return x.Transition
+	}
+	return nil
+}
+
+type LedString struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LedCount    int32 `protobuf:"varint,1,opt,name=LedCount,proto3" json:"LedCount,omitempty"`
+	StringCount int32 `protobuf:"varint,2,opt,name=StringCount,proto3" json:"StringCount,omitempty"`
+	// RGB (or empty), GRB, BGR, RGBW, GRBW or RGB16
+	PixelFormat string `protobuf:"bytes,3,opt,name=PixelFormat,proto3" json:"PixelFormat,omitempty"`
+}
+
+func (x *LedString) Reset() {
+	*x = LedString{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_setting_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LedString) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LedString) ProtoMessage() {}
+
+func (x *LedString) ProtoReflect() protoreflect.Message {
+	mi := &file_setting_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LedString.ProtoReflect.Descriptor instead.
+func (*LedString) Descriptor() ([]byte, []int) {
+	return file_setting_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *LedString) GetLedCount() int32 {
+	if x != nil {
+		return x.LedCount
+	}
+	return 0
+}
+
+func (x *LedString) GetStringCount() int32 {
+	if x != nil {
+		return x.StringCount
+	}
+	return 0
+}
+
+func (x *LedString) GetPixelFormat() string {
+	if x != nil {
+		return x.PixelFormat
+	}
+	return ""
+}
+
+type LedUniverse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Strings []*LedString `protobuf:"bytes,1,rep,name=Strings,proto3" json:"Strings,omitempty"`
+}
+
+func (x *LedUniverse) Reset() {
+	*x = LedUniverse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_setting_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LedUniverse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LedUniverse) ProtoMessage() {}
+
+func (x *LedUniverse) ProtoReflect() protoreflect.Message {
+	mi := &file_setting_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LedUniverse.ProtoReflect.Descriptor instead.
+func (*LedUniverse) Descriptor() ([]byte, []int) {
+	return file_setting_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *LedUniverse) GetStrings() []*LedString {
+	if x != nil {
+		return x.Strings
+	}
+	return nil
+}
+
+type LightingSettings struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SegmentDefinition []*LedUniverse `protobuf:"bytes,1,rep,name=SegmentDefinition,proto3" json:"SegmentDefinition,omitempty"`
+	SegmentCount      int32          `protobuf:"varint,2,opt,name=SegmentCount,proto3" json:"SegmentCount,omitempty"`
+}
+
+func (x *LightingSettings) Reset() {
+	*x = LightingSettings{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_setting_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LightingSettings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LightingSettings) ProtoMessage() {}
+
+func (x *LightingSettings) ProtoReflect() protoreflect.Message {
+	mi := &file_setting_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LightingSettings.ProtoReflect.Descriptor instead.
+func (*LightingSettings) Descriptor() ([]byte, []int) {
+	return file_setting_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *LightingSettings) GetSegmentDefinition() []*LedUniverse {
+	if x != nil {
+		return x.SegmentDefinition
+	}
+	return nil
+}
+
+func (x *LightingSettings) GetSegmentCount() int32 {
+	if x != nil {
+		return x.SegmentCount
+	}
+	return 0
+}
+
+type LightingSettingsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status   *common.SuccessFailure `protobuf:"bytes,1,opt,name=Status,proto3" json:"Status,omitempty"`
+	Settings *LightingSettings      `protobuf:"bytes,2,opt,name=Settings,proto3" json:"Settings,omitempty"`
+}
+
+func (x *LightingSettingsResponse) Reset() {
+	*x = LightingSettingsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_setting_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LightingSettingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LightingSettingsResponse) ProtoMessage() {}
+
+func (x *LightingSettingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_setting_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LightingSettingsResponse.ProtoReflect.Descriptor instead.
+func (*LightingSettingsResponse) Descriptor() ([]byte, []int) {
+	return file_setting_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *LightingSettingsResponse) GetStatus() *common.SuccessFailure {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *LightingSettingsResponse) GetSettings() *LightingSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+type SetLightingSettingsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Settings *LightingSettings `protobuf:"bytes,1,opt,name=Settings,proto3" json:"Settings,omitempty"`
+}
+
+func (x *SetLightingSettingsRequest) Reset() {
+	*x = SetLightingSettingsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_setting_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetLightingSettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLightingSettingsRequest) ProtoMessage() {}
+
+func (x *SetLightingSettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_setting_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLightingSettingsRequest.ProtoReflect.Descriptor instead.
+func (*SetLightingSettingsRequest) Descriptor() ([]byte, []int) {
+	return file_setting_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SetLightingSettingsRequest) GetSettings() *LightingSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+type NodeDefinition struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address   string  `protobuf:"bytes,1,opt,name=Address,proto3" json:"Address,omitempty"`
+	Universes []int32 `protobuf:"varint,2,rep,packed,name=Universes,proto3" json:"Universes,omitempty"`
+	Port      int32   `protobuf:"varint,3,opt,name=Port,proto3" json:"Port,omitempty"`
+	Sync      bool    `protobuf:"varint,4,opt,name=Sync,proto3" json:"Sync,omitempty"`
+	// "artnet" (or empty) / "sacn"; the rest only apply to sacn
+	Protocol     string `protobuf:"bytes,5,opt,name=Protocol,proto3" json:"Protocol,omitempty"`
+	Multicast    bool   `protobuf:"varint,6,opt,name=Multicast,proto3" json:"Multicast,omitempty"`
+	Priority     int32  `protobuf:"varint,7,opt,name=Priority,proto3" json:"Priority,omitempty"`
+	SyncUniverse int32  `protobuf:"varint,8,opt,name=SyncUniverse,proto3" json:"SyncUniverse,omitempty"`
+	SourceName   string `protobuf:"bytes,9,opt,name=SourceName,proto3" json:"SourceName,omitempty"`
+}
+
+func (x *NodeDefinition) Reset() {
+	*x = NodeDefinition{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_setting_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NodeDefinition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodeDefinition) ProtoMessage() {}
+
+func (x *NodeDefinition) ProtoReflect() protoreflect.Message {
+	mi := &file_setting_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodeDefinition.ProtoReflect.Descriptor instead.
+func (*NodeDefinition) Descriptor() ([]byte, []int) {
+	return file_setting_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *NodeDefinition) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *NodeDefinition) GetUniverses() []int32 {
+	if x != nil {
+		return x.Universes
+	}
+	return nil
+}
+
+func (x *NodeDefinition) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *NodeDefinition) GetSync() bool {
+	if x != nil {
+		return x.Sync
+	}
+	return false
+}
+
+func (x *NodeDefinition) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
+func (x *NodeDefinition) GetMulticast() bool {
+	if x != nil {
+		return x.Multicast
+	}
+	return false
+}
+
+func (x *NodeDefinition) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *NodeDefinition) GetSyncUniverse() int32 {
+	if x != nil {
+		return x.SyncUniverse
+	}
+	return 0
+}
+
+func (x *NodeDefinition) GetSourceName() string {
+	if x != nil {
+		return x.SourceName
+	}
+	return ""
+}
+
+type ControllerSettings struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LocalAddress    string            `protobuf:"bytes,1,opt,name=LocalAddress,proto3" json:"LocalAddress,omitempty"`
+	NodeDefinitions []*NodeDefinition `protobuf:"bytes,2,rep,name=NodeDefinitions,proto3" json:"NodeDefinitions,omitempty"`
+}
+
+func (x *ControllerSettings) Reset() {
+	*x = ControllerSettings{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_setting_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ControllerSettings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ControllerSettings) ProtoMessage() {}
+
+func (x *ControllerSettings) ProtoReflect() protoreflect.Message {
+	mi := &file_setting_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ControllerSettings.ProtoReflect.Descriptor instead.
+func (*ControllerSettings) Descriptor() ([]byte, []int) {
+	return file_setting_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ControllerSettings) GetLocalAddress() string {
+	if x != nil {
+		return x.LocalAddress
+	}
+	return ""
+}
+
+func (x *ControllerSettings) GetNodeDefinitions() []*NodeDefinition {
+	if x != nil {
+		return x.NodeDefinitions
+	}
+	return nil
+}
+
+type ControllerSettingsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status   *common.SuccessFailure `protobuf:"bytes,1,opt,name=Status,proto3" json:"Status,omitempty"`
+	Settings *ControllerSettings    `protobuf:"bytes,2,opt,name=Settings,proto3" json:"Settings,omitempty"`
+}
+
+func (x *ControllerSettingsResponse) Reset() {
+	*x = ControllerSettingsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_setting_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ControllerSettingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ControllerSettingsResponse) ProtoMessage() {}
+
+func (x *ControllerSettingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_setting_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ControllerSettingsResponse.ProtoReflect.Descriptor instead.
+func (*ControllerSettingsResponse) Descriptor() ([]byte, []int) {
+	return file_setting_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ControllerSettingsResponse) GetStatus() *common.SuccessFailure {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *ControllerSettingsResponse) GetSettings() *ControllerSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+type SetControllerSettingsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Settings *ControllerSettings `protobuf:"bytes,1,opt,name=Settings,proto3" json:"Settings,omitempty"`
+}
+
+func (x *SetControllerSettingsRequest) Reset() {
+	*x = SetControllerSettingsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_setting_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetControllerSettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetControllerSettingsRequest) ProtoMessage() {}
+
+func (x *SetControllerSettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_setting_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetControllerSettingsRequest.ProtoReflect.Descriptor instead.
+func (*SetControllerSettingsRequest) Descriptor() ([]byte, []int) {
+	return file_setting_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SetControllerSettingsRequest) GetSettings() *ControllerSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+type WhiteBalance struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	R float64 `protobuf:"fixed64,1,opt,name=R,proto3" json:"R,omitempty"`
+	G float64 `protobuf:"fixed64,2,opt,name=G,proto3" json:"G,omitempty"`
+	B float64 `protobuf:"fixed64,3,opt,name=B,proto3" json:"B,omitempty"`
+}
+
+func (x *WhiteBalance) Reset() {
+	*x = WhiteBalance{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_setting_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WhiteBalance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WhiteBalance) ProtoMessage() {}
+
+func (x *WhiteBalance) ProtoReflect() protoreflect.Message {
+	mi := &file_setting_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WhiteBalance.ProtoReflect.Descriptor instead.
+func (*WhiteBalance) Descriptor() ([]byte, []int) {
+	return file_setting_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *WhiteBalance) GetR() float64 {
+	if x != nil {
+		return x.R
+	}
+	return 0
+}
+
+func (x *WhiteBalance) GetG() float64 {
+	if x != nil {
+		return x.G
+	}
+	return 0
+}
+
+func (x *WhiteBalance) GetB() float64 {
+	if x != nil {
+		return x.B
+	}
+	return 0
+}
+
+type PowerSegment struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Universe     int32   `protobuf:"varint,1,opt,name=Universe,proto3" json:"Universe,omitempty"`
+	FirstPixel   int32   `protobuf:"varint,2,opt,name=FirstPixel,proto3" json:"FirstPixel,omitempty"`
+	PixelCount   int32   `protobuf:"varint,3,opt,name=PixelCount,proto3" json:"PixelCount,omitempty"`
+	MaxMilliamps float64 `protobuf:"fixed64,4,opt,name=MaxMilliamps,proto3" json:"MaxMilliamps,omitempty"`
+}
+
+func (x *PowerSegment) Reset() {
+	*x = PowerSegment{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_setting_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PowerSegment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PowerSegment) ProtoMessage() {}
+
+func (x *PowerSegment) ProtoReflect() protoreflect.Message {
+	mi := &file_setting_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PowerSegment.ProtoReflect.Descriptor instead.
+func (*PowerSegment) Descriptor() ([]byte, []int) {
+	return file_setting_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *PowerSegment) GetUniverse() int32 {
+	if x != nil {
+		return x.Universe
+	}
+	return 0
+}
+
+func (x *PowerSegment) GetFirstPixel() int32 {
+	if x != nil {
+		return x.FirstPixel
+	}
+	return 0
+}
+
+func (x *PowerSegment) GetPixelCount() int32 {
+	if x != nil {
+		return x.PixelCount
+	}
+	return 0
+}
+
+func (x *PowerSegment) GetMaxMilliamps() float64 {
+	if x != nil {
+		return x.MaxMilliamps
+	}
+	return 0
+}
+
+type PowerBudget struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MilliampsPerChannel float64         `protobuf:"fixed64,1,opt,name=MilliampsPerChannel,proto3" json:"MilliampsPerChannel,omitempty"`
+	Segments            []*PowerSegment `protobuf:"bytes,2,rep,name=Segments,proto3" json:"Segments,omitempty"`
+}
+
+func (x *PowerBudget) Reset() {
+	*x = PowerBudget{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_setting_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PowerBudget) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PowerBudget) ProtoMessage() {}
+
+func (x *PowerBudget) ProtoReflect() protoreflect.Message {
+	mi := &file_setting_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PowerBudget.ProtoReflect.Descriptor instead.
+func (*PowerBudget) Descriptor() ([]byte, []int) {
+	return file_setting_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *PowerBudget) GetMilliampsPerChannel() float64 {
+	if x != nil {
+		return x.MilliampsPerChannel
+	}
+	return 0
+}
+
+func (x *PowerBudget) GetSegments() []*PowerSegment {
+	if x != nil {
+		return x.Segments
+	}
+	return nil
+}
+
+type RenderSettings struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Gamma        float64       `protobuf:"fixed64,1,opt,name=Gamma,proto3" json:"Gamma,omitempty"`
+	WhiteBalance *WhiteBalance `protobuf:"bytes,2,opt,name=WhiteBalance,proto3" json:"WhiteBalance,omitempty"`
+	Dithering    bool          `protobuf:"varint,3,opt,name=Dithering,proto3" json:"Dithering,omitempty"`
+	Brightness   float64       `protobuf:"fixed64,4,opt,name=Brightness,proto3" json:"Brightness,omitempty"`
+	PowerBudget  *PowerBudget  `protobuf:"bytes,5,opt,name=PowerBudget,proto3" json:"PowerBudget,omitempty"`
+}
+
+func (x *RenderSettings) Reset() {
+	*x = RenderSettings{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_setting_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RenderSettings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenderSettings) ProtoMessage() {}
+
+func (x *RenderSettings) ProtoReflect() protoreflect.Message {
+	mi := &file_setting_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenderSettings.ProtoReflect.Descriptor instead.
+func (*RenderSettings) Descriptor() ([]byte, []int) {
+	return file_setting_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RenderSettings) GetGamma() float64 {
+	if x != nil {
+		return x.Gamma
+	}
+	return 0
+}
+
+func (x *RenderSettings) GetWhiteBalance() *WhiteBalance {
+	if x != nil {
+		return x.WhiteBalance
+	}
+	return nil
+}
+
+func (x *RenderSettings) GetDithering() bool {
+	if x != nil {
+		return x.Dithering
+	}
+	return false
+}
+
+func (x *RenderSettings) GetBrightness() float64 {
+	if x != nil {
+		return x.Brightness
+	}
+	return 0
+}
+
+func (x *RenderSettings) GetPowerBudget() *PowerBudget {
+	if x != nil {
+		return x.PowerBudget
+	}
+	return nil
+}
+
+type RenderSettingsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status   *common.SuccessFailure `protobuf:"bytes,1,opt,name=Status,proto3" json:"Status,omitempty"`
+	Settings *RenderSettings        `protobuf:"bytes,2,opt,name=Settings,proto3" json:"Settings,omitempty"`
+}
+
+func (x *RenderSettingsResponse) Reset() {
+	*x = RenderSettingsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_setting_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RenderSettingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenderSettingsResponse) ProtoMessage() {}
+
+func (x *RenderSettingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_setting_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenderSettingsResponse.ProtoReflect.Descriptor instead.
+func (*RenderSettingsResponse) Descriptor() ([]byte, []int) {
+	return file_setting_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RenderSettingsResponse) GetStatus() *common.SuccessFailure {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *RenderSettingsResponse) GetSettings() *RenderSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+type SetRenderSettingsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Settings *RenderSettings `protobuf:"bytes,1,opt,name=Settings,proto3" json:"Settings,omitempty"`
+}
+
+func (x *SetRenderSettingsRequest) Reset() {
+	*x = SetRenderSettingsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_setting_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetRenderSettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetRenderSettingsRequest) ProtoMessage() {}
+
+func (x *SetRenderSettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_setting_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetRenderSettingsRequest.ProtoReflect.Descriptor instead.
+func (*SetRenderSettingsRequest) Descriptor() ([]byte, []int) {
+	return file_setting_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SetRenderSettingsRequest) GetSettings() *RenderSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+type StatusResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status          *common.SuccessFailure `protobuf:"bytes,1,opt,name=Status,proto3" json:"Status,omitempty"`
+	GraphicsRunning bool                   `protobuf:"varint,2,opt,name=GraphicsRunning,proto3" json:"GraphicsRunning,omitempty"`
+	RunningShader   string                 `protobuf:"bytes,3,opt,name=RunningShader,proto3" json:"RunningShader,omitempty"`
+	FramesRendered  uint64                 `protobuf:"varint,4,opt,name=FramesRendered,proto3" json:"FramesRendered,omitempty"`
+	GraphicsCrashes uint64                 `protobuf:"varint,5,opt,name=GraphicsCrashes,proto3" json:"GraphicsCrashes,omitempty"`
+	LastFrameUnixMs int64                  `protobuf:"varint,6,opt,name=LastFrameUnixMs,proto3" json:"LastFrameUnixMs,omitempty"`
+	PowerSegments   []*PowerSegmentStatus  `protobuf:"bytes,7,rep,name=PowerSegments,proto3" json:"PowerSegments,omitempty"`
+	ShowOn          bool                   `protobuf:"varint,8,opt,name=ShowOn,proto3" json:"ShowOn,omitempty"`
+}
+
+func (x *StatusResponse) Reset() {
+	*x = StatusResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_setting_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusResponse) ProtoMessage() {}
+
+func (x *StatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_setting_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusResponse.ProtoReflect.Descriptor instead.
+func (*StatusResponse) Descriptor() ([]byte, []int) {
+	return file_setting_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *StatusResponse) GetStatus() *common.SuccessFailure {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *StatusResponse) GetGraphicsRunning() bool {
+	if x != nil {
+		return x.GraphicsRunning
+	}
+	return false
+}
+
+func (x *StatusResponse) GetRunningShader() string {
+	if x != nil {
+		return x.RunningShader
+	}
+	return ""
+}
+
+func (x *StatusResponse) GetFramesRendered() uint64 {
+	if x != nil {
+		return x.FramesRendered
+	}
+	return 0
+}
+
+func (x *StatusResponse) GetGraphicsCrashes() uint64 {
+	if x != nil {
+		return x.GraphicsCrashes
+	}
+	return 0
+}
+
+func (x *StatusResponse) GetLastFrameUnixMs() int64 {
+	if x != nil {
+		return x.LastFrameUnixMs
+	}
+	return 0
+}
+
+func (x *StatusResponse) GetPowerSegments() []*PowerSegmentStatus {
+	if x != nil {
+		return x.PowerSegments
+	}
+	return nil
+}
+
+func (x *StatusResponse) GetShowOn() bool {
+	if x != nil {
+		return x.ShowOn
+	}
+	return false
+}
+
+type PowerSegmentStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Universe           int32   `protobuf:"varint,1,opt,name=Universe,proto3" json:"Universe,omitempty"`
+	FirstPixel         int32   `protobuf:"varint,2,opt,name=FirstPixel,proto3" json:"FirstPixel,omitempty"`
+	EstimatedMilliamps float64 `protobuf:"fixed64,3,opt,name=EstimatedMilliamps,proto3" json:"EstimatedMilliamps,omitempty"`
+	MaxMilliamps       float64 `protobuf:"fixed64,4,opt,name=MaxMilliamps,proto3" json:"MaxMilliamps,omitempty"`
+	Scale              float64 `protobuf:"fixed64,5,opt,name=Scale,proto3" json:"Scale,omitempty"`
+}
+
+func (x *PowerSegmentStatus) Reset() {
+	*x = PowerSegmentStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_setting_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PowerSegmentStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PowerSegmentStatus) ProtoMessage() {}
+
+func (x *PowerSegmentStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_setting_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PowerSegmentStatus.ProtoReflect.Descriptor instead.
+func (*PowerSegmentStatus) Descriptor() ([]byte, []int) {
+	return file_setting_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *PowerSegmentStatus) GetUniverse() int32 {
+	if x != nil {
+		return x.Universe
+	}
+	return 0
+}
+
+func (x *PowerSegmentStatus) GetFirstPixel() int32 {
+	if x != nil {
+		return x.FirstPixel
+	}
+	return 0
+}
+
+func (x *PowerSegmentStatus) GetEstimatedMilliamps() float64 {
+	if x != nil {
+		return x.EstimatedMilliamps
+	}
+	return 0
+}
+
+func (x *PowerSegmentStatus) GetMaxMilliamps() float64 {
+	if x != nil {
+		return x.MaxMilliamps
+	}
+	return 0
+}
+
+func (x *PowerSegmentStatus) GetScale() float64 {
+	if x != nil {
+		return x.Scale
+	}
+	return 0
+}
+
+var File_setting_proto protoreflect.FileDescriptor
+
+var file_setting_proto_rawDesc = []byte{
+	0x0a, 0x0d, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x1e, 0x43, 0x6f, 0x73, 0x6d, 0x69, 0x63, 0x4d, 0x75, 0x72, 0x6d, 0x75, 0x72, 0x42, 0x61, 0x63,
+	0x6b, 0x65, 0x6e, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x1a,
+	0x0c, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x68, 0x0a,
+	0x0a, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x22, 0x0a, 0x0c, 0x44,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x4d, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0c, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x4d, 0x73, 0x12,
+	0x16, 0x0a, 0x06, 0x45, 0x61, 0x73, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x45, 0x61, 0x73, 0x69, 0x6e, 0x67, 0x12, 0x1e, 0x0a, 0x0a, 0x4d, 0x61, 0x73, 0x6b, 0x53,
+	0x68, 0x61, 0x64, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x4d, 0x61, 0x73,
+	0x6b, 0x53, 0x68, 0x61, 0x64, 0x65, 0x72, 0x22, 0x90, 0x02, 0x0a, 0x10, 0x47, 0x72, 0x61, 0x70,
+	0x68, 0x69, 0x63, 0x73, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x18, 0x0a, 0x07,
+	0x53, 0x68, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x53,
+	0x68, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x52, 0x75, 0x6e, 0x6e, 0x69, 0x6e,
+	0x67, 0x53, 0x68, 0x61, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x52,
+	0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x53, 0x68, 0x61, 0x64, 0x65, 0x72, 0x12, 0x20, 0x0a, 0x0b,
+	0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x49, 0x6e, 0x4d, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0b, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x49, 0x6e, 0x4d, 0x73, 0x12, 0x26,
+	0x0a, 0x0e, 0x52, 0x65, 0x6c, 0x6f, 0x61, 0x64, 0x4f, 0x6e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x52, 0x65, 0x6c, 0x6f, 0x61, 0x64, 0x4f, 0x6e,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x4a, 0x0a, 0x0a, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x43, 0x6f, 0x73,
+	0x6d, 0x69, 0x63, 0x4d, 0x75, 0x72, 0x6d, 0x75, 0x72, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64,
+	0x2e, 0x76, 0x31, 0x2e, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x26, 0x0a, 0x0e, 0x41, 0x63, 0x74, 0x69, 0x76, 0x65, 0x50, 0x6c, 0x61, 0x79,
+	0x6c, 0x69, 0x73, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x41, 0x63, 0x74, 0x69,
+	0x76, 0x65, 0x50, 0x6c, 0x61, 0x79, 0x6c, 0x69, 0x73, 0x74, 0x22, 0xaf, 0x01, 0x0a, 0x18, 0x47,
+	0x72, 0x61, 0x70, 0x68, 0x69, 0x63, 0x73, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x45, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x43, 0x6f, 0x73, 0x6d, 0x69, 0x63,
+	0x4d, 0x75, 0x72, 0x6d, 0x75, 0x72, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2e, 0x76, 0x31,
+	0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x46,
+	0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x52, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x4c,
+	0x0a, 0x08, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x30, 0x2e, 0x43, 0x6f, 0x73, 0x6d, 0x69, 0x63, 0x4d, 0x75, 0x72, 0x6d, 0x75, 0x72, 0x42,
+	0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e,
+	0x67, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x69, 0x63, 0x73, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e,
+	0x67, 0x73, 0x52, 0x08, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x22, 0xd2, 0x01, 0x0a,
+	0x1a, 0x53, 0x65, 0x74, 0x47, 0x72, 0x61, 0x70, 0x68, 0x69, 0x63, 0x73, 0x53, 0x65, 0x74, 0x74,
+	0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x53,
+	0x68, 0x61, 0x64, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x53, 0x68, 0x61, 0x64, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x52,
+	0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x49, 0x6e, 0x4d, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0b, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x49, 0x6e, 0x4d, 0x73, 0x12, 0x26, 0x0a,
+	0x0e, 0x52, 0x65, 0x6c, 0x6f, 0x61, 0x64, 0x4f, 0x6e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x52, 0x65, 0x6c, 0x6f, 0x61, 0x64, 0x4f, 0x6e, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x4a, 0x0a, 0x0a, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x43, 0x6f, 0x73, 0x6d,
+	0x69, 0x63, 0x4d, 0x75, 0x72, 0x6d, 0x75, 0x72, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2e,
+	0x76, 0x31, 0x2e, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x22, 0x6b, 0x0a, 0x09, 0x4c, 0x65, 0x64, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x1a,
+	0x0a, 0x08, 0x4c, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x08, 0x4c, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x53, 0x74,
+	0x72, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0b, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x20, 0x0a, 0x0b,
+	0x50, 0x69, 0x78, 0x65, 0x6c, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x22, 0x52,
+	0x0a, 0x0b, 0x4c, 0x65, 0x64, 0x55, 0x6e, 0x69, 0x76, 0x65, 0x72, 0x73, 0x65, 0x12, 0x43, 0x0a,
+	0x07, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29,
+	0x2e, 0x43, 0x6f, 0x73, 0x6d, 0x69, 0x63, 0x4d, 0x75, 0x72, 0x6d, 0x75, 0x72, 0x42, 0x61, 0x63,
+	0x6b, 0x65, 0x6e, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x2e,
+	0x4c, 0x65, 0x64, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x52, 0x07, 0x53, 0x74, 0x72, 0x69, 0x6e,
+	0x67, 0x73, 0x22, 0x91, 0x01, 0x0a, 0x10, 0x4c, 0x69, 0x67, 0x68, 0x74, 0x69, 0x6e, 0x67, 0x53,
+	0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x59, 0x0a, 0x11, 0x53, 0x65, 0x67, 0x6d, 0x65,
+	0x6e, 0x74, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x43, 0x6f, 0x73, 0x6d, 0x69, 0x63, 0x4d, 0x75, 0x72, 0x6d, 0x75,
+	0x72, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x65, 0x74, 0x74,
+	0x69, 0x6e, 0x67, 0x2e, 0x4c, 0x65, 0x64, 0x55, 0x6e, 0x69, 0x76, 0x65, 0x72, 0x73, 0x65, 0x52,
+	0x11, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x22, 0x0a, 0x0c, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e,
+	0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0xaf, 0x01, 0x0a, 0x18, 0x4c, 0x69, 0x67, 0x68, 0x74,
+	0x69, 0x6e, 0x67, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x45, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x43, 0x6f, 0x73, 0x6d, 0x69, 0x63, 0x4d, 0x75, 0x72, 0x6d,
+	0x75, 0x72, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x63, 0x6f, 0x6d,
+	0x6d, 0x6f, 0x6e, 0x2e, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x46, 0x61, 0x69, 0x6c, 0x75,
+	0x72, 0x65, 0x52, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x4c, 0x0a, 0x08, 0x53, 0x65,
+	0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x43,
+	0x6f, 0x73, 0x6d, 0x69, 0x63, 0x4d, 0x75, 0x72, 0x6d, 0x75, 0x72, 0x42, 0x61, 0x63, 0x6b, 0x65,
+	0x6e, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x4c, 0x69,
+	0x67, 0x68, 0x74, 0x69, 0x6e, 0x67, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x08,
+	0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x22, 0x6a, 0x0a, 0x1a, 0x53, 0x65, 0x74, 0x4c,
+	0x69, 0x67, 0x68, 0x74, 0x69, 0x6e, 0x67, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x4c, 0x0a, 0x08, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e,
+	0x67, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x43, 0x6f, 0x73, 0x6d, 0x69,
+	0x63, 0x4d, 0x75, 0x72, 0x6d, 0x75, 0x72, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2e, 0x76,
+	0x31, 0x2e, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x4c, 0x69, 0x67, 0x68, 0x74, 0x69,
+	0x6e, 0x67, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x08, 0x53, 0x65, 0x74, 0x74,
+	0x69, 0x6e, 0x67, 0x73, 0x22, 0x8a, 0x02, 0x0a, 0x0e, 0x4e, 0x6f, 0x64, 0x65, 0x44, 0x65, 0x66,
+	0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x41, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x12, 0x1c, 0x0a, 0x09, 0x55, 0x6e, 0x69, 0x76, 0x65, 0x72, 0x73, 0x65, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x05, 0x52, 0x09, 0x55, 0x6e, 0x69, 0x76, 0x65, 0x72, 0x73, 0x65, 0x73, 0x12,
+	0x12, 0x0a, 0x04, 0x50, 0x6f, 0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x50,
+	0x6f, 0x72, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x53, 0x79, 0x6e, 0x63, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x04, 0x53, 0x79, 0x6e, 0x63, 0x12, 0x1a, 0x0a, 0x08, 0x50, 0x72, 0x6f, 0x74, 0x6f,
+	0x63, 0x6f, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x50, 0x72, 0x6f, 0x74, 0x6f,
+	0x63, 0x6f, 0x6c, 0x12, 0x1c, 0x0a, 0x09, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x63, 0x61, 0x73, 0x74,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x63, 0x61, 0x73,
+	0x74, 0x12, 0x1a, 0x0a, 0x08, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x08, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x22, 0x0a,
+	0x0c, 0x53, 0x79, 0x6e, 0x63, 0x55, 0x6e, 0x69, 0x76, 0x65, 0x72, 0x73, 0x65, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0c, 0x53, 0x79, 0x6e, 0x63, 0x55, 0x6e, 0x69, 0x76, 0x65, 0x72, 0x73,
+	0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4e, 0x61, 0x6d,
+	0x65, 0x22, 0x92, 0x01, 0x0a, 0x12, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72,
+	0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x4c, 0x6f, 0x63, 0x61,
+	0x6c, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x58, 0x0a, 0x0f,
+	0x4e, 0x6f, 0x64, 0x65, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x43, 0x6f, 0x73, 0x6d, 0x69, 0x63, 0x4d, 0x75,
+	0x72, 0x6d, 0x75, 0x72, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x73,
+	0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x44, 0x65, 0x66, 0x69, 0x6e,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0f, 0x4e, 0x6f, 0x64, 0x65, 0x44, 0x65, 0x66, 0x69, 0x6e,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xb3, 0x01, 0x0a, 0x1a, 0x43, 0x6f, 0x6e, 0x74, 0x72,
+	0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x45, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x43, 0x6f, 0x73, 0x6d, 0x69, 0x63, 0x4d, 0x75,
+	0x72, 0x6d, 0x75, 0x72, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x63,
+	0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x46, 0x61, 0x69,
+	0x6c, 0x75, 0x72, 0x65, 0x52, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x4e, 0x0a, 0x08,
+	0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x32,
+	0x2e, 0x43, 0x6f, 0x73, 0x6d, 0x69, 0x63, 0x4d, 0x75, 0x72, 0x6d, 0x75, 0x72, 0x42, 0x61, 0x63,
+	0x6b, 0x65, 0x6e, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x2e,
+	0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e,
+	0x67, 0x73, 0x52, 0x08, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x22, 0x6e, 0x0a, 0x1c,
+	0x53, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x53, 0x65, 0x74,
+	0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x4e, 0x0a, 0x08,
+	0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x32,
+	0x2e, 0x43, 0x6f, 0x73, 0x6d, 0x69, 0x63, 0x4d, 0x75, 0x72, 0x6d, 0x75, 0x72, 0x42, 0x61, 0x63,
+	0x6b, 0x65, 0x6e, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x2e,
+	0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e,
+	0x67, 0x73, 0x52, 0x08, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x22, 0x38, 0x0a, 0x0c,
+	0x57, 0x68, 0x69, 0x74, 0x65, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x0c, 0x0a, 0x01,
+	0x52, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x01, 0x52, 0x12, 0x0c, 0x0a, 0x01, 0x47, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x01, 0x47, 0x12, 0x0c, 0x0a, 0x01, 0x42, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x01, 0x42, 0x22, 0x8e, 0x01, 0x0a, 0x0c, 0x50, 0x6f, 0x77, 0x65, 0x72,
+	0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x55, 0x6e, 0x69, 0x76, 0x65,
+	0x72, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x55, 0x6e, 0x69, 0x76, 0x65,
+	0x72, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x46, 0x69, 0x72, 0x73, 0x74, 0x50, 0x69, 0x78, 0x65,
+	0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x46, 0x69, 0x72, 0x73, 0x74, 0x50, 0x69,
+	0x78, 0x65, 0x6c, 0x12, 0x1e, 0x0a, 0x0a, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x4d, 0x61, 0x78, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x61,
+	0x6d, 0x70, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x4d, 0x61, 0x78, 0x4d, 0x69,
+	0x6c, 0x6c, 0x69, 0x61, 0x6d, 0x70, 0x73, 0x22, 0x89, 0x01, 0x0a, 0x0b, 0x50, 0x6f, 0x77, 0x65,
+	0x72, 0x42, 0x75, 0x64, 0x67, 0x65, 0x74, 0x12, 0x30, 0x0a, 0x13, 0x4d, 0x69, 0x6c, 0x6c, 0x69,
+	0x61, 0x6d, 0x70, 0x73, 0x50, 0x65, 0x72, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x13, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x61, 0x6d, 0x70, 0x73, 0x50,
+	0x65, 0x72, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x48, 0x0a, 0x08, 0x53, 0x65, 0x67,
+	0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x43, 0x6f,
+	0x73, 0x6d, 0x69, 0x63, 0x4d, 0x75, 0x72, 0x6d, 0x75, 0x72, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e,
+	0x64, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x50, 0x6f, 0x77,
+	0x65, 0x72, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x08, 0x53, 0x65, 0x67, 0x6d, 0x65,
+	0x6e, 0x74, 0x73, 0x22, 0x85, 0x02, 0x0a, 0x0e, 0x52, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x53, 0x65,
+	0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x47, 0x61, 0x6d, 0x6d, 0x61, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x47, 0x61, 0x6d, 0x6d, 0x61, 0x12, 0x50, 0x0a, 0x0c,
+	0x57, 0x68, 0x69, 0x74, 0x65, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x43, 0x6f, 0x73, 0x6d, 0x69, 0x63, 0x4d, 0x75, 0x72, 0x6d, 0x75,
+	0x72, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x65, 0x74, 0x74,
+	0x69, 0x6e, 0x67, 0x2e, 0x57, 0x68, 0x69, 0x74, 0x65, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65,
+	0x52, 0x0c, 0x57, 0x68, 0x69, 0x74, 0x65, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x1c,
+	0x0a, 0x09, 0x44, 0x69, 0x74, 0x68, 0x65, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x09, 0x44, 0x69, 0x74, 0x68, 0x65, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x1e, 0x0a, 0x0a,
+	0x42, 0x72, 0x69, 0x67, 0x68, 0x74, 0x6e, 0x65, 0x73, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x0a, 0x42, 0x72, 0x69, 0x67, 0x68, 0x74, 0x6e, 0x65, 0x73, 0x73, 0x12, 0x4d, 0x0a, 0x0b,
+	0x50, 0x6f, 0x77, 0x65, 0x72, 0x42, 0x75, 0x64, 0x67, 0x65, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x2b, 0x2e, 0x43, 0x6f, 0x73, 0x6d, 0x69, 0x63, 0x4d, 0x75, 0x72, 0x6d, 0x75, 0x72,
+	0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x65, 0x74, 0x74, 0x69,
+	0x6e, 0x67, 0x2e, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x42, 0x75, 0x64, 0x67, 0x65, 0x74, 0x52, 0x0b,
+	0x50, 0x6f, 0x77, 0x65, 0x72, 0x42, 0x75, 0x64, 0x67, 0x65, 0x74, 0x22, 0xab, 0x01, 0x0a, 0x16,
+	0x52, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x45, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x43, 0x6f, 0x73, 0x6d, 0x69, 0x63, 0x4d,
+	0x75, 0x72, 0x6d, 0x75, 0x72, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2e, 0x76, 0x31, 0x2e,
+	0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x46, 0x61,
+	0x69, 0x6c, 0x75, 0x72, 0x65, 0x52, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x4a, 0x0a,
+	0x08, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x2e, 0x2e, 0x43, 0x6f, 0x73, 0x6d, 0x69, 0x63, 0x4d, 0x75, 0x72, 0x6d, 0x75, 0x72, 0x42, 0x61,
+	0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67,
+	0x2e, 0x52, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52,
+	0x08, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x22, 0x66, 0x0a, 0x18, 0x53, 0x65, 0x74,
+	0x52, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x4a, 0x0a, 0x08, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x43, 0x6f, 0x73, 0x6d, 0x69, 0x63,
+	0x4d, 0x75, 0x72, 0x6d, 0x75, 0x72, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2e, 0x76, 0x31,
+	0x2e, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x52, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x53,
+	0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x08, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67,
+	0x73, 0x22, 0x95, 0x03, 0x0a, 0x0e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x45, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x43, 0x6f, 0x73, 0x6d, 0x69, 0x63, 0x4d, 0x75, 0x72,
+	0x6d, 0x75, 0x72, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x63, 0x6f,
+	0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x46, 0x61, 0x69, 0x6c,
+	0x75, 0x72, 0x65, 0x52, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x28, 0x0a, 0x0f, 0x47,
+	0x72, 0x61, 0x70, 0x68, 0x69, 0x63, 0x73, 0x52, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x47, 0x72, 0x61, 0x70, 0x68, 0x69, 0x63, 0x73, 0x52, 0x75,
+	0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x12, 0x24, 0x0a, 0x0d, 0x52, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67,
+	0x53, 0x68, 0x61, 0x64, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x52, 0x75,
+	0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x53, 0x68, 0x61, 0x64, 0x65, 0x72, 0x12, 0x26, 0x0a, 0x0e, 0x46,
+	0x72, 0x61, 0x6d, 0x65, 0x73, 0x52, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x65, 0x64, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x0e, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x73, 0x52, 0x65, 0x6e, 0x64, 0x65,
+	0x72, 0x65, 0x64, 0x12, 0x28, 0x0a, 0x0f, 0x47, 0x72, 0x61, 0x70, 0x68, 0x69, 0x63, 0x73, 0x43,
+	0x72, 0x61, 0x73, 0x68, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x47, 0x72,
+	0x61, 0x70, 0x68, 0x69, 0x63, 0x73, 0x43, 0x72, 0x61, 0x73, 0x68, 0x65, 0x73, 0x12, 0x28, 0x0a,
+	0x0f, 0x4c, 0x61, 0x73, 0x74, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x55, 0x6e, 0x69, 0x78, 0x4d, 0x73,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x4c, 0x61, 0x73, 0x74, 0x46, 0x72, 0x61, 0x6d,
+	0x65, 0x55, 0x6e, 0x69, 0x78, 0x4d, 0x73, 0x12, 0x58, 0x0a, 0x0d, 0x50, 0x6f, 0x77, 0x65, 0x72,
+	0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x32,
+	0x2e, 0x43, 0x6f, 0x73, 0x6d, 0x69, 0x63, 0x4d, 0x75, 0x72, 0x6d, 0x75, 0x72, 0x42, 0x61, 0x63,
+	0x6b, 0x65, 0x6e, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x2e,
+	0x50, 0x6f, 0x77, 0x65, 0x72, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x0d, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74,
+	0x73, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x68, 0x6f, 0x77, 0x4f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x06, 0x53, 0x68, 0x6f, 0x77, 0x4f, 0x6e, 0x22, 0xba, 0x01, 0x0a, 0x12, 0x50, 0x6f,
+	0x77, 0x65, 0x72, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x1a, 0x0a, 0x08, 0x55, 0x6e, 0x69, 0x76, 0x65, 0x72, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x08, 0x55, 0x6e, 0x69, 0x76, 0x65, 0x72, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x0a,
+	0x46, 0x69, 0x72, 0x73, 0x74, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0a, 0x46, 0x69, 0x72, 0x73, 0x74, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x12, 0x2e, 0x0a, 0x12,
+	0x45, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x64, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x61, 0x6d,
+	0x70, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x12, 0x45, 0x73, 0x74, 0x69, 0x6d, 0x61,
+	0x74, 0x65, 0x64, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x61, 0x6d, 0x70, 0x73, 0x12, 0x22, 0x0a, 0x0c,
+	0x4d, 0x61, 0x78, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x61, 0x6d, 0x70, 0x73, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x0c, 0x4d, 0x61, 0x78, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x61, 0x6d, 0x70, 0x73,
+	0x12, 0x14, 0x0a, 0x05, 0x53, 0x63, 0x61, 0x6c, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x05, 0x53, 0x63, 0x61, 0x6c, 0x65, 0x32, 0xf3, 0x09, 0x0a, 0x0e, 0x53, 0x65, 0x74, 0x74, 0x69,
+	0x6e, 0x67, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x7c, 0x0a, 0x13, 0x47, 0x65, 0x74,
+	0x47, 0x72, 0x61, 0x70, 0x68, 0x69, 0x63, 0x73, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73,
+	0x12, 0x2b, 0x2e, 0x43, 0x6f, 0x73, 0x6d, 0x69, 0x63, 0x4d, 0x75, 0x72, 0x6d, 0x75, 0x72, 0x42,
+	0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x38, 0x2e,
+	0x43, 0x6f, 0x73, 0x6d, 0x69, 0x63, 0x4d, 0x75, 0x72, 0x6d, 0x75, 0x72, 0x42, 0x61, 0x63, 0x6b,
+	0x65, 0x6e, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x47,
+	0x72, 0x61, 0x70, 0x68, 0x69, 0x63, 0x73, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x81, 0x01, 0x0a, 0x13, 0x53, 0x65, 0x74, 0x47,
+	0x72, 0x61, 0x70, 0x68, 0x69, 0x63, 0x73, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x12,
+	0x3a, 0x2e, 0x43, 0x6f, 0x73, 0x6d, 0x69, 0x63, 0x4d, 0x75, 0x72, 0x6d, 0x75, 0x72, 0x42, 0x61,
+	0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67,
+	0x2e, 0x53, 0x65, 0x74, 0x47, 0x72, 0x61, 0x70, 0x68, 0x69, 0x63, 0x73, 0x53, 0x65, 0x74, 0x74,
+	0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2e, 0x2e, 0x43, 0x6f,
+	0x73, 0x6d, 0x69, 0x63, 0x4d, 0x75, 0x72, 0x6d, 0x75, 0x72, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e,
+	0x64, 0x2e, 0x76, 0x31, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x44, 0x65, 0x66, 0x61,
+	0x75, 0x6c, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x7c, 0x0a, 0x13, 0x47,
+	0x65, 0x74, 0x4c, 0x69, 0x67, 0x68, 0x74, 0x69, 0x6e, 0x67, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e,
+	0x67, 0x73, 0x12, 0x2b, 0x2e, 0x43, 0x6f, 0x73, 0x6d, 0x69, 0x63, 0x4d, 0x75, 0x72, 0x6d, 0x75,
+	0x72, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x63, 0x6f, 0x6d, 0x6d,
+	0x6f, 0x6e, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x38, 0x2e, 0x43, 0x6f, 0x73, 0x6d, 0x69, 0x63, 0x4d, 0x75, 0x72, 0x6d, 0x75, 0x72, 0x42, 0x61,
+	0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67,
+	0x2e, 0x4c, 0x69, 0x67, 0x68, 0x74, 0x69, 0x6e, 0x67, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x81, 0x01, 0x0a, 0x13, 0x53, 0x65,
+	0x74, 0x4c, 0x69, 0x67, 0x68, 0x74, 0x69, 0x6e, 0x67, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67,
+	0x73, 0x12, 0x3a, 0x2e, 0x43, 0x6f, 0x73, 0x6d, 0x69, 0x63, 0x4d, 0x75, 0x72, 0x6d, 0x75, 0x72,
+	0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x65, 0x74, 0x74, 0x69,
+	0x6e, 0x67, 0x2e, 0x53, 0x65, 0x74, 0x4c, 0x69, 0x67, 0x68, 0x74, 0x69, 0x6e, 0x67, 0x53, 0x65,
+	0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2e, 0x2e,
+	0x43, 0x6f, 0x73, 0x6d, 0x69, 0x63, 0x4d, 0x75, 0x72, 0x6d, 0x75, 0x72, 0x42, 0x61, 0x63, 0x6b,
+	0x65, 0x6e, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x44, 0x65,
+	0x66, 0x61, 0x75, 0x6c, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x80, 0x01,
+	0x0a, 0x15, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x53,
+	0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x2b, 0x2e, 0x43, 0x6f, 0x73, 0x6d, 0x69, 0x63,
+	0x4d, 0x75, 0x72, 0x6d, 0x75, 0x72, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2e, 0x76, 0x31,
+	0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x3a, 0x2e, 0x43, 0x6f, 0x73, 0x6d, 0x69, 0x63, 0x4d, 0x75, 0x72,
+	0x6d, 0x75, 0x72, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x65,
+	0x74, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72,
+	0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x85, 0x01, 0x0a, 0x15, 0x53, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c,
+	0x65, 0x72, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x3c, 0x2e, 0x43, 0x6f, 0x73,
+	0x6d, 0x69, 0x63, 0x4d, 0x75, 0x72, 0x6d, 0x75, 0x72, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64,
+	0x2e, 0x76, 0x31, 0x2e, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x53, 0x65, 0x74, 0x43,
+	0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2e, 0x2e, 0x43, 0x6f, 0x73, 0x6d, 0x69,
+	0x63, 0x4d, 0x75, 0x72, 0x6d, 0x75, 0x72, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2e, 0x76,
+	0x31, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x78, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x52,
+	0x65, 0x6e, 0x64, 0x65, 0x72, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x2b, 0x2e,
+	0x43, 0x6f, 0x73, 0x6d, 0x69, 0x63, 0x4d, 0x75, 0x72, 0x6d, 0x75, 0x72, 0x42, 0x61, 0x63, 0x6b,
+	0x65, 0x6e, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x36, 0x2e, 0x43, 0x6f, 0x73,
+	0x6d, 0x69, 0x63, 0x4d, 0x75, 0x72, 0x6d, 0x75, 0x72, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64,
+	0x2e, 0x76, 0x31, 0x2e, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x52, 0x65, 0x6e, 0x64,
+	0x65, 0x72, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x7d, 0x0a, 0x11, 0x53, 0x65, 0x74, 0x52, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x53,
+	0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x38, 0x2e, 0x43, 0x6f, 0x73, 0x6d, 0x69, 0x63,
+	0x4d, 0x75, 0x72, 0x6d, 0x75, 0x72, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2e, 0x76, 0x31,
+	0x2e, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x53, 0x65, 0x74, 0x52, 0x65, 0x6e, 0x64,
+	0x65, 0x72, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x2e, 0x2e, 0x43, 0x6f, 0x73, 0x6d, 0x69, 0x63, 0x4d, 0x75, 0x72, 0x6d, 0x75, 0x72,
+	0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f,
+	0x6e, 0x2e, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x6f, 0x0a, 0x10, 0x52, 0x65, 0x73, 0x65, 0x74, 0x41, 0x70, 0x70, 0x6c, 0x69, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2b, 0x2e, 0x43, 0x6f, 0x73, 0x6d, 0x69, 0x63, 0x4d, 0x75,
+	0x72, 0x6d, 0x75, 0x72, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x63,
+	0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x2e, 0x2e, 0x43, 0x6f, 0x73, 0x6d, 0x69, 0x63, 0x4d, 0x75, 0x72, 0x6d, 0x75,
+	0x72, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x63, 0x6f, 0x6d, 0x6d,
+	0x6f, 0x6e, 0x2e, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x68, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x2b, 0x2e, 0x43, 0x6f, 0x73, 0x6d, 0x69, 0x63, 0x4d, 0x75, 0x72, 0x6d, 0x75, 0x72, 0x42, 0x61,
+	0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2e, 0x2e, 0x43,
+	0x6f, 0x73, 0x6d, 0x69, 0x63, 0x4d, 0x75, 0x72, 0x6d, 0x75, 0x72, 0x42, 0x61, 0x63, 0x6b, 0x65,
+	0x6e, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x4e, 0x5a, 0x4c,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x70, 0x6f, 0x6c, 0x69, 0x73,
+	0x2d, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x2f, 0x32, 0x30, 0x32,
+	0x33, 0x2d, 0x43, 0x6f, 0x73, 0x6d, 0x69, 0x63, 0x4d, 0x75, 0x72, 0x6d, 0x75, 0x72, 0x2f, 0x61,
+	0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x67, 0x6f, 0x2f, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67,
+	0x3b, 0x67, 0x72, 0x70, 0x63, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_setting_proto_rawDescOnce sync.Once
+	file_setting_proto_rawDescData = file_setting_proto_rawDesc
+)
+
+func file_setting_proto_rawDescGZIP() []byte {
+	file_setting_proto_rawDescOnce.Do(func() {
+		file_setting_proto_rawDescData = protoimpl.X.CompressGZIP(file_setting_proto_rawDescData)
+	})
+	return file_setting_proto_rawDescData
+}
+
+var file_setting_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
+var file_setting_proto_goTypes = []interface{}{
+	(*Transition)(nil),                   // 0: CosmicMurmurBackend.v1.setting.Transition
+	(*GraphicsSettings)(nil),             // 1: CosmicMurmurBackend.v1.setting.GraphicsSettings
+	(*GraphicsSettingsResponse)(nil),     // 2: CosmicMurmurBackend.v1.setting.GraphicsSettingsResponse
+	(*SetGraphicsSettingsRequest)(nil),   // 3: CosmicMurmurBackend.v1.setting.SetGraphicsSettingsRequest
+	(*LedString)(nil),                    // 4: CosmicMurmurBackend.v1.setting.LedString
+	(*LedUniverse)(nil),                  // 5: CosmicMurmurBackend.v1.setting.LedUniverse
+	(*LightingSettings)(nil),             // 6: CosmicMurmurBackend.v1.setting.LightingSettings
+	(*LightingSettingsResponse)(nil),     // 7: CosmicMurmurBackend.v1.setting.LightingSettingsResponse
+	(*SetLightingSettingsRequest)(nil),   // 8: CosmicMurmurBackend.v1.setting.SetLightingSettingsRequest
+	(*NodeDefinition)(nil),               // 9: CosmicMurmurBackend.v1.setting.NodeDefinition
+	(*ControllerSettings)(nil),           // 10: CosmicMurmurBackend.v1.setting.ControllerSettings
+	(*ControllerSettingsResponse)(nil),   // 11: CosmicMurmurBackend.v1.setting.ControllerSettingsResponse
+	(*SetControllerSettingsRequest)(nil), // 12: CosmicMurmurBackend.v1.setting.SetControllerSettingsRequest
+	(*WhiteBalance)(nil),                 // 13: CosmicMurmurBackend.v1.setting.WhiteBalance
+	(*PowerSegment)(nil),                 // 14: CosmicMurmurBackend.v1.setting.PowerSegment
+	(*PowerBudget)(nil),                  // 15: CosmicMurmurBackend.v1.setting.PowerBudget
+	(*RenderSettings)(nil),               // 16: CosmicMurmurBackend.v1.setting.RenderSettings
+	(*RenderSettingsResponse)(nil),       // 17: CosmicMurmurBackend.v1.setting.RenderSettingsResponse
+	(*SetRenderSettingsRequest)(nil),     // 18: CosmicMurmurBackend.v1.setting.SetRenderSettingsRequest
+	(*StatusResponse)(nil),               // 19: CosmicMurmurBackend.v1.setting.StatusResponse
+	(*PowerSegmentStatus)(nil),           // 20: CosmicMurmurBackend.v1.setting.PowerSegmentStatus
+	(*common.SuccessFailure)(nil),        // 21: CosmicMurmurBackend.v1.common.successFailure
+	(*common.EmptyRequest)(nil),          // 22: CosmicMurmurBackend.v1.common.EmptyRequest
+	(*common.DefaultResponse)(nil),       // 23: CosmicMurmurBackend.v1.common.DefaultResponse
+}
+var file_setting_proto_depIdxs = []int32{
+	0,  // 0: CosmicMurmurBackend.v1.setting.GraphicsSettings.Transition:type_name -> CosmicMurmurBackend.v1.setting.Transition
+	21, // 1: CosmicMurmurBackend.v1.setting.GraphicsSettingsResponse.Status:type_name -> CosmicMurmurBackend.v1.common.successFailure
+	1,  // 2: CosmicMurmurBackend.v1.setting.GraphicsSettingsResponse.Settings:type_name -> CosmicMurmurBackend.v1.setting.GraphicsSettings
+	0,  // 3: CosmicMurmurBackend.v1.setting.SetGraphicsSettingsRequest.Transition:type_name -> CosmicMurmurBackend.v1.setting.Transition
+	4,  // 4: CosmicMurmurBackend.v1.setting.LedUniverse.Strings:type_name -> CosmicMurmurBackend.v1.setting.LedString
+	5,  // 5: CosmicMurmurBackend.v1.setting.LightingSettings.SegmentDefinition:type_name -> CosmicMurmurBackend.v1.setting.LedUniverse
+	21, // 6: CosmicMurmurBackend.v1.setting.LightingSettingsResponse.Status:type_name -> CosmicMurmurBackend.v1.common.successFailure
+	6,  // 7: CosmicMurmurBackend.v1.setting.LightingSettingsResponse.Settings:type_name -> CosmicMurmurBackend.v1.setting.LightingSettings
+	6,  // 8: CosmicMurmurBackend.v1.setting.SetLightingSettingsRequest.Settings:type_name -> CosmicMurmurBackend.v1.setting.LightingSettings
+	9,  // 9: CosmicMurmurBackend.v1.setting.ControllerSettings.NodeDefinitions:type_name -> CosmicMurmurBackend.v1.setting.NodeDefinition
+	21, // 10: CosmicMurmurBackend.v1.setting.ControllerSettingsResponse.Status:type_name -> CosmicMurmurBackend.v1.common.successFailure
+	10, // 11: CosmicMurmurBackend.v1.setting.ControllerSettingsResponse.Settings:type_name -> CosmicMurmurBackend.v1.setting.ControllerSettings
+	10, // 12: CosmicMurmurBackend.v1.setting.SetControllerSettingsRequest.Settings:type_name -> CosmicMurmurBackend.v1.setting.ControllerSettings
+	14, // 13: CosmicMurmurBackend.v1.setting.PowerBudget.Segments:type_name -> CosmicMurmurBackend.v1.setting.PowerSegment
+	13, // 14: CosmicMurmurBackend.v1.setting.RenderSettings.WhiteBalance:type_name -> CosmicMurmurBackend.v1.setting.WhiteBalance
+	15, // 15: CosmicMurmurBackend.v1.setting.RenderSettings.PowerBudget:type_name -> CosmicMurmurBackend.v1.setting.PowerBudget
+	21, // 16: CosmicMurmurBackend.v1.setting.RenderSettingsResponse.Status:type_name -> CosmicMurmurBackend.v1.common.successFailure
+	16, // 17: CosmicMurmurBackend.v1.setting.RenderSettingsResponse.Settings:type_name -> CosmicMurmurBackend.v1.setting.RenderSettings
+	16, // 18: CosmicMurmurBackend.v1.setting.SetRenderSettingsRequest.Settings:type_name -> CosmicMurmurBackend.v1.setting.RenderSettings
+	21, // 19: CosmicMurmurBackend.v1.setting.StatusResponse.Status:type_name -> CosmicMurmurBackend.v1.common.successFailure
+	20, // 20: CosmicMurmurBackend.v1.setting.StatusResponse.PowerSegments:type_name -> CosmicMurmurBackend.v1.setting.PowerSegmentStatus
+	22, // 21: CosmicMurmurBackend.v1.setting.SettingService.GetGraphicsSettings:input_type -> CosmicMurmurBackend.v1.common.EmptyRequest
+	3,  // 22: CosmicMurmurBackend.v1.setting.SettingService.SetGraphicsSettings:input_type -> CosmicMurmurBackend.v1.setting.SetGraphicsSettingsRequest
+	22, // 23: CosmicMurmurBackend.v1.setting.SettingService.GetLightingSettings:input_type -> CosmicMurmurBackend.v1.common.EmptyRequest
+	8,  // 24: CosmicMurmurBackend.v1.setting.SettingService.SetLightingSettings:input_type -> CosmicMurmurBackend.v1.setting.SetLightingSettingsRequest
+	22, // 25: CosmicMurmurBackend.v1.setting.SettingService.GetControllerSettings:input_type -> CosmicMurmurBackend.v1.common.EmptyRequest
+	12, // 26: CosmicMurmurBackend.v1.setting.SettingService.SetControllerSettings:input_type -> CosmicMurmurBackend.v1.setting.SetControllerSettingsRequest
+	22, // 27: CosmicMurmurBackend.v1.setting.SettingService.GetRenderSettings:input_type -> CosmicMurmurBackend.v1.common.EmptyRequest
+	18, // 28: CosmicMurmurBackend.v1.setting.SettingService.SetRenderSettings:input_type -> CosmicMurmurBackend.v1.setting.SetRenderSettingsRequest
+	22, // 29: CosmicMurmurBackend.v1.setting.SettingService.ResetApplication:input_type -> CosmicMurmurBackend.v1.common.EmptyRequest
+	22, // 30: CosmicMurmurBackend.v1.setting.SettingService.GetStatus:input_type -> CosmicMurmurBackend.v1.common.EmptyRequest
+	2,  // 31: CosmicMurmurBackend.v1.setting.SettingService.GetGraphicsSettings:output_type -> CosmicMurmurBackend.v1.setting.GraphicsSettingsResponse
+	23, // 32: CosmicMurmurBackend.v1.setting.SettingService.SetGraphicsSettings:output_type -> CosmicMurmurBackend.v1.common.DefaultResponse
+	7,  // 33: CosmicMurmurBackend.v1.setting.SettingService.GetLightingSettings:output_type -> CosmicMurmurBackend.v1.setting.LightingSettingsResponse
+	23, // 34: CosmicMurmurBackend.v1.setting.SettingService.SetLightingSettings:output_type -> CosmicMurmurBackend.v1.common.DefaultResponse
+	11, // 35: CosmicMurmurBackend.v1.setting.SettingService.GetControllerSettings:output_type -> CosmicMurmurBackend.v1.setting.ControllerSettingsResponse
+	23, // 36: CosmicMurmurBackend.v1.setting.SettingService.SetControllerSettings:output_type -> CosmicMurmurBackend.v1.common.DefaultResponse
+	17, // 37: CosmicMurmurBackend.v1.setting.SettingService.GetRenderSettings:output_type -> CosmicMurmurBackend.v1.setting.RenderSettingsResponse
+	23, // 38: CosmicMurmurBackend.v1.setting.SettingService.SetRenderSettings:output_type -> CosmicMurmurBackend.v1.common.DefaultResponse
+	23, // 39: CosmicMurmurBackend.v1.setting.SettingService.ResetApplication:output_type -> CosmicMurmurBackend.v1.common.DefaultResponse
+	19, // 40: CosmicMurmurBackend.v1.setting.SettingService.GetStatus:output_type -> CosmicMurmurBackend.v1.setting.StatusResponse
+	31, // [31:41] is the sub-list for method output_type
+	21, // [21:31] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
+}
+
+func init() { file_setting_proto_init() }
+func file_setting_proto_init() {
+	if File_setting_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_setting_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Transition); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_setting_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GraphicsSettings); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_setting_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GraphicsSettingsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_setting_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetGraphicsSettingsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_setting_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LedString); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_setting_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LedUniverse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_setting_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LightingSettings); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_setting_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LightingSettingsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_setting_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetLightingSettingsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_setting_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NodeDefinition); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_setting_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ControllerSettings); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_setting_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ControllerSettingsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_setting_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetControllerSettingsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_setting_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WhiteBalance); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_setting_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PowerSegment); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_setting_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PowerBudget); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_setting_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RenderSettings); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_setting_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RenderSettingsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_setting_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetRenderSettingsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_setting_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatusResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_setting_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PowerSegmentStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_setting_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   21,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_setting_proto_goTypes,
+		DependencyIndexes: file_setting_proto_depIdxs,
+		MessageInfos:      file_setting_proto_msgTypes,
+	}.Build()
+	File_setting_proto = out.File
+	file_setting_proto_rawDesc = nil
+	file_setting_proto_goTypes = nil
+	file_setting_proto_depIdxs = nil
+}
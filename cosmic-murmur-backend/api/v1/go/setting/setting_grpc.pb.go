@@ -0,0 +1,430 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.21.12
+// source: setting.proto
+
+package grpcSetting
+
+import (
+	context "context"
+	common "github.com/polis-interactive/2023-CosmicMurmur/api/v1/go/common"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// SettingServiceClient is the client API for SettingService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SettingServiceClient interface {
+	GetGraphicsSettings(ctx context.Context, in *common.EmptyRequest, opts ...grpc.CallOption) (*GraphicsSettingsResponse, error)
+	SetGraphicsSettings(ctx context.Context, in *SetGraphicsSettingsRequest, opts ...grpc.CallOption) (*common.DefaultResponse, error)
+	GetLightingSettings(ctx context.Context, in *common.EmptyRequest, opts ...grpc.CallOption) (*LightingSettingsResponse, error)
+	SetLightingSettings(ctx context.Context, in *SetLightingSettingsRequest, opts ...grpc.CallOption) (*common.DefaultResponse, error)
+	GetControllerSettings(ctx context.Context, in *common.EmptyRequest, opts ...grpc.CallOption) (*ControllerSettingsResponse, error)
+	SetControllerSettings(ctx context.Context, in *SetControllerSettingsRequest, opts ...grpc.CallOption) (*common.DefaultResponse, error)
+	GetRenderSettings(ctx context.Context, in *common.EmptyRequest, opts ...grpc.CallOption) (*RenderSettingsResponse, error)
+	SetRenderSettings(ctx context.Context, in *SetRenderSettingsRequest, opts ...grpc.CallOption) (*common.DefaultResponse, error)
+	ResetApplication(ctx context.Context, in *common.EmptyRequest, opts ...grpc.CallOption) (*common.DefaultResponse, error)
+	GetStatus(ctx context.Context, in *common.EmptyRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+}
+
+type settingServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSettingServiceClient(cc grpc.ClientConnInterface) SettingServiceClient {
+	return &settingServiceClient{cc}
+}
+
+func (c *settingServiceClient) GetGraphicsSettings(ctx context.Context, in *common.EmptyRequest, opts ...grpc.CallOption) (*GraphicsSettingsResponse, error) {
+	out := new(GraphicsSettingsResponse)
+	err := c.cc.Invoke(ctx, "/CosmicMurmurBackend.v1.setting.SettingService/GetGraphicsSettings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *settingServiceClient) SetGraphicsSettings(ctx context.Context, in *SetGraphicsSettingsRequest, opts ...grpc.CallOption) (*common.DefaultResponse, error) {
+	out := new(common.DefaultResponse)
+	err := c.cc.Invoke(ctx, "/CosmicMurmurBackend.v1.setting.SettingService/SetGraphicsSettings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *settingServiceClient) GetLightingSettings(ctx context.Context, in *common.EmptyRequest, opts ...grpc.CallOption) (*LightingSettingsResponse, error) {
+	out := new(LightingSettingsResponse)
+	err := c.cc.Invoke(ctx, "/CosmicMurmurBackend.v1.setting.SettingService/GetLightingSettings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *settingServiceClient) SetLightingSettings(ctx context.Context, in *SetLightingSettingsRequest, opts ...grpc.CallOption) (*common.DefaultResponse, error) {
+	out := new(common.DefaultResponse)
+	err := c.cc.Invoke(ctx, "/CosmicMurmurBackend.v1.setting.SettingService/SetLightingSettings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *settingServiceClient) GetControllerSettings(ctx context.Context, in *common.EmptyRequest, opts ...grpc.CallOption) (*ControllerSettingsResponse, error) {
+	out := new(ControllerSettingsResponse)
+	err := c.cc.Invoke(ctx, "/CosmicMurmurBackend.v1.setting.SettingService/GetControllerSettings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *settingServiceClient) SetControllerSettings(ctx context.Context, in *SetControllerSettingsRequest, opts ...grpc.CallOption) (*common.DefaultResponse, error) {
+	out := new(common.DefaultResponse)
+	err := c.cc.Invoke(ctx, "/CosmicMurmurBackend.v1.setting.SettingService/SetControllerSettings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *settingServiceClient) GetRenderSettings(ctx context.Context, in *common.EmptyRequest, opts ...grpc.CallOption) (*RenderSettingsResponse, error) {
+	out := new(RenderSettingsResponse)
+	err := c.cc.Invoke(ctx, "/CosmicMurmurBackend.v1.setting.SettingService/GetRenderSettings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *settingServiceClient) SetRenderSettings(ctx context.Context, in *SetRenderSettingsRequest, opts ...grpc.CallOption) (*common.DefaultResponse, error) {
+	out := new(common.DefaultResponse)
+	err := c.cc.Invoke(ctx, "/CosmicMurmurBackend.v1.setting.SettingService/SetRenderSettings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *settingServiceClient) ResetApplication(ctx context.Context, in *common.EmptyRequest, opts ...grpc.CallOption) (*common.DefaultResponse, error) {
+	out := new(common.DefaultResponse)
+	err := c.cc.Invoke(ctx, "/CosmicMurmurBackend.v1.setting.SettingService/ResetApplication", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *settingServiceClient) GetStatus(ctx context.Context, in *common.EmptyRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/CosmicMurmurBackend.v1.setting.SettingService/GetStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SettingServiceServer is the server API for SettingService service.
+// All implementations must embed UnimplementedSettingServiceServer
+// for forward compatibility
+type SettingServiceServer interface {
+	GetGraphicsSettings(context.Context, *common.EmptyRequest) (*GraphicsSettingsResponse, error)
+	SetGraphicsSettings(context.Context, *SetGraphicsSettingsRequest) (*common.DefaultResponse, error)
+	GetLightingSettings(context.Context, *common.EmptyRequest) (*LightingSettingsResponse, error)
+	SetLightingSettings(context.Context, *SetLightingSettingsRequest) (*common.DefaultResponse, error)
+	GetControllerSettings(context.Context, *common.EmptyRequest) (*ControllerSettingsResponse, error)
+	SetControllerSettings(context.Context, *SetControllerSettingsRequest) (*common.DefaultResponse, error)
+	GetRenderSettings(context.Context, *common.EmptyRequest) (*RenderSettingsResponse, error)
+	SetRenderSettings(context.Context, *SetRenderSettingsRequest) (*common.DefaultResponse, error)
+	ResetApplication(context.Context, *common.EmptyRequest) (*common.DefaultResponse, error)
+	GetStatus(context.Context, *common.EmptyRequest) (*StatusResponse, error)
+	mustEmbedUnimplementedSettingServiceServer()
+}
+
+// UnimplementedSettingServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedSettingServiceServer struct {
+}
+
+func (UnimplementedSettingServiceServer) GetGraphicsSettings(context.Context, *common.EmptyRequest) (*GraphicsSettingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGraphicsSettings not implemented")
+}
+func (UnimplementedSettingServiceServer) SetGraphicsSettings(context.Context, *SetGraphicsSettingsRequest) (*common.DefaultResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetGraphicsSettings not implemented")
+}
+func (UnimplementedSettingServiceServer) GetLightingSettings(context.Context, *common.EmptyRequest) (*LightingSettingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLightingSettings not implemented")
+}
+func (UnimplementedSettingServiceServer) SetLightingSettings(context.Context, *SetLightingSettingsRequest) (*common.DefaultResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLightingSettings not implemented")
+}
+func (UnimplementedSettingServiceServer) GetControllerSettings(context.Context, *common.EmptyRequest) (*ControllerSettingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetControllerSettings not implemented")
+}
+func (UnimplementedSettingServiceServer) SetControllerSettings(context.Context, *SetControllerSettingsRequest) (*common.DefaultResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetControllerSettings not implemented")
+}
+func (UnimplementedSettingServiceServer) GetRenderSettings(context.Context, *common.EmptyRequest) (*RenderSettingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRenderSettings not implemented")
+}
+func (UnimplementedSettingServiceServer) SetRenderSettings(context.Context, *SetRenderSettingsRequest) (*common.DefaultResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetRenderSettings not implemented")
+}
+func (UnimplementedSettingServiceServer) ResetApplication(context.Context, *common.EmptyRequest) (*common.DefaultResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetApplication not implemented")
+}
+func (UnimplementedSettingServiceServer) GetStatus(context.Context, *common.EmptyRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStatus not implemented")
+}
+func (UnimplementedSettingServiceServer) mustEmbedUnimplementedSettingServiceServer() {}
+
+// UnsafeSettingServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SettingServiceServer will
+// result in compilation errors.
+type UnsafeSettingServiceServer interface {
+	mustEmbedUnimplementedSettingServiceServer()
+}
+
+func RegisterSettingServiceServer(s grpc.ServiceRegistrar, srv SettingServiceServer) {
+	s.RegisterService(&SettingService_ServiceDesc, srv)
+}
+
+func _SettingService_GetGraphicsSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.EmptyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SettingServiceServer).GetGraphicsSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/CosmicMurmurBackend.v1.setting.SettingService/GetGraphicsSettings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SettingServiceServer).GetGraphicsSettings(ctx, req.(*common.EmptyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SettingService_SetGraphicsSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetGraphicsSettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SettingServiceServer).SetGraphicsSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/CosmicMurmurBackend.v1.setting.SettingService/SetGraphicsSettings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SettingServiceServer).SetGraphicsSettings(ctx, req.(*SetGraphicsSettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SettingService_GetLightingSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.EmptyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SettingServiceServer).GetLightingSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/CosmicMurmurBackend.v1.setting.SettingService/GetLightingSettings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SettingServiceServer).GetLightingSettings(ctx, req.(*common.EmptyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SettingService_SetLightingSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLightingSettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SettingServiceServer).SetLightingSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/CosmicMurmurBackend.v1.setting.SettingService/SetLightingSettings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SettingServiceServer).SetLightingSettings(ctx, req.(*SetLightingSettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SettingService_GetControllerSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.EmptyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SettingServiceServer).GetControllerSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/CosmicMurmurBackend.v1.setting.SettingService/GetControllerSettings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SettingServiceServer).GetControllerSettings(ctx, req.(*common.EmptyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SettingService_SetControllerSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetControllerSettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SettingServiceServer).SetControllerSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/CosmicMurmurBackend.v1.setting.SettingService/SetControllerSettings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SettingServiceServer).SetControllerSettings(ctx, req.(*SetControllerSettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SettingService_GetRenderSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.EmptyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SettingServiceServer).GetRenderSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/CosmicMurmurBackend.v1.setting.SettingService/GetRenderSettings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SettingServiceServer).GetRenderSettings(ctx, req.(*common.EmptyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SettingService_SetRenderSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRenderSettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SettingServiceServer).SetRenderSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/CosmicMurmurBackend.v1.setting.SettingService/SetRenderSettings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SettingServiceServer).SetRenderSettings(ctx, req.(*SetRenderSettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SettingService_ResetApplication_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.EmptyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SettingServiceServer).ResetApplication(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/CosmicMurmurBackend.v1.setting.SettingService/ResetApplication",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SettingServiceServer).ResetApplication(ctx, req.(*common.EmptyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SettingService_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.EmptyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SettingServiceServer).GetStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/CosmicMurmurBackend.v1.setting.SettingService/GetStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SettingServiceServer).GetStatus(ctx, req.(*common.EmptyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SettingService_ServiceDesc is the grpc.ServiceDesc for SettingService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SettingService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "CosmicMurmurBackend.v1.setting.SettingService",
+	HandlerType: (*SettingServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetGraphicsSettings",
+			Handler:    _SettingService_GetGraphicsSettings_Handler,
+		},
+		{
+			MethodName: "SetGraphicsSettings",
+			Handler:    _SettingService_SetGraphicsSettings_Handler,
+		},
+		{
+			MethodName: "GetLightingSettings",
+			Handler:    _SettingService_GetLightingSettings_Handler,
+		},
+		{
+			MethodName: "SetLightingSettings",
+			Handler:    _SettingService_SetLightingSettings_Handler,
+		},
+		{
+			MethodName: "GetControllerSettings",
+			Handler:    _SettingService_GetControllerSettings_Handler,
+		},
+		{
+			MethodName: "SetControllerSettings",
+			Handler:    _SettingService_SetControllerSettings_Handler,
+		},
+		{
+			MethodName: "GetRenderSettings",
+			Handler:    _SettingService_GetRenderSettings_Handler,
+		},
+		{
+			MethodName: "SetRenderSettings",
+			Handler:    _SettingService_SetRenderSettings_Handler,
+		},
+		{
+			MethodName: "ResetApplication",
+			Handler:    _SettingService_ResetApplication_Handler,
+		},
+		{
+			MethodName: "GetStatus",
+			Handler:    _SettingService_GetStatus_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "setting.proto",
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/jsimonetti/go-artnet/packet"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/infrastructure/receiver"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+/*
+	stands in for an art-net node; point a node definition at it (address 127.0.0.1, port matching -listen)
+	to watch what the controller is sending
+*/
+
+func main() {
+	listen := flag.String("listen", fmt.Sprintf("127.0.0.1:%d", packet.ArtNetPort), "address to receive art-net on")
+	capturePath := flag.String("capture", "", "file to write dmx packets to as json lines; empty to disable")
+	reportPeriod := flag.Duration("report", 1*time.Second, "how often to log per universe stats")
+	flag.Parse()
+
+	zerolog.SetGlobalLevel(zerolog.InfoLevel)
+	log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr})
+
+	var capture io.Writer
+	if *capturePath != "" {
+		f, err := os.Create(*capturePath)
+		if err != nil {
+			log.Fatal().
+				Str("method", "main").Err(err).Msg("couldn't create capture file")
+		}
+		defer f.Close()
+		capture = f
+	}
+
+	r, err := receiver.NewReceiver(*listen, capture)
+	if err != nil {
+		log.Fatal().
+			Str("method", "main").Err(err).Msg("couldn't bind receiver")
+	}
+	r.Startup()
+
+	log.Info().
+		Str("method", "main").Str("address", r.Addr().String()).Msg("receiving")
+
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+	ticker := time.NewTicker(*reportPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c:
+			log.Info().
+				Str("method", "main").Msg("closing")
+			r.Shutdown()
+			return
+		case <-ticker.C:
+			for _, u := range r.GetUniverses() {
+				log.Info().
+					Int("universe", u.Universe).Uint64("packets", u.Packets).
+					Float64("fps", u.FramesPerSecond).Uint64("sequenceErrors", u.SequenceErrors).
+					Dur("sinceLast", time.Since(u.LastPacket)).Msg("universe")
+			}
+			if syncs := r.GetSyncCount(); syncs > 0 {
+				log.Info().Uint64("syncs", syncs).Msg("sync")
+			}
+		}
+	}
+}
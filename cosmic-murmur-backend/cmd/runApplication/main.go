@@ -3,6 +3,7 @@ package main
 import (
 	"github.com/polis-interactive/2023-CosmicMurmur/data"
 	"github.com/polis-interactive/2023-CosmicMurmur/internal/application"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/types"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"os"
@@ -22,15 +23,43 @@ func main() {
 			PixelSize:      7,
 			Frequency:      33 * time.Millisecond,
 			ReloadOnUpdate: false,
+			UseCpuRenderer: false,
+			Transition:     types.Transition{Duration: 0, Easing: types.EasingLinear},
 		},
 		ControllerConfig: &application.ControllerConfig{
 			LocalAddress:    "2.0.0.1",
 			NodeDefinitions: data.DefaultNodeDefinitions,
 		},
+		RenderConfig: &application.RenderConfig{
+			Gamma:        1,
+			WhiteBalance: types.WhiteBalance{R: 1, G: 1, B: 1},
+			Dithering:    false,
+			Brightness:   1,
+			PowerBudget:  types.PowerBudget{},
+		},
+		ScheduleConfig: &application.ScheduleConfig{
+			Enabled:     false,
+			Location:    types.GeoLocation{},
+			Windows:     nil,
+			Playlist:    nil,
+			CheckPeriod: 30 * time.Second,
+		},
 		ServiceBusConfig: &application.ServiceBusConfig{
 			EventQueueSize: 50,
 			BusyTimeout:    1 * time.Second,
 		},
+		RepositoryConfig: &application.RepositoryConfig{
+			UseFileRepository:  true,
+			FileRepositoryPath: "/var/lib/cosmic-murmur/settings.json",
+		},
+		WebServerConfig: &application.WebServerConfig{
+			Port:          8080,
+			RootDirectory: "../cosmic-murmur-frontend/out",
+			IsProduction:  true,
+		},
+		GrpcConfig: &application.GrpcConfig{
+			Port: 8081,
+		},
 		ProgramName: "cosmic-murmur-backend",
 	}
 
@@ -62,7 +91,7 @@ func main() {
 
 	log.Info().Msg("Main: running")
 
-	c := make(chan os.Signal)
+	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 	<-c
 
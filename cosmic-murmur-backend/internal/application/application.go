@@ -1,9 +1,15 @@
 package application
 
 import (
+	"fmt"
 	"github.com/polis-interactive/2023-CosmicMurmur/internal/domain/controller"
 	"github.com/polis-interactive/2023-CosmicMurmur/internal/domain/graphics"
 	"github.com/polis-interactive/2023-CosmicMurmur/internal/domain/lighting"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/domain/render"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/domain/scheduler"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/infrastructure/api"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/infrastructure/grpcApi"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/infrastructure/repository/file"
 	"github.com/polis-interactive/2023-CosmicMurmur/internal/infrastructure/repository/memory"
 	"github.com/polis-interactive/2023-CosmicMurmur/internal/service"
 	"log"
@@ -11,10 +17,12 @@ import (
 )
 
 type Application struct {
-	memoryRepository *memory.Repository
-	serviceBus       applicationBus
-	shutdown         bool
-	shutdownLock     *sync.Mutex
+	repository   applicationRepository
+	serviceBus   applicationBus
+	apiServer    *api.Server
+	grpcServer   *grpcApi.Server
+	shutdown     bool
+	shutdownLock *sync.Mutex
 }
 
 func NewApplication(conf *Config) (*Application, error) {
@@ -27,25 +35,54 @@ func NewApplication(conf *Config) (*Application, error) {
 	}
 
 	/* create repositories */
-	memoryRepository := memory.NewMemoryRepository()
-	app.memoryRepository = memoryRepository
+	if conf.UseFileRepository {
+		fileRepository, err := file.NewFileRepository(conf.FileRepositoryPath)
+		if err != nil {
+			log.Println("Application, NewApplication: failed to open file repository")
+			return nil, err
+		}
+		app.repository = fileRepository
+	} else {
+		app.repository = memory.NewMemoryRepository()
+	}
 
 	/* create bus */
-	app.serviceBus = service.NewBus(conf, app.memoryRepository)
+	app.serviceBus = service.NewBus(conf, app.repository)
 
 	/* create services */
-	lightingService := lighting.NewService(conf, app.memoryRepository)
+	lightingService := lighting.NewService(conf, app.repository)
 	app.serviceBus.BindLightingService(lightingService)
 
-	graphicsService, err := graphics.NewService(conf, app.memoryRepository, app.serviceBus)
+	renderService := render.NewService(conf, app.repository)
+	app.serviceBus.BindRenderService(renderService)
+
+	graphicsService, err := graphics.NewService(conf, app.repository, app.serviceBus)
 	if err != nil {
 		log.Fatalln("Application, NewApplication: failed to initialize graphics service")
 	}
 	app.serviceBus.BindGraphicsService(graphicsService)
 
-	controllerService := controller.NewService(conf, app.memoryRepository, app.serviceBus)
+	controllerService := controller.NewService(conf, app.repository, app.serviceBus)
 	app.serviceBus.BindControllerService(controllerService)
 
+	schedulerService := scheduler.NewService(conf, app.repository, app.serviceBus)
+	app.serviceBus.BindSchedulerService(schedulerService)
+
+	/* create api */
+	apiServer, err := api.NewServer(conf, app.serviceBus)
+	if err != nil {
+		log.Println("Application, NewApplication: failed to create api server")
+		return nil, err
+	}
+	app.apiServer = apiServer
+
+	grpcServer, err := grpcApi.NewServer(conf, app.serviceBus)
+	if err != nil {
+		log.Println("Application, NewApplication: failed to create grpc server")
+		return nil, err
+	}
+	app.grpcServer = grpcServer
+
 	return app, nil
 }
 
@@ -66,6 +103,16 @@ func (app *Application) Startup() error {
 		return err
 	}
 
+	err = app.apiServer.Startup()
+	if err != nil {
+		return err
+	}
+
+	err = app.grpcServer.Startup()
+	if err != nil {
+		return err
+	}
+
 	log.Println("Application, Startup: started")
 
 	return nil
@@ -82,6 +129,13 @@ func (app *Application) Shutdown() error {
 	}
 	app.shutdown = true
 
+	// stop taking requests before pulling the services out from under them
+	err := app.apiServer.Shutdown()
+	if err != nil {
+		log.Println(fmt.Sprintf("Application, Shutdown: api server didn't shut down cleanly; %s", err.Error()))
+	}
+	app.grpcServer.Shutdown()
+
 	app.serviceBus.Shutdown()
 
 	log.Println("Application, Shutdown: finished")
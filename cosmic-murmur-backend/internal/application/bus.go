@@ -4,6 +4,9 @@ import (
 	"github.com/polis-interactive/2023-CosmicMurmur/internal/domain"
 	"github.com/polis-interactive/2023-CosmicMurmur/internal/domain/controller"
 	"github.com/polis-interactive/2023-CosmicMurmur/internal/domain/graphics"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/domain/scheduler"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/infrastructure/api"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/infrastructure/grpcApi"
 )
 
 type applicationBus interface {
@@ -12,6 +15,11 @@ type applicationBus interface {
 	BindGraphicsService(graphicsClient domain.GraphicsService)
 	BindLightingService(lightingService domain.LightingService)
 	BindControllerService(controllerClient domain.ControllerService)
+	BindRenderService(renderService domain.RenderService)
+	BindSchedulerService(schedulerService domain.SchedulerService)
 	graphics.Bus
 	controller.Bus
+	scheduler.Bus
+	api.Bus
+	grpcApi.Bus
 }
@@ -23,6 +23,8 @@ type GraphicsConfig struct {
 	PixelSize      int
 	Frequency      time.Duration
 	ReloadOnUpdate bool
+	UseCpuRenderer bool
+	Transition     types.Transition
 }
 
 func (c *GraphicsConfig) GetGraphicsDefaultShader() string {
@@ -41,6 +43,14 @@ func (c *GraphicsConfig) GetGraphicsReloadOnUpdate() bool {
 	return c.ReloadOnUpdate
 }
 
+func (c *GraphicsConfig) GetGraphicsUseCpuRenderer() bool {
+	return c.UseCpuRenderer
+}
+
+func (c *GraphicsConfig) GetGraphicsTransition() types.Transition {
+	return c.Transition
+}
+
 type ControllerConfig struct {
 	LocalAddress    string
 	NodeDefinitions types.NodeDefinitions
@@ -54,6 +64,62 @@ func (c *ControllerConfig) GetControllerNodeDefinitions() types.NodeDefinitions
 	return c.NodeDefinitions
 }
 
+type RenderConfig struct {
+	Gamma        float64
+	WhiteBalance types.WhiteBalance
+	Dithering    bool
+	Brightness   float64
+	PowerBudget  types.PowerBudget
+}
+
+func (c *RenderConfig) GetRenderGamma() float64 {
+	return c.Gamma
+}
+
+func (c *RenderConfig) GetRenderWhiteBalance() types.WhiteBalance {
+	return c.WhiteBalance
+}
+
+func (c *RenderConfig) GetRenderDithering() bool {
+	return c.Dithering
+}
+
+func (c *RenderConfig) GetRenderBrightness() float64 {
+	return c.Brightness
+}
+
+func (c *RenderConfig) GetRenderPowerBudget() types.PowerBudget {
+	return c.PowerBudget
+}
+
+type ScheduleConfig struct {
+	Enabled     bool
+	Location    types.GeoLocation
+	Windows     []types.ScheduleWindow
+	Playlist    []types.PlaylistEntry
+	CheckPeriod time.Duration
+}
+
+func (c *ScheduleConfig) GetScheduleEnabled() bool {
+	return c.Enabled
+}
+
+func (c *ScheduleConfig) GetScheduleLocation() types.GeoLocation {
+	return c.Location
+}
+
+func (c *ScheduleConfig) GetScheduleWindows() []types.ScheduleWindow {
+	return c.Windows
+}
+
+func (c *ScheduleConfig) GetSchedulePlaylist() []types.PlaylistEntry {
+	return c.Playlist
+}
+
+func (c *ScheduleConfig) GetScheduleCheckPeriod() time.Duration {
+	return c.CheckPeriod
+}
+
 type ServiceBusConfig struct {
 	EventQueueSize int
 	BusyTimeout    time.Duration
@@ -67,11 +133,47 @@ func (c *ServiceBusConfig) GetServiceBusBusyTimeout() time.Duration {
 	return c.BusyTimeout
 }
 
+type WebServerConfig struct {
+	Port          int
+	RootDirectory string
+	IsProduction  bool
+}
+
+func (c *WebServerConfig) GetWebServerPort() int {
+	return c.Port
+}
+
+func (c *WebServerConfig) GetWebServerRootDirectory() string {
+	return c.RootDirectory
+}
+
+func (c *WebServerConfig) GetWebServerIsProduction() bool {
+	return c.IsProduction
+}
+
+type GrpcConfig struct {
+	Port int
+}
+
+func (c *GrpcConfig) GetGrpcPort() int {
+	return c.Port
+}
+
+type RepositoryConfig struct {
+	UseFileRepository  bool
+	FileRepositoryPath string
+}
+
 type Config struct {
 	*LightingConfig
 	*GraphicsConfig
 	*ControllerConfig
+	*RenderConfig
+	*ScheduleConfig
 	*ServiceBusConfig
+	*RepositoryConfig
+	*WebServerConfig
+	*GrpcConfig
 	ProgramName string
 }
 
@@ -0,0 +1,19 @@
+package application
+
+import (
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/domain/controller"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/domain/graphics"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/domain/lighting"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/domain/render"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/domain/scheduler"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/service"
+)
+
+type applicationRepository interface {
+	service.Repository
+	graphics.Repository
+	lighting.Repository
+	controller.Repository
+	render.Repository
+	scheduler.Repository
+}
@@ -1,4 +1,8 @@
 package controller
 
+import "github.com/polis-interactive/2023-CosmicMurmur/internal/domain"
+
 type Bus interface {
+	EmitNodeError(address string, err error)
+	EmitNodeHealth(health *domain.NodeHealth)
 }
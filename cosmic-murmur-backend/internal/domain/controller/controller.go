@@ -2,22 +2,40 @@ package controller
 
 import (
 	"github.com/polis-interactive/2023-CosmicMurmur/internal/types"
+	"net"
+	"sync"
 )
 
 type controller struct {
+	bus          Bus
 	localAddress string
+	dial         dialer
 
 	nodes             []*node
 	universeBufferMap map[int]*[512]byte
 	universeNodeMap   map[int]*node
+
+	listener     *net.UDPConn
+	syncConn     nodeConn
+	discovered   map[string]*discoveredNode
+	discoveredMu *sync.RWMutex
+
+	shutdowns chan struct{}
+	wg        *sync.WaitGroup
 }
 
-func newController(localAddress string, definitions types.NodeDefinitions) *controller {
+func newController(bus Bus, localAddress string, definitions types.NodeDefinitions) *controller {
 	c := &controller{
+		bus:               bus,
 		localAddress:      localAddress,
+		dial:              dialUdp,
 		nodes:             make([]*node, 0, len(definitions)),
 		universeBufferMap: make(map[int]*[512]byte),
 		universeNodeMap:   make(map[int]*node),
+		discovered:        make(map[string]*discoveredNode),
+		discoveredMu:      &sync.RWMutex{},
+		shutdowns:         nil,
+		wg:                &sync.WaitGroup{},
 	}
 	for _, nodeDefinition := range definitions {
 		n := newNode(c, nodeDefinition)
@@ -25,3 +43,29 @@ func newController(localAddress string, definitions types.NodeDefinitions) *cont
 	}
 	return c
 }
+
+func (c *controller) startup() {
+	if c.shutdowns != nil {
+		return
+	}
+	c.shutdowns = make(chan struct{})
+	// nodes send syncs through the sync socket, so it has to be up first and down last
+	c.startupSync()
+	c.startupPoller()
+	for _, n := range c.nodes {
+		n.startup()
+	}
+}
+
+func (c *controller) shutdown() {
+	if c.shutdowns == nil {
+		return
+	}
+	close(c.shutdowns)
+	for _, n := range c.nodes {
+		n.shutdown()
+	}
+	c.shutdownPoller()
+	c.shutdownSync()
+	c.shutdowns = nil
+}
@@ -0,0 +1,120 @@
+package controller
+
+import (
+	"bytes"
+	"github.com/jsimonetti/go-artnet/packet"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/types"
+	"net"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// art-net nodes live on 2.x.x.x or 10.x.x.x and answer polls broadcast to their /8
+var artNetBroadcast = net.IPv4(2, 255, 255, 255)
+
+type discoveredNode struct {
+	address  string
+	port     int
+	lastSeen time.Time
+	// nodes with more than four ports send a reply per bind index
+	portAddresses map[uint8][]int
+}
+
+// outputPortAddresses reads the 15 bit port address of each output; net is bits 14-8, the sub-net switch bits
+// 7-4 and the port's own switch bits 3-0
+func outputPortAddresses(reply *packet.ArtPollReplyPacket) []int {
+	portAddresses := make([]int, 0, 4)
+	for i := 0; i < int(reply.NumPorts) && i < 4; i++ {
+		if !reply.PortTypes[i].Output() {
+			continue
+		}
+		portAddresses = append(portAddresses,
+			int(reply.NetSwitch&0x7F)<<8|int(reply.SubSwitch&0x0F)<<4|int(reply.SwOut[i]&0x0F),
+		)
+	}
+	return portAddresses
+}
+
+func (c *controller) recordDiscovery(address string, port int, reply *packet.ArtPollReplyPacket, now time.Time) {
+	key := net.JoinHostPort(address, strconv.Itoa(port))
+	c.discoveredMu.Lock()
+	defer c.discoveredMu.Unlock()
+	d, ok := c.discovered[key]
+	if !ok {
+		d = &discoveredNode{
+			address:       address,
+			port:          port,
+			portAddresses: make(map[uint8][]int),
+		}
+		c.discovered[key] = d
+	}
+	d.lastSeen = now
+	d.portAddresses[reply.BindIndex] = outputPortAddresses(reply)
+}
+
+func (c *controller) getDiscoveredNodes(now time.Time) []discoveredNode {
+	c.discoveredMu.RLock()
+	defer c.discoveredMu.RUnlock()
+	nodes := make([]discoveredNode, 0, len(c.discovered))
+	for _, d := range c.discovered {
+		if now.Sub(d.lastSeen) > nodeOfflineTimeout {
+			continue
+		}
+		nodes = append(nodes, *d)
+	}
+	return nodes
+}
+
+// proposeNodeDefinitions keeps the universes we drive that each discovered node outputs; a universe claimed
+// by more than one node goes to the lowest address so the proposal is always valid and stable. A node that's
+// already defined at the same address and port keeps the rest of its definition, only its universes change
+func proposeNodeDefinitions(
+	discovered []discoveredNode, requiredUniverses []int, current types.NodeDefinitions,
+) (definitions types.NodeDefinitions, missingUniverses []int) {
+	sort.Slice(discovered, func(i, j int) bool {
+		a, b := net.ParseIP(discovered[i].address), net.ParseIP(discovered[j].address)
+		if c := bytes.Compare(a.To16(), b.To16()); c != 0 {
+			return c < 0
+		}
+		return discovered[i].port < discovered[j].port
+	})
+	required := make(map[int]bool)
+	for _, u := range requiredUniverses {
+		required[u] = true
+	}
+	claimed := make(map[int]bool)
+	definitions = make(types.NodeDefinitions, 0, len(discovered))
+	for _, d := range discovered {
+		universes := make([]int, 0)
+		for _, portAddresses := range d.portAddresses {
+			for _, u := range portAddresses {
+				if required[u] && !claimed[u] {
+					claimed[u] = true
+					universes = append(universes, u)
+				}
+			}
+		}
+		if len(universes) == 0 {
+			continue
+		}
+		sort.Ints(universes)
+		definition := types.NodeDefinition{Address: d.address, Port: d.port}
+		for _, c := range current {
+			if c.Address == d.address && c.Port == d.port {
+				definition = c
+				break
+			}
+		}
+		definition.Universes = universes
+		definitions = append(definitions, definition)
+	}
+	missingUniverses = make([]int, 0)
+	for _, u := range requiredUniverses {
+		if !claimed[u] {
+			missingUniverses = append(missingUniverses, u)
+		}
+	}
+	sort.Ints(missingUniverses)
+	return definitions, missingUniverses
+}
@@ -0,0 +1,90 @@
+package controller
+
+import (
+	"github.com/jsimonetti/go-artnet/packet"
+	"github.com/jsimonetti/go-artnet/packet/code"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/domain"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/types"
+	"testing"
+	"time"
+)
+
+func TestOutputPortAddresses(t *testing.T) {
+	reply := &packet.ArtPollReplyPacket{
+		NetSwitch: 1,
+		SubSwitch: 2,
+		NumPorts:  3,
+		PortTypes: [4]code.PortType{code.PortType(0x80), code.PortType(0x40), code.PortType(0x80)},
+		SwOut:     [4]uint8{3, 4, 5},
+	}
+	addresses := outputPortAddresses(reply)
+	// the second port is an input
+	if len(addresses) != 2 || addresses[0] != 0x123 || addresses[1] != 0x125 {
+		t.Errorf("port addresses = %x", addresses)
+	}
+}
+
+func TestProposeNodeDefinitions(t *testing.T) {
+	now := time.Now()
+	discovered := []discoveredNode{
+		{address: "2.0.0.10", lastSeen: now, portAddresses: map[uint8][]int{1: {4, 5}}},
+		{address: "2.0.0.3", lastSeen: now, portAddresses: map[uint8][]int{1: {0, 1, 2, 3}, 2: {4, 9}}},
+		{address: "2.0.0.4", lastSeen: now, portAddresses: map[uint8][]int{1: {100}}},
+	}
+	definitions, missing := proposeNodeDefinitions(discovered, []int{0, 1, 2, 3, 4, 5, 6}, nil)
+
+	// 2.0.0.3 sorts first, so it keeps universe 4; 2.0.0.4 outputs nothing we drive
+	if len(definitions) != 2 {
+		t.Fatalf("definitions = %v", definitions)
+	}
+	if definitions[0].Address != "2.0.0.3" || len(definitions[0].Universes) != 5 || definitions[0].Universes[4] != 4 {
+		t.Errorf("first definition = %v", definitions[0])
+	}
+	if definitions[1].Address != "2.0.0.10" || len(definitions[1].Universes) != 1 || definitions[1].Universes[0] != 5 {
+		t.Errorf("second definition = %v", definitions[1])
+	}
+	if len(missing) != 1 || missing[0] != 6 {
+		t.Errorf("missing universes = %v", missing)
+	}
+	if err := validateSettings(&domain.ControllerSettings{
+		NodeDefinitions: definitions, LocalAddress: "2.0.0.1",
+	}); err != nil {
+		t.Errorf("proposal doesn't validate; %s", err.Error())
+	}
+}
+
+func TestProposeNodeDefinitions_keepsNodeSettings(t *testing.T) {
+	now := time.Now()
+	discovered := []discoveredNode{
+		{address: "2.0.0.3", lastSeen: now, portAddresses: map[uint8][]int{1: {0, 1}}},
+		{address: "2.0.0.4", port: 6455, lastSeen: now, portAddresses: map[uint8][]int{1: {2}}},
+	}
+	current := types.NodeDefinitions{
+		{
+			Address: "2.0.0.3", Universes: []int{0}, Sync: true, Protocol: types.ProtocolSacn, Multicast: true,
+			Priority: 150, SyncUniverse: 9, SourceName: "murmur",
+		},
+		// a different port is a different node
+		{Address: "2.0.0.4", Universes: []int{2}, Sync: true},
+	}
+	definitions, _ := proposeNodeDefinitions(discovered, []int{0, 1, 2}, current)
+	if len(definitions) != 2 {
+		t.Fatalf("definitions = %v", definitions)
+	}
+	kept := definitions[0]
+	if !kept.Sync || kept.Protocol != types.ProtocolSacn || !kept.Multicast || kept.Priority != 150 ||
+		kept.SyncUniverse != 9 || kept.SourceName != "murmur" || len(kept.Universes) != 2 {
+		t.Errorf("existing node lost its settings; %v", kept)
+	}
+	if current[0].Universes[0] != 0 || len(current[0].Universes) != 1 {
+		t.Errorf("current definitions were changed; %v", current[0])
+	}
+	if fresh := definitions[1]; fresh.Sync || fresh.Port != 6455 || len(fresh.Universes) != 1 {
+		t.Errorf("new node = %v", fresh)
+	}
+	if err := validateSettings(&domain.ControllerSettings{
+		NodeDefinitions: definitions, LocalAddress: "2.0.0.1",
+	}); err != nil {
+		t.Errorf("proposal doesn't validate; %s", err.Error())
+	}
+}
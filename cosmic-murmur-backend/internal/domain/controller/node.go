@@ -3,60 +3,87 @@ package controller
 import (
 	"errors"
 	"fmt"
-	"github.com/jsimonetti/go-artnet"
-	"github.com/jsimonetti/go-artnet/packet"
 	"github.com/polis-interactive/2023-CosmicMurmur/internal/types"
 	"log"
 	"net"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 type node struct {
 	c               *controller
+	definition      types.NodeDefinition
 	address         string
+	port            int
+	sync            bool
+	transport       transport
 	universeNumbers []int
-	universePackets map[int]*packet.ArtDMXPacket
+	universeBuffers map[int]*[512]byte
+	sequences       map[int]uint8
 
 	shutdowns chan struct{}
 	wg        *sync.WaitGroup
 	mu        *sync.RWMutex
 
+	health   nodeHealth
+	healthMu *sync.RWMutex
+
+	// staged holds the latest snapshot of each universe waiting to go out; a newer frame overwrites it
+	staged      map[int]*[512]byte
+	outgoing    map[int]*[512]byte
+	pending     map[int]struct{}
+	pendingSync *frameSync
+	stagingMu   *sync.Mutex
+	stats       nodeStats
+
 	pollChan chan struct{}
-	sendChan chan int
-	conn     net.Conn
+	wake     chan struct{}
+	conn     nodeConn
+}
+
+// nodeStats count universe updates; coalesced ones were overwritten by a newer frame before the node loop got
+// to them, dropped ones had no running loop to go to
+type nodeStats struct {
+	sent      uint64
+	coalesced uint64
+	dropped   uint64
 }
 
 func newNode(c *controller, definition types.NodeDefinition) *node {
 	n := &node{
 		c:               c,
+		definition:      definition,
 		address:         definition.Address,
+		port:            definition.Port,
+		sync:            definition.Sync,
+		transport:       newTransport(c, definition),
 		universeNumbers: definition.Universes,
-		universePackets: make(map[int]*packet.ArtDMXPacket),
+		universeBuffers: make(map[int]*[512]byte),
+		sequences:       make(map[int]uint8),
+
+		staged:    make(map[int]*[512]byte),
+		outgoing:  make(map[int]*[512]byte),
+		pending:   make(map[int]struct{}),
+		stagingMu: &sync.Mutex{},
 
 		shutdowns: nil,
 		wg:        &sync.WaitGroup{},
 		mu:        &sync.RWMutex{},
 
+		healthMu: &sync.RWMutex{},
+
 		pollChan: nil,
-		sendChan: nil,
+		wake:     nil,
 		conn:     nil,
 	}
 	for _, u := range definition.Universes {
-		address := artnet.Address{
-			Net:    uint8(u >> 8 & 0xFF),
-			SubUni: uint8(u & 0xFF),
-		}
-		artNetPacket := &packet.ArtDMXPacket{
-			Sequence: 0,
-			SubUni:   address.SubUni,
-			Net:      address.Net,
-			Length:   0,
-			Data:     [512]byte{},
-		}
-		n.universePackets[u] = artNetPacket
+		buffer := &[512]byte{}
+		n.universeBuffers[u] = buffer
+		n.staged[u] = &[512]byte{}
+		n.outgoing[u] = &[512]byte{}
 		// add node to controller maps
-		c.universeBufferMap[u] = &artNetPacket.Data
+		c.universeBufferMap[u] = buffer
 		c.universeNodeMap[u] = n
 	}
 	return n
@@ -86,10 +113,7 @@ func (n *node) reset() {
 }
 
 func (n *node) getDefinition() types.NodeDefinition {
-	return types.NodeDefinition{
-		Address:   n.address,
-		Universes: n.universeNumbers,
-	}
+	return n.definition
 }
 
 func (n *node) runMainLoop() {
@@ -101,6 +125,7 @@ func (n *node) runMainLoop() {
 		err := n.runSendLoop()
 		if err != nil {
 			log.Println(fmt.Sprintf("controller, node, runMainLoop: received error; %s", err.Error()))
+			n.c.bus.EmitNodeError(n.address, err)
 		}
 		select {
 		case _, ok := <-n.shutdowns:
@@ -119,24 +144,43 @@ func (n *node) runSendLoop() error {
 	if err != nil {
 		return err
 	}
+	// shutdown clears the node's channels from another goroutine, so select on what setup handed out
+	n.mu.RLock()
+	pollChan, wake := n.pollChan, n.wake
+	n.mu.RUnlock()
+	// announce ourselves right away rather than waiting on the next poll period
+	err = n.sendPoll()
+	if err != nil {
+		return errors.New(fmt.Sprintf("couldn't send poll; %s", err.Error()))
+	}
 	for {
 		select {
 		case _, ok := <-n.shutdowns:
 			if !ok {
 				return nil
 			}
-		case _, ok := <-n.pollChan:
+		case _, ok := <-pollChan:
 			if !ok {
 				return errors.New("poll chan unexpectedly closed")
 			}
-			// handle poll
-		case u, ok := <-n.sendChan:
+			err = n.sendPoll()
+			if err != nil {
+				return errors.New(fmt.Sprintf("couldn't send poll; %s", err.Error()))
+			}
+		case _, ok := <-wake:
 			if !ok {
-				return errors.New("send chan unexpectedly closed")
+				return errors.New("wake chan unexpectedly closed")
 			}
-			err = n.sendUniverseUpdate(u)
-			if err != nil {
-				return errors.New(fmt.Sprintf("couldn't send full universe update %d", u))
+			universes, f := n.takePending()
+			for _, u := range universes {
+				err = n.sendUniverseUpdate(u)
+				if err != nil {
+					return errors.New(fmt.Sprintf("couldn't send full universe update %d; %s", u, err.Error()))
+				}
+			}
+			// every universe staged ahead of the marker is out
+			if f != nil {
+				f.arrive()
 			}
 		}
 	}
@@ -144,22 +188,25 @@ func (n *node) runSendLoop() error {
 
 func (n *node) sendUniverseUpdate(u int) error {
 	// universe guaranteed to be on node because it's coordinated by service
-	b, err := n.universePackets[u].MarshalBinary()
+	sequence := n.transport.nextSequence(n.sequences[u])
+	n.sequences[u] = sequence
+	b, err := n.transport.marshalUniverse(u, sequence, n.outgoing[u])
 	if err != nil {
 		return err
 	}
-	return n.doSendPacket(b)
+	err = n.doSendPacket(b, n.transport.universeDestination(u))
+	if err == nil {
+		atomic.AddUint64(&n.stats.sent, 1)
+	}
+	return err
 }
 
-func (n *node) doSendPacket(packet []byte) error {
-	for len(packet) > 0 {
-		b, err := n.conn.Write(packet)
-		if err != nil {
-			return err
-		} else if b == 0 {
-			return errors.New("couldn't write push payload")
-		}
-		packet = packet[b:]
+func (n *node) doSendPacket(packet []byte, destination *net.UDPAddr) error {
+	b, err := n.conn.WriteTo(packet, destination)
+	if err != nil {
+		return err
+	} else if b != len(packet) {
+		return errors.New("couldn't write push payload")
 	}
 	return nil
 }
@@ -167,14 +214,15 @@ func (n *node) doSendPacket(packet []byte) error {
 func (n *node) setupNodeLoop() error {
 	n.mu.Lock()
 	defer n.mu.Unlock()
-	addr := fmt.Sprintf("%s:%d", n.address, packet.ArtNetPort)
-	conn, err := net.Dial("udp", addr)
+	conn, err := n.c.dial()
 	if err != nil {
 		return err
 	}
 	n.conn = conn
+	n.wg.Add(1)
+	go n.runReplyLoop(conn)
 	n.pollChan = make(chan struct{}, 5)
-	n.sendChan = make(chan int, 10)
+	n.wake = make(chan struct{}, 1)
 	return nil
 }
 
@@ -185,5 +233,80 @@ func (n *node) cleanupNodeLoop() {
 		_ = n.conn.Close()
 	}
 	n.pollChan = nil
-	n.sendChan = nil
+	n.wake = nil
+	// whatever was staged never made it out
+	n.stagingMu.Lock()
+	atomic.AddUint64(&n.stats.dropped, uint64(len(n.pending)))
+	n.pending = make(map[int]struct{})
+	n.pendingSync = nil
+	n.stagingMu.Unlock()
+}
+
+/*
+	staging never blocks the caller; the event loop snapshots a universe and moves on, and the node loop sends
+	whatever is staged when it gets to it, so a stalled node loses frames instead of stalling the bus
+*/
+
+// stageUniverse snapshots the universe for the node loop, reporting false if there's no loop to send it
+func (n *node) stageUniverse(u int) bool {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	if n.wake == nil {
+		atomic.AddUint64(&n.stats.dropped, 1)
+		return false
+	}
+	n.stagingMu.Lock()
+	if _, ok := n.pending[u]; ok {
+		atomic.AddUint64(&n.stats.coalesced, 1)
+	}
+	n.pending[u] = struct{}{}
+	*n.staged[u] = *n.universeBuffers[u]
+	n.stagingMu.Unlock()
+	n.signalWake()
+	return true
+}
+
+// stageFrameSync marks the end of a frame; a sync still pending belongs to a frame that got coalesced, so
+// it's replaced and its barrier never releases
+func (n *node) stageFrameSync(f *frameSync) bool {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	if n.wake == nil {
+		return false
+	}
+	n.stagingMu.Lock()
+	n.pendingSync = f
+	n.stagingMu.Unlock()
+	n.signalWake()
+	return true
+}
+
+func (n *node) signalWake() {
+	select {
+	case n.wake <- struct{}{}:
+	default:
+	}
+}
+
+// takePending moves staged universes to the loop's outgoing buffers, in definition order
+func (n *node) takePending() ([]int, *frameSync) {
+	n.stagingMu.Lock()
+	defer n.stagingMu.Unlock()
+	universes := make([]int, 0, len(n.pending))
+	for _, u := range n.universeNumbers {
+		if _, ok := n.pending[u]; !ok {
+			continue
+		}
+		*n.outgoing[u] = *n.staged[u]
+		universes = append(universes, u)
+	}
+	n.pending = make(map[int]struct{})
+	f := n.pendingSync
+	n.pendingSync = nil
+	return universes, f
+}
+
+func (n *node) getStats() (sent, coalesced, dropped uint64) {
+	return atomic.LoadUint64(&n.stats.sent), atomic.LoadUint64(&n.stats.coalesced),
+		atomic.LoadUint64(&n.stats.dropped)
 }
@@ -0,0 +1,375 @@
+package controller
+
+import (
+	"errors"
+	"github.com/jsimonetti/go-artnet/packet"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/domain"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/types"
+	"net"
+	"sync"
+	"testing"
+	"time"
+)
+
+/*
+	recordingDialer stands in for the network; every conn it hands out writes into the same log, the next
+	failWrites writes error out so tests can knock a node loop over, and writes wait on stall while it's set
+*/
+
+type recordedPacket struct {
+	destination string
+	data        []byte
+}
+
+type recordingDialer struct {
+	mu         sync.Mutex
+	dials      int
+	failWrites int
+	stall      chan struct{}
+	packets    []recordedPacket
+}
+
+func (d *recordingDialer) dial() (nodeConn, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.dials++
+	return &recordingConn{d: d, closed: make(chan struct{})}, nil
+}
+
+func (d *recordingDialer) getDials() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.dials
+}
+
+// dmxPackets are the ArtDMX packets written so far for a universe, oldest first
+func (d *recordingDialer) dmxPackets(universe int) []*packet.ArtDMXPacket {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var dmxPackets []*packet.ArtDMXPacket
+	for _, p := range d.packets {
+		parsed, err := packet.Unmarshal(p.data)
+		if err != nil {
+			continue
+		}
+		dmx, ok := parsed.(*packet.ArtDMXPacket)
+		if ok && int(dmx.Net)<<8|int(dmx.SubUni) == universe {
+			dmxPackets = append(dmxPackets, dmx)
+		}
+	}
+	return dmxPackets
+}
+
+// syncDestinations are where each ArtSync written so far went, oldest first
+func (d *recordingDialer) syncDestinations() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var destinations []string
+	for _, p := range d.packets {
+		parsed, err := packet.Unmarshal(p.data)
+		if err != nil {
+			continue
+		}
+		if _, ok := parsed.(*packet.ArtSyncPacket); ok {
+			destinations = append(destinations, p.destination)
+		}
+	}
+	return destinations
+}
+
+type recordingConn struct {
+	d         *recordingDialer
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func (c *recordingConn) WriteTo(b []byte, addr net.Addr) (int, error) {
+	c.d.mu.Lock()
+	stall := c.d.stall
+	c.d.mu.Unlock()
+	if stall != nil {
+		<-stall
+	}
+	c.d.mu.Lock()
+	defer c.d.mu.Unlock()
+	if c.d.failWrites > 0 {
+		c.d.failWrites--
+		return 0, errors.New("recording conn: write failed")
+	}
+	c.d.packets = append(c.d.packets, recordedPacket{
+		destination: addr.String(), data: append([]byte(nil), b...),
+	})
+	return len(b), nil
+}
+
+func (c *recordingConn) ReadFrom(_ []byte) (int, net.Addr, error) {
+	<-c.closed
+	return 0, nil, net.ErrClosed
+}
+
+func (c *recordingConn) Close() error {
+	c.closeOnce.Do(func() { close(c.closed) })
+	return nil
+}
+
+type recordingBus struct {
+	mu     sync.Mutex
+	errors []string
+}
+
+func (b *recordingBus) EmitNodeError(address string, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.errors = append(b.errors, address+": "+err.Error())
+}
+
+func (b *recordingBus) EmitNodeHealth(_ *domain.NodeHealth) {}
+
+func (b *recordingBus) getErrors() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]string(nil), b.errors...)
+}
+
+// newRecordingService skips NewService so nothing touches the repo or the network; the local address isn't
+// on this host, so the poller's listener won't come up either
+func newRecordingService(definitions types.NodeDefinitions) (*service, *recordingDialer, *recordingBus) {
+	d := &recordingDialer{}
+	b := &recordingBus{}
+	c := newController(b, "2.0.0.1", definitions)
+	c.dial = d.dial
+	return &service{bus: b, controller: c, nodeDefinitions: definitions}, d, b
+}
+
+func waitFor(t *testing.T, what string, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(3 * time.Second)
+	for time.Now().Before(deadline) {
+		if condition() {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %s", what)
+}
+
+func TestNode_sendUniverseUpdateWithoutLoop(t *testing.T) {
+	s, d, _ := newRecordingService(types.NodeDefinitions{
+		types.NodeDefinition{Address: "2.0.0.10", Universes: []int{0}},
+	})
+	// never started, so there's no send chan; this must return rather than block
+	s.SendUniverseUpdate(0)
+	s.BlackoutNodes()
+	s.SendFrameSync()
+	if d.getDials() != 0 || len(d.dmxPackets(0)) != 0 {
+		t.Errorf("node wrote without being started")
+	}
+}
+
+func TestNode_blackoutNodes(t *testing.T) {
+	s, d, _ := newRecordingService(types.NodeDefinitions{
+		types.NodeDefinition{Address: "2.0.0.10", Universes: []int{0, 1}},
+		types.NodeDefinition{Address: "2.0.0.11", Universes: []int{2}},
+	})
+	s.Startup()
+	defer s.Shutdown()
+	for _, u := range []int{0, 1, 2} {
+		buffer, _ := s.GetUniverseBuffer(u)
+		buffer[10] = 200
+	}
+	waitFor(t, "nodes to dial", func() bool { return d.getDials() == 2 })
+
+	s.BlackoutNodes()
+	for _, u := range []int{0, 1, 2} {
+		u := u
+		waitFor(t, "blackout packet", func() bool { return len(d.dmxPackets(u)) == 1 })
+		if p := d.dmxPackets(u)[0]; p.Data[10] != 0 {
+			t.Errorf("universe %d wasn't blacked out", u)
+		}
+		if buffer, _ := s.GetUniverseBuffer(u); buffer[10] != 0 {
+			t.Errorf("universe %d buffer wasn't cleared", u)
+		}
+	}
+}
+
+func TestNode_blackoutNodesSyncs(t *testing.T) {
+	s, d, _ := newRecordingService(types.NodeDefinitions{
+		types.NodeDefinition{Address: "2.0.0.10", Universes: []int{0, 1}, Sync: true},
+	})
+	s.Startup()
+	defer s.Shutdown()
+	// the sync socket, then the node
+	waitFor(t, "node to dial", func() bool { return d.getDials() == 2 })
+
+	s.BlackoutNodes()
+	waitFor(t, "blackout sync", func() bool { return len(d.syncDestinations()) == 1 })
+	if len(d.dmxPackets(0)) != 1 || len(d.dmxPackets(1)) != 1 {
+		t.Errorf("sync went out before the blacked out universes")
+	}
+	if destination := d.syncDestinations()[0]; destination != "2.255.255.255:6454" {
+		t.Errorf("sync went to %s; expected the art-net broadcast", destination)
+	}
+}
+
+func TestNode_reconnectsAfterWriteError(t *testing.T) {
+	s, d, b := newRecordingService(types.NodeDefinitions{
+		types.NodeDefinition{Address: "2.0.0.10", Universes: []int{0}},
+	})
+	// the poll sent right after setup fails, so the loop should tear down, report, and dial again
+	d.failWrites = 1
+	s.Startup()
+	defer s.Shutdown()
+
+	waitFor(t, "redial", func() bool { return d.getDials() == 2 })
+	if errs := b.getErrors(); len(errs) != 1 {
+		t.Errorf("node errors = %v", errs)
+	}
+	waitFor(t, "update after redial", func() bool {
+		s.SendUniverseUpdate(0)
+		return len(d.dmxPackets(0)) > 0
+	})
+}
+
+func TestNode_startupShutdownResetRace(t *testing.T) {
+	s, d, _ := newRecordingService(types.NodeDefinitions{
+		types.NodeDefinition{Address: "2.0.0.10", Universes: []int{0, 1}, Sync: true},
+		types.NodeDefinition{Address: "2.0.0.11", Universes: []int{2}},
+	})
+	s.Startup()
+
+	// senders hammer the nodes while they're torn down and brought back; run with -race
+	stop := make(chan struct{})
+	wg := &sync.WaitGroup{}
+	for _, u := range []int{0, 1, 2} {
+		wg.Add(1)
+		go func(u int) {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+					s.SendUniverseUpdate(u)
+				}
+			}
+		}(u)
+	}
+	for i := 0; i < 20; i++ {
+		for _, n := range s.controller.nodes {
+			n.reset()
+		}
+	}
+	s.Shutdown()
+	close(stop)
+	wg.Wait()
+
+	// the sync socket and two initial dials, then one per reset per node
+	if dials := d.getDials(); dials != 43 {
+		t.Errorf("dials = %d; expected 43", dials)
+	}
+	// fully shut down, sends go nowhere
+	before := len(d.dmxPackets(0))
+	s.SendUniverseUpdate(0)
+	if after := len(d.dmxPackets(0)); after != before {
+		t.Errorf("node wrote after shutdown")
+	}
+}
+
+func TestNode_coalescesWhileStalled(t *testing.T) {
+	s, d, _ := newRecordingService(types.NodeDefinitions{
+		types.NodeDefinition{Address: "2.0.0.10", Universes: []int{0}},
+	})
+	s.Startup()
+	defer s.Shutdown()
+	// wait out the poll sent on setup, then wedge the node's writes
+	waitFor(t, "setup poll", func() bool {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		return len(d.packets) > 0
+	})
+	stall := make(chan struct{})
+	d.mu.Lock()
+	d.stall = stall
+	d.mu.Unlock()
+
+	buffer, _ := s.GetUniverseBuffer(0)
+	buffer[0] = 1
+	s.SendUniverseUpdate(0)
+	// the first frame is taken and stuck in the write; the rest pile up on a single staged universe
+	waitFor(t, "stuck write", func() bool {
+		s.controller.nodes[0].stagingMu.Lock()
+		defer s.controller.nodes[0].stagingMu.Unlock()
+		return len(s.controller.nodes[0].pending) == 0
+	})
+	for i := byte(2); i <= 4; i++ {
+		buffer[0] = i
+		s.SendUniverseUpdate(0)
+	}
+	health := s.GetNodeHealth()[0]
+	if health.UniversesCoalesced != 2 {
+		t.Errorf("coalesced = %d; expected 2", health.UniversesCoalesced)
+	}
+
+	d.mu.Lock()
+	d.stall = nil
+	d.mu.Unlock()
+	close(stall)
+	waitFor(t, "stalled frames", func() bool { return len(d.dmxPackets(0)) == 2 })
+	packets := d.dmxPackets(0)
+	if packets[0].Data[0] != 1 || packets[1].Data[0] != 4 {
+		t.Errorf("sent %d then %d; expected 1 then the latest, 4", packets[0].Data[0], packets[1].Data[0])
+	}
+	if sent := s.GetNodeHealth()[0].UniversesSent; sent != 2 {
+		t.Errorf("sent = %d; expected 2", sent)
+	}
+}
+
+// failingConn's reads fail straight away until it's closed, like a socket stuck in an error state
+type failingConn struct {
+	mu     sync.Mutex
+	reads  int
+	closed bool
+}
+
+func (c *failingConn) WriteTo(b []byte, addr net.Addr) (int, error) {
+	return len(b), nil
+}
+
+func (c *failingConn) ReadFrom(b []byte) (int, net.Addr, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return 0, nil, net.ErrClosed
+	}
+	c.reads++
+	return 0, nil, errors.New("read failed")
+}
+
+func (c *failingConn) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	return nil
+}
+
+func TestNode_replyLoopBacksOffOnReadErrors(t *testing.T) {
+	n := &node{address: "127.0.0.1", wg: &sync.WaitGroup{}}
+	conn := &failingConn{}
+	n.wg.Add(1)
+	done := make(chan struct{})
+	go func() {
+		n.runReplyLoop(conn)
+		close(done)
+	}()
+	time.Sleep(200 * time.Millisecond)
+	_ = conn.Close()
+	select {
+	case <-done:
+	case <-time.After(2 * maxReadBackoff):
+		t.Fatal("reply loop didn't stop once the conn closed")
+	}
+	// 10, 20, 40, 80 ms; without the backoff this is in the millions
+	if conn.reads > 10 {
+		t.Errorf("reply loop read %d times in 200ms", conn.reads)
+	}
+}
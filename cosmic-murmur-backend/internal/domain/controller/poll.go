@@ -0,0 +1,275 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"github.com/jsimonetti/go-artnet"
+	"github.com/jsimonetti/go-artnet/packet"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/domain"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/types"
+	"log"
+	"net"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+const (
+	pollPeriod = 2 * time.Second
+	// a node that misses this many polls in a row is considered offline
+	nodeOfflineTimeout = 3 * pollPeriod
+	maxReplySize       = 1024
+	// a read error that keeps coming back, rather than the socket closing, mustn't spin; reads back off up to
+	// the max, and start over once one succeeds
+	minReadBackoff = 10 * time.Millisecond
+	maxReadBackoff = 1 * time.Second
+)
+
+type readBackoff struct {
+	delay time.Duration
+}
+
+// wait sleeps before the next read, reporting whether this was the first error since the last good read
+func (b *readBackoff) wait() (first bool) {
+	first = b.delay == 0
+	if first {
+		b.delay = minReadBackoff
+	} else if b.delay *= 2; b.delay > maxReadBackoff {
+		b.delay = maxReadBackoff
+	}
+	time.Sleep(b.delay)
+	return first
+}
+
+func (b *readBackoff) reset() {
+	b.delay = 0
+}
+
+type nodeHealth struct {
+	online        bool
+	lastSeen      time.Time
+	shortName     string
+	longName      string
+	firmware      uint16
+	portAddresses []int
+}
+
+func parsePollReply(b []byte) (*packet.ArtPollReplyPacket, bool) {
+	p, err := packet.Unmarshal(b)
+	if err != nil {
+		return nil, false
+	}
+	reply, ok := p.(*packet.ArtPollReplyPacket)
+	return reply, ok
+}
+
+/*
+	nodes are supposed to broadcast their reply to the art-net port, but plenty just answer whoever asked; the
+	controller listens on the local address for the former, and each node reads its own socket for the latter
+*/
+
+func (c *controller) startupPoller() {
+	addr := net.JoinHostPort(c.localAddress, strconv.Itoa(packet.ArtNetPort))
+	udpAddr, err := net.ResolveUDPAddr("udp", addr)
+	if err == nil {
+		c.listener, err = net.ListenUDP("udp", udpAddr)
+	}
+	if err != nil {
+		log.Println(fmt.Sprintf(
+			"Controller, startupPoller: couldn't listen at %s, only unicast poll replies will be seen; %s",
+			addr, err.Error(),
+		))
+		c.listener = nil
+	} else {
+		c.wg.Add(1)
+		go c.runListenLoop(c.listener)
+	}
+	c.wg.Add(1)
+	go c.runPollLoop()
+}
+
+func (c *controller) shutdownPoller() {
+	if c.listener != nil {
+		_ = c.listener.Close()
+	}
+	c.wg.Wait()
+	c.listener = nil
+}
+
+func (c *controller) runListenLoop(listener *net.UDPConn) {
+	defer func() {
+		log.Println("Controller, runListenLoop, Listen Loop: closed")
+		c.wg.Done()
+	}()
+	buf := make([]byte, maxReplySize)
+	var backoff readBackoff
+	for {
+		n, from, err := listener.ReadFromUDP(buf)
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			if first := backoff.wait(); first {
+				log.Println(fmt.Sprintf("Controller, runListenLoop: read failed; %s", err.Error()))
+			}
+			continue
+		}
+		backoff.reset()
+		reply, ok := parsePollReply(buf[:n])
+		if !ok {
+			continue
+		}
+		c.recordDiscovery(from.IP.String(), 0, reply, time.Now())
+		for _, nd := range c.nodes {
+			if nd.definition.Protocol == types.ProtocolSacn {
+				continue
+			}
+			if ip := net.ParseIP(nd.address); ip != nil && ip.Equal(from.IP) {
+				nd.recordPollReply(reply, time.Now())
+			}
+		}
+	}
+}
+
+func (c *controller) runPollLoop() {
+	defer func() {
+		log.Println("Controller, runPollLoop, Poll Loop: closed")
+		c.wg.Done()
+	}()
+	ticker := time.NewTicker(pollPeriod)
+	defer ticker.Stop()
+	for {
+		c.broadcastPoll()
+		for _, n := range c.nodes {
+			n.requestPoll()
+			n.checkOffline(time.Now())
+		}
+		select {
+		case <-c.shutdowns:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// broadcastPoll finds nodes we don't have definitions for yet
+func (c *controller) broadcastPoll() {
+	if c.listener == nil {
+		return
+	}
+	b, err := (&packet.ArtPollPacket{}).MarshalBinary()
+	if err != nil {
+		return
+	}
+	_, err = c.listener.WriteToUDP(b, &net.UDPAddr{IP: artNetBroadcast, Port: packet.ArtNetPort})
+	if err != nil {
+		log.Println(fmt.Sprintf("Controller, broadcastPoll: couldn't broadcast; %s", err.Error()))
+	}
+}
+
+func (n *node) requestPoll() {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	if n.pollChan == nil {
+		return
+	}
+	// a poll still waiting to go out is as good as a new one
+	select {
+	case n.pollChan <- struct{}{}:
+	default:
+	}
+}
+
+func (n *node) sendPoll() error {
+	b, destination, err := n.transport.marshalPoll()
+	if err != nil || b == nil {
+		return err
+	}
+	return n.doSendPacket(b, destination)
+}
+
+func (n *node) runReplyLoop(conn nodeConn) {
+	defer n.wg.Done()
+	buf := make([]byte, maxReplySize)
+	var backoff readBackoff
+	for {
+		b, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			// closed by cleanupNodeLoop; a refused read just means nothing is listening yet, the poll loop
+			// will notice the node is offline
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			if first := backoff.wait(); first && !errors.Is(err, syscall.ECONNREFUSED) {
+				log.Println(fmt.Sprintf("controller, node, runReplyLoop: %s read failed; %s", n.address, err.Error()))
+			}
+			continue
+		}
+		backoff.reset()
+		if reply, ok := parsePollReply(buf[:b]); ok {
+			n.recordPollReply(reply, time.Now())
+		}
+	}
+}
+
+func (n *node) recordPollReply(reply *packet.ArtPollReplyPacket, now time.Time) {
+	config := artnet.ConfigFromArtPollReply(*reply)
+	portAddresses := outputPortAddresses(reply)
+	n.c.recordDiscovery(n.address, n.port, reply, now)
+	n.healthMu.Lock()
+	wasOnline := n.health.online
+	n.health = nodeHealth{
+		online:        true,
+		lastSeen:      now,
+		shortName:     config.Name,
+		longName:      config.Description,
+		firmware:      config.Version,
+		portAddresses: portAddresses,
+	}
+	n.healthMu.Unlock()
+	if !wasOnline {
+		log.Println(fmt.Sprintf("controller, node, recordPollReply: %s is online", n.address))
+		n.c.bus.EmitNodeHealth(n.getHealth())
+	}
+}
+
+func (n *node) checkOffline(now time.Time) {
+	n.healthMu.Lock()
+	wentOffline := n.health.online && now.Sub(n.health.lastSeen) > nodeOfflineTimeout
+	if wentOffline {
+		n.health.online = false
+	}
+	n.healthMu.Unlock()
+	if wentOffline {
+		log.Println(fmt.Sprintf("controller, node, checkOffline: %s hasn't replied to polls; offline", n.address))
+		n.c.bus.EmitNodeHealth(n.getHealth())
+	}
+}
+
+func (n *node) protocol() types.NodeProtocol {
+	if n.definition.Protocol == "" {
+		return types.ProtocolArtNet
+	}
+	return n.definition.Protocol
+}
+
+func (n *node) getHealth() *domain.NodeHealth {
+	sent, coalesced, dropped := n.getStats()
+	n.healthMu.RLock()
+	defer n.healthMu.RUnlock()
+	return &domain.NodeHealth{
+		Address:       n.address,
+		Port:          n.port,
+		Protocol:      n.protocol(),
+		Online:        n.health.online,
+		LastSeen:      n.health.lastSeen,
+		ShortName:     n.health.shortName,
+		LongName:      n.health.longName,
+		Firmware:      n.health.firmware,
+		PortAddresses: append([]int(nil), n.health.portAddresses...),
+
+		UniversesSent:      sent,
+		UniversesCoalesced: coalesced,
+		UniversesDropped:   dropped,
+	}
+}
@@ -0,0 +1,152 @@
+package controller
+
+import (
+	"crypto/sha1"
+	"encoding/binary"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/types"
+	"net"
+	"sync/atomic"
+)
+
+/*
+	sACN / E1.31; universes on the wire are numbered from 1, so our universe n goes out as sACN universe n + 1.
+	Packet layouts are from ANSI E1.31-2018 section 4
+*/
+
+const (
+	sacnPort            = 5568
+	sacnDefaultPriority = 100
+	sacnMaxPriority     = 200
+	sacnMaxUniverse     = 63999
+	sacnSourceName      = "Cosmic Murmur"
+
+	sacnDataPacketSize = 638
+	sacnSyncPacketSize = 49
+
+	sacnVectorRootData         = 0x00000004
+	sacnVectorRootExtended     = 0x00000008
+	sacnVectorFramingData      = 0x00000002
+	sacnVectorFramingSync      = 0x00000001
+	sacnVectorDmpSetProperty   = 0x02
+	sacnDmpAddressAndDataTypes = 0xa1
+)
+
+var sacnPacketIdentifier = [12]byte{'A', 'S', 'C', '-', 'E', '1', '.', '1', '7', 0, 0, 0}
+
+type sacnTransport struct {
+	address      net.IP
+	port         int
+	multicast    bool
+	priority     uint8
+	sourceName   [64]byte
+	syncUniverse uint16
+	cid          [16]byte
+
+	syncSequence uint32
+}
+
+func newSacnTransport(c *controller, definition types.NodeDefinition) *sacnTransport {
+	t := &sacnTransport{
+		address:   net.ParseIP(definition.Address),
+		port:      definition.Port,
+		multicast: definition.Multicast,
+		priority:  uint8(definition.Priority),
+		cid:       sacnCid(c.localAddress),
+	}
+	if t.port == 0 {
+		t.port = sacnPort
+	}
+	if t.priority == 0 {
+		t.priority = sacnDefaultPriority
+	}
+	sourceName := definition.SourceName
+	if sourceName == "" {
+		sourceName = sacnSourceName
+	}
+	// the name is null terminated, so at most 63 bytes of it fit
+	copy(t.sourceName[:63], sourceName)
+	if definition.Sync {
+		t.syncUniverse = uint16(definition.SyncUniverse)
+	}
+	return t
+}
+
+// sacnCid is the source's component id; receivers track sources by it, so it has to stay the same across
+// restarts, hence derived rather than random
+func sacnCid(localAddress string) (cid [16]byte) {
+	sum := sha1.Sum([]byte("cosmic-murmur:" + localAddress))
+	copy(cid[:], sum[:16])
+	// mark it as a name based (v5) uuid
+	cid[6] = cid[6]&0x0F | 0x50
+	cid[8] = cid[8]&0x3F | 0x80
+	return cid
+}
+
+func sacnMulticastAddress(sacnUniverse uint16) net.IP {
+	return net.IPv4(239, 255, byte(sacnUniverse>>8), byte(sacnUniverse))
+}
+
+func (t *sacnTransport) destination(sacnUniverse uint16) *net.UDPAddr {
+	if t.multicast {
+		return &net.UDPAddr{IP: sacnMulticastAddress(sacnUniverse), Port: t.port}
+	}
+	return &net.UDPAddr{IP: t.address, Port: t.port}
+}
+
+func (t *sacnTransport) universeDestination(universe int) *net.UDPAddr {
+	return t.destination(uint16(universe + 1))
+}
+
+func (t *sacnTransport) marshalUniverse(universe int, sequence uint8, data *[512]byte) ([]byte, error) {
+	b := make([]byte, sacnDataPacketSize)
+	t.putRootLayer(b, sacnVectorRootData)
+	// framing layer
+	binary.BigEndian.PutUint16(b[38:40], 0x7000|uint16(sacnDataPacketSize-38))
+	binary.BigEndian.PutUint32(b[40:44], sacnVectorFramingData)
+	copy(b[44:108], t.sourceName[:])
+	b[108] = t.priority
+	binary.BigEndian.PutUint16(b[109:111], t.syncUniverse)
+	b[111] = sequence
+	b[112] = 0
+	binary.BigEndian.PutUint16(b[113:115], uint16(universe+1))
+	// dmp layer
+	binary.BigEndian.PutUint16(b[115:117], 0x7000|uint16(sacnDataPacketSize-115))
+	b[117] = sacnVectorDmpSetProperty
+	b[118] = sacnDmpAddressAndDataTypes
+	binary.BigEndian.PutUint16(b[119:121], 0)
+	binary.BigEndian.PutUint16(b[121:123], 1)
+	binary.BigEndian.PutUint16(b[123:125], 513)
+	// start code 0 is plain dimmer data
+	b[125] = 0
+	copy(b[126:], data[:])
+	return b, nil
+}
+
+// marshalSync counts its own sequence; the sync universe is a stream of its own
+func (t *sacnTransport) marshalSync() []byte {
+	b := make([]byte, sacnSyncPacketSize)
+	t.putRootLayer(b, sacnVectorRootExtended)
+	binary.BigEndian.PutUint16(b[38:40], 0x7000|uint16(sacnSyncPacketSize-38))
+	binary.BigEndian.PutUint32(b[40:44], sacnVectorFramingSync)
+	b[44] = uint8(atomic.AddUint32(&t.syncSequence, 1))
+	binary.BigEndian.PutUint16(b[45:47], t.syncUniverse)
+	return b
+}
+
+func (t *sacnTransport) putRootLayer(b []byte, vector uint32) {
+	binary.BigEndian.PutUint16(b[0:2], 0x0010)
+	binary.BigEndian.PutUint16(b[2:4], 0)
+	copy(b[4:16], sacnPacketIdentifier[:])
+	binary.BigEndian.PutUint16(b[16:18], 0x7000|uint16(len(b)-16))
+	binary.BigEndian.PutUint32(b[18:22], vector)
+	copy(b[22:38], t.cid[:])
+}
+
+// nextSequence uses the whole byte; unlike art-net, 0 is an ordinary sequence number
+func (t *sacnTransport) nextSequence(sequence uint8) uint8 {
+	return sequence + 1
+}
+
+func (t *sacnTransport) marshalPoll() ([]byte, *net.UDPAddr, error) {
+	return nil, nil, nil
+}
@@ -0,0 +1,73 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/binary"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/types"
+	"testing"
+)
+
+func TestSacnTransport_marshalUniverse(t *testing.T) {
+	c := &controller{localAddress: "2.0.0.1"}
+	tr := newSacnTransport(c, types.NodeDefinition{
+		Address: "2.0.0.20", Protocol: types.ProtocolSacn, Sync: true, SyncUniverse: 7,
+	})
+	data := &[512]byte{}
+	data[0], data[511] = 0x11, 0x22
+	b, err := tr.marshalUniverse(2, 9, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(b) != sacnDataPacketSize {
+		t.Fatalf("packet is %d bytes", len(b))
+	}
+	if !bytes.Equal(b[4:16], sacnPacketIdentifier[:]) {
+		t.Errorf("packet identifier = %v", b[4:16])
+	}
+	// flags and length, 0x7 in the high nibble
+	if got := binary.BigEndian.Uint16(b[16:18]); got != 0x7000|622 {
+		t.Errorf("root flags and length = %x", got)
+	}
+	if !bytes.Equal(b[44:57], []byte(sacnSourceName)) || b[57] != 0 {
+		t.Errorf("source name = %q", b[44:108])
+	}
+	if b[108] != sacnDefaultPriority {
+		t.Errorf("priority = %d", b[108])
+	}
+	if got := binary.BigEndian.Uint16(b[109:111]); got != 7 {
+		t.Errorf("sync address = %d", got)
+	}
+	if b[111] != 9 {
+		t.Errorf("sequence = %d", b[111])
+	}
+	// universe 2 is sACN universe 3
+	if got := binary.BigEndian.Uint16(b[113:115]); got != 3 {
+		t.Errorf("universe = %d", got)
+	}
+	if got := binary.BigEndian.Uint16(b[123:125]); got != 513 {
+		t.Errorf("property value count = %d", got)
+	}
+	if b[125] != 0 || b[126] != 0x11 || b[637] != 0x22 {
+		t.Errorf("start code / data = %x %x %x", b[125], b[126], b[637])
+	}
+
+	s := tr.marshalSync()
+	if len(s) != sacnSyncPacketSize || binary.BigEndian.Uint16(s[45:47]) != 7 || s[44] != 1 {
+		t.Errorf("sync packet = %v", s)
+	}
+}
+
+func TestSacnTransport_destination(t *testing.T) {
+	c := &controller{localAddress: "2.0.0.1"}
+	unicast := newSacnTransport(c, types.NodeDefinition{Address: "2.0.0.20", Protocol: types.ProtocolSacn})
+	if got := unicast.universeDestination(300).String(); got != "2.0.0.20:5568" {
+		t.Errorf("unicast destination = %s", got)
+	}
+	multicast := newSacnTransport(c, types.NodeDefinition{
+		Address: "2.0.0.20", Protocol: types.ProtocolSacn, Multicast: true,
+	})
+	// sACN universe 301 is 0x012D
+	if got := multicast.universeDestination(300).String(); got != "239.255.1.45:5568" {
+		t.Errorf("multicast destination = %s", got)
+	}
+}
@@ -1,9 +1,13 @@
 package controller
 
 import (
+	"fmt"
 	"github.com/polis-interactive/2023-CosmicMurmur/internal/domain"
 	"github.com/polis-interactive/2023-CosmicMurmur/internal/types"
 	"log"
+	"net"
+	"strconv"
+	"time"
 )
 
 type service struct {
@@ -15,6 +19,7 @@ type service struct {
 	nodeDefinitions types.NodeDefinitions
 
 	controller *controller
+	proposal   *domain.NodeDefinitionsProposal
 }
 
 var _ domain.ControllerService = (*service)(nil)
@@ -55,39 +60,30 @@ func (s *service) initializeVariables() {
 }
 
 func (s *service) doCreateController() {
-	s.controller = newController(s.localAddress, s.nodeDefinitions)
+	s.controller = newController(s.bus, s.localAddress, s.nodeDefinitions)
 }
 
 func (s *service) Startup() {
-	for _, n := range s.controller.nodes {
-		n.startup()
-	}
+	s.controller.startup()
 }
 
 func (s *service) Shutdown() {
-	for _, n := range s.controller.nodes {
-		n.shutdown()
-	}
+	s.controller.shutdown()
 }
 
 func (s *service) BlackoutNodes() {
 	for _, n := range s.controller.nodes {
-		func() {
-			n.mu.RLock()
-			defer n.mu.RUnlock()
-			if n.sendChan == nil {
-				return
-			}
-			for _, u := range n.universeNumbers {
-				data := n.universePackets[u].Data
-				// loop is optimized in assembly by go
-				for i := range data {
-					data[i] = 0
-				}
-				n.sendChan <- u
+		for _, u := range n.universeNumbers {
+			data := n.universeBuffers[u]
+			// loop is optimized in assembly by go
+			for i := range data {
+				data[i] = 0
 			}
-		}()
+			n.stageUniverse(u)
+		}
 	}
+	// nodes that sync would otherwise hold the last frame until their own sync timeout
+	s.controller.sendFrameSync()
 }
 
 func (s *service) GetUniverseBuffer(universe int) (*[512]byte, bool) {
@@ -98,12 +94,11 @@ func (s *service) GetUniverseBuffer(universe int) (*[512]byte, bool) {
 }
 
 func (s *service) SendUniverseUpdate(universe int) {
-	n := s.controller.universeNodeMap[universe]
-	n.mu.RLock()
-	defer n.mu.RUnlock()
-	if n.sendChan != nil {
-		n.sendChan <- universe
-	}
+	s.controller.universeNodeMap[universe].stageUniverse(universe)
+}
+
+func (s *service) SendFrameSync() {
+	s.controller.sendFrameSync()
 }
 
 func (s *service) GetSettings() *domain.ControllerSettings {
@@ -113,8 +108,136 @@ func (s *service) GetSettings() *domain.ControllerSettings {
 	}
 }
 
+func (s *service) GetNodeHealth() []*domain.NodeHealth {
+	health := make([]*domain.NodeHealth, 0, len(s.controller.nodes))
+	for _, n := range s.controller.nodes {
+		health = append(health, n.getHealth())
+	}
+	return health
+}
+
+func (s *service) ProposeNodeDefinitions(requiredUniverses []int) *domain.NodeDefinitionsProposal {
+	definitions, missingUniverses := proposeNodeDefinitions(
+		s.controller.getDiscoveredNodes(time.Now()), requiredUniverses, s.nodeDefinitions,
+	)
+	s.proposal = &domain.NodeDefinitionsProposal{
+		NodeDefinitions:  definitions,
+		MissingUniverses: missingUniverses,
+	}
+	return s.proposal
+}
+
+// AcceptNodeDefinitionsProposal applies the last proposal handed out, so the operator gets exactly what they
+// reviewed even if discovery has moved on since
+func (s *service) AcceptNodeDefinitionsProposal() error {
+	if s.proposal == nil {
+		return fmt.Errorf("%w: no node definitions have been proposed", domain.ErrNotFound)
+	}
+	err := s.SetSettings(&domain.ControllerSettings{
+		NodeDefinitions: s.proposal.NodeDefinitions,
+		LocalAddress:    s.localAddress,
+	})
+	if err != nil {
+		return err
+	}
+	s.proposal = nil
+	return nil
+}
+
+// art-net port addresses are 15 bits; net, sub net and universe
+const maxUniverse = 0x7FFF
+
+const maxPort = 0xFFFF
+
+func validateSettings(settings *domain.ControllerSettings) error {
+	if net.ParseIP(settings.LocalAddress) == nil {
+		return fmt.Errorf("%w: local address %q is not an ip", domain.ErrInvalidSettings, settings.LocalAddress)
+	}
+	if len(settings.NodeDefinitions) == 0 {
+		return fmt.Errorf("%w: no nodes defined", domain.ErrInvalidSettings)
+	}
+	// nodes may share an address when they're on different ports, e.g. several local receivers
+	seenAddresses := make(map[string]struct{})
+	seenUniverses := make(map[int]string)
+	for _, definition := range settings.NodeDefinitions {
+		ip := net.ParseIP(definition.Address)
+		if ip == nil {
+			return fmt.Errorf("%w: node address %q is not an ip", domain.ErrInvalidSettings, definition.Address)
+		}
+		if definition.Port < 0 || definition.Port > maxPort {
+			return fmt.Errorf(
+				"%w: node %s port %d is out of range", domain.ErrInvalidSettings, definition.Address, definition.Port,
+			)
+		}
+		endpoint := net.JoinHostPort(ip.String(), strconv.Itoa(definition.Port))
+		if _, ok := seenAddresses[endpoint]; ok {
+			return fmt.Errorf("%w: node address %s is defined twice", domain.ErrInvalidSettings, definition.Address)
+		}
+		seenAddresses[endpoint] = struct{}{}
+		if len(definition.Universes) == 0 {
+			return fmt.Errorf("%w: node %s has no universes", domain.ErrInvalidSettings, definition.Address)
+		}
+		universeLimit := maxUniverse
+		switch definition.Protocol {
+		case "", types.ProtocolArtNet:
+			if definition.Multicast {
+				return fmt.Errorf(
+					"%w: node %s can't multicast over art-net", domain.ErrInvalidSettings, definition.Address,
+				)
+			}
+		case types.ProtocolSacn:
+			err := validateSacnDefinition(definition)
+			if err != nil {
+				return err
+			}
+			universeLimit = sacnMaxUniverse - 1
+		default:
+			return fmt.Errorf(
+				"%w: node %s protocol %q is unknown", domain.ErrInvalidSettings, definition.Address, definition.Protocol,
+			)
+		}
+		for _, u := range definition.Universes {
+			if u < 0 || u > universeLimit {
+				return fmt.Errorf(
+					"%w: node %s universe %d is out of range", domain.ErrInvalidSettings, definition.Address, u,
+				)
+			}
+			if other, ok := seenUniverses[u]; ok {
+				return fmt.Errorf(
+					"%w: universe %d is on both %s and %s", domain.ErrInvalidSettings, u, other, definition.Address,
+				)
+			}
+			seenUniverses[u] = definition.Address
+		}
+	}
+	return nil
+}
+
+func validateSacnDefinition(definition types.NodeDefinition) error {
+	if definition.Priority < 0 || definition.Priority > sacnMaxPriority {
+		return fmt.Errorf(
+			"%w: node %s priority %d is out of range", domain.ErrInvalidSettings, definition.Address,
+			definition.Priority,
+		)
+	}
+	if len(definition.SourceName) > 63 {
+		return fmt.Errorf("%w: node %s source name is too long", domain.ErrInvalidSettings, definition.Address)
+	}
+	if definition.Sync && (definition.SyncUniverse < 1 || definition.SyncUniverse > sacnMaxUniverse) {
+		return fmt.Errorf(
+			"%w: node %s syncs but sync universe %d is out of range", domain.ErrInvalidSettings,
+			definition.Address, definition.SyncUniverse,
+		)
+	}
+	return nil
+}
+
 func (s *service) SetSettings(settings *domain.ControllerSettings) error {
-	err := s.repo.SetControllerNodeDefinitions(settings.NodeDefinitions)
+	err := validateSettings(settings)
+	if err != nil {
+		return err
+	}
+	err = s.repo.SetControllerNodeDefinitions(settings.NodeDefinitions)
 	if err != nil {
 		return err
 	}
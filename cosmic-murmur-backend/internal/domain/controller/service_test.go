@@ -0,0 +1,131 @@
+package controller
+
+import (
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/domain"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/infrastructure/receiver"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/infrastructure/repository/memory"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/types"
+	"testing"
+	"time"
+)
+
+type testConfig struct {
+	nodeDefinitions types.NodeDefinitions
+}
+
+func (c *testConfig) GetControllerLocalAddress() string {
+	return "127.0.0.1"
+}
+
+func (c *testConfig) GetControllerNodeDefinitions() types.NodeDefinitions {
+	return c.nodeDefinitions
+}
+
+type testBus struct{}
+
+func (b *testBus) EmitNodeError(_ string, _ error) {}
+
+func (b *testBus) EmitNodeHealth(_ *domain.NodeHealth) {}
+
+func TestService_sendsToLocalReceiver(t *testing.T) {
+	r, err := receiver.NewReceiver("127.0.0.1:0", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Startup()
+	defer r.Shutdown()
+
+	s := NewService(&testConfig{
+		nodeDefinitions: types.NodeDefinitions{
+			types.NodeDefinition{Address: "127.0.0.1", Universes: []int{3, 0x102}, Port: r.Addr().Port},
+		},
+	}, memory.NewMemoryRepository(), &testBus{})
+	s.Startup()
+	defer s.Shutdown()
+
+	buffer, ok := s.GetUniverseBuffer(0x102)
+	if !ok {
+		t.Fatal("expected a buffer for universe 0x102")
+	}
+	buffer[0] = 42
+
+	// the node loop dials asynchronously; keep sending until the receiver sees the update
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		s.SendUniverseUpdate(0x102)
+		if stats, ok := r.GetUniverse(0x102); ok {
+			if stats.Data[0] != 42 {
+				t.Errorf("received %d; expected 42", stats.Data[0])
+			}
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("receiver never saw universe 0x102")
+}
+
+func TestService_tracksNodeHealthFromPolls(t *testing.T) {
+	r, err := receiver.NewReceiver("127.0.0.1:0", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Startup()
+	defer r.Shutdown()
+
+	s := NewService(&testConfig{
+		nodeDefinitions: types.NodeDefinitions{
+			types.NodeDefinition{Address: "127.0.0.1", Universes: []int{0}, Port: r.Addr().Port},
+		},
+	}, memory.NewMemoryRepository(), &testBus{})
+	if health := s.GetNodeHealth(); len(health) != 1 || health[0].Online {
+		t.Fatalf("expected one offline node before startup; health = %v", health)
+	}
+	s.Startup()
+	defer s.Shutdown()
+
+	// the first poll goes out as soon as the node loop is up
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		health := s.GetNodeHealth()[0]
+		if health.Online {
+			if health.ShortName != "artnetReceiver" || health.LastSeen.IsZero() {
+				t.Errorf("health = %+v", health)
+			}
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("node never came online")
+}
+
+func TestService_sequencesAndSyncsFrames(t *testing.T) {
+	r, err := receiver.NewReceiver("127.0.0.1:0", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Startup()
+	defer r.Shutdown()
+
+	s := NewService(&testConfig{
+		nodeDefinitions: types.NodeDefinitions{
+			types.NodeDefinition{Address: "127.0.0.1", Universes: []int{0, 1}, Port: r.Addr().Port, Sync: true},
+		},
+	}, memory.NewMemoryRepository(), &testBus{})
+	s.Startup()
+	defer s.Shutdown()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		s.SendUniverseUpdate(0)
+		s.SendUniverseUpdate(1)
+		s.SendFrameSync()
+		if stats, ok := r.GetUniverse(1); ok && stats.Packets >= 3 && r.GetSyncCount() >= 3 {
+			if stats.SequenceErrors != 0 || stats.LastSequence == 0 {
+				t.Errorf("stats = %+v", stats)
+			}
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("receiver never saw sequenced, synced frames")
+}
@@ -0,0 +1,142 @@
+package controller
+
+import (
+	"fmt"
+	"github.com/jsimonetti/go-artnet/packet"
+	"github.com/jsimonetti/go-artnet/packet/code"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/types"
+	"log"
+	"net"
+	"sync/atomic"
+)
+
+/*
+	frameSync is a barrier across the syncing nodes; each node arrives once it has written every universe staged
+	ahead of the marker, and the last one to arrive sends the ArtSync / sACN sync
+*/
+
+type frameSync struct {
+	c         *controller
+	remaining int32
+}
+
+func (f *frameSync) arrive() {
+	if atomic.AddInt32(&f.remaining, -1) == 0 {
+		f.c.broadcastSync()
+	}
+}
+
+/*
+	syncs go out on a socket of their own rather than the poll listener; the listener has to bind the art-net
+	port on the local address, and when it can't, the nodes holding their output for a sync would never show
+	a frame
+*/
+
+func (c *controller) startupSync() {
+	syncing := false
+	for _, n := range c.nodes {
+		syncing = syncing || n.sync
+	}
+	if !syncing {
+		return
+	}
+	conn, err := c.dial()
+	if err != nil {
+		log.Println(fmt.Sprintf(
+			"Controller, startupSync: couldn't open the sync socket; nodes set to sync will hold their output; %s",
+			err.Error(),
+		))
+		return
+	}
+	c.syncConn = conn
+}
+
+func (c *controller) shutdownSync() {
+	if c.syncConn != nil {
+		_ = c.syncConn.Close()
+	}
+	c.syncConn = nil
+}
+
+func (c *controller) sendFrameSync() {
+	// start at one so the frame can't complete while markers are still being queued
+	f := &frameSync{c: c, remaining: 1}
+	queued := 0
+	for _, n := range c.nodes {
+		if !n.sync {
+			continue
+		}
+		// count the node before it can arrive, and take it back if its loop isn't up
+		atomic.AddInt32(&f.remaining, 1)
+		if n.stageFrameSync(f) {
+			queued++
+		} else {
+			atomic.AddInt32(&f.remaining, -1)
+		}
+	}
+	if queued == 0 {
+		// no syncing node is up, nothing to release
+		return
+	}
+	f.arrive()
+}
+
+func (c *controller) broadcastSync() {
+	// startupSync already said why there's no socket
+	if c.syncConn == nil {
+		return
+	}
+	c.broadcastArtNetSync()
+	c.sendSacnSyncs()
+}
+
+// broadcastArtNetSync goes out on the art-net broadcast; nodes on a port override (i.e. local receivers) won't
+// hear that, so they get it directly
+func (c *controller) broadcastArtNetSync() {
+	// go-artnet doesn't fill in the op code for ArtSync when marshalling
+	b, err := (&packet.ArtSyncPacket{Header: packet.Header{OpCode: code.OpSync}}).MarshalBinary()
+	if err != nil {
+		return
+	}
+	broadcast := false
+	for _, n := range c.nodes {
+		if !n.sync || n.definition.Protocol == types.ProtocolSacn {
+			continue
+		}
+		if n.port == 0 || n.port == packet.ArtNetPort {
+			broadcast = true
+			continue
+		}
+		_, err = c.syncConn.WriteTo(b, &net.UDPAddr{IP: net.ParseIP(n.address), Port: n.port})
+		if err != nil {
+			log.Println(fmt.Sprintf("Controller, broadcastArtNetSync: couldn't send to %s; %s", n.address, err.Error()))
+		}
+	}
+	if !broadcast {
+		return
+	}
+	_, err = c.syncConn.WriteTo(b, &net.UDPAddr{IP: artNetBroadcast, Port: packet.ArtNetPort})
+	if err != nil {
+		log.Println(fmt.Sprintf("Controller, broadcastArtNetSync: couldn't broadcast; %s", err.Error()))
+	}
+}
+
+// sendSacnSyncs sends one sync per destination; multicast nodes on the same sync universe share a group
+func (c *controller) sendSacnSyncs() {
+	sent := make(map[string]struct{})
+	for _, n := range c.nodes {
+		t, ok := n.transport.(*sacnTransport)
+		if !n.sync || !ok {
+			continue
+		}
+		destination := t.destination(t.syncUniverse)
+		if _, ok = sent[destination.String()]; ok {
+			continue
+		}
+		sent[destination.String()] = struct{}{}
+		_, err := c.syncConn.WriteTo(t.marshalSync(), destination)
+		if err != nil {
+			log.Println(fmt.Sprintf("Controller, sendSacnSyncs: couldn't send to %s; %s", destination, err.Error()))
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package controller
+
+import (
+	"github.com/jsimonetti/go-artnet/packet"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/types"
+	"net"
+)
+
+// transport is the wire protocol a node speaks; the node owns the socket, sequencing and send loop, the
+// transport only says what bytes go where
+type transport interface {
+	universeDestination(universe int) *net.UDPAddr
+	marshalUniverse(universe int, sequence uint8, data *[512]byte) ([]byte, error)
+	nextSequence(sequence uint8) uint8
+	// marshalPoll returns nil when the protocol has no way to ask a node how it's doing
+	marshalPoll() ([]byte, *net.UDPAddr, error)
+}
+
+// nodeConn is the socket a node writes its packets through and reads unicast poll replies from
+type nodeConn interface {
+	WriteTo(b []byte, addr net.Addr) (int, error)
+	ReadFrom(b []byte) (int, net.Addr, error)
+	Close() error
+}
+
+// dialer hands each node loop a fresh connection; tests swap it out for a fake that records packets
+type dialer func() (nodeConn, error)
+
+func dialUdp() (nodeConn, error) {
+	// unconnected, sACN multicast sends each universe to its own group
+	conn, err := net.ListenUDP("udp", nil)
+	if err != nil {
+		return nil, err
+	}
+	return conn, nil
+}
+
+func newTransport(c *controller, definition types.NodeDefinition) transport {
+	if definition.Protocol == types.ProtocolSacn {
+		return newSacnTransport(c, definition)
+	}
+	return newArtNetTransport(definition)
+}
+
+type artNetTransport struct {
+	destination *net.UDPAddr
+}
+
+func newArtNetTransport(definition types.NodeDefinition) *artNetTransport {
+	port := definition.Port
+	if port == 0 {
+		port = packet.ArtNetPort
+	}
+	return &artNetTransport{
+		destination: &net.UDPAddr{IP: net.ParseIP(definition.Address), Port: port},
+	}
+}
+
+func (t *artNetTransport) universeDestination(_ int) *net.UDPAddr {
+	return t.destination
+}
+
+func (t *artNetTransport) marshalUniverse(universe int, sequence uint8, data *[512]byte) ([]byte, error) {
+	p := &packet.ArtDMXPacket{
+		Sequence: sequence,
+		SubUni:   uint8(universe & 0xFF),
+		Net:      uint8(universe >> 8 & 0x7F),
+		Data:     *data,
+	}
+	return p.MarshalBinary()
+}
+
+// nextSequence rolls 1 through 255; 0 tells nodes we aren't sequencing, so it's skipped on wrap
+func (t *artNetTransport) nextSequence(sequence uint8) uint8 {
+	if sequence == 255 {
+		return 1
+	}
+	return sequence + 1
+}
+
+func (t *artNetTransport) marshalPoll() ([]byte, *net.UDPAddr, error) {
+	b, err := (&packet.ArtPollPacket{}).MarshalBinary()
+	return b, t.destination, err
+}
@@ -0,0 +1,14 @@
+package domain
+
+import "errors"
+
+/*
+	sentinel errors services wrap so callers outside the domain (the api, mostly) can tell a bad request
+	apart from something that is missing or a bus that isn't answering
+*/
+
+var (
+	ErrInvalidSettings = errors.New("invalid settings")
+	ErrNotFound        = errors.New("not found")
+	ErrUnavailable     = errors.New("unavailable")
+)
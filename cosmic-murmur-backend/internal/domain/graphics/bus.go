@@ -8,4 +8,6 @@ type Bus interface {
 	GetGridDimensions() *types.Grid
 	EmitGraphicsCrashed()
 	EmitGraphicsReady()
+	EmitGraphicsShadersChanged()
+	EmitShaderError(shaderName string, err error)
 }
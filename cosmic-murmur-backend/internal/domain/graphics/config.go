@@ -1,6 +1,9 @@
 package graphics
 
-import "time"
+import (
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/types"
+	"time"
+)
 
 type Config interface {
 	GetProgramName() string
@@ -8,4 +11,7 @@ type Config interface {
 	GetGraphicsPixelSize() int
 	GetGraphicsFrequency() time.Duration
 	GetGraphicsReloadOnUpdate() bool
+	GetGraphicsTransition() types.Transition
+	GetGraphicsUseCpuRenderer() bool
+	GetServiceBusBusyTimeout() time.Duration
 }
@@ -4,13 +4,14 @@ import (
 	"errors"
 	"fmt"
 	"github.com/polis-interactive/2023-CosmicMurmur/internal/types"
-	"github.com/polis-interactive/go-lighting-utils/pkg/graphicsShader"
 	"io/ioutil"
 	"log"
+	"os"
 	"path"
 	"strings"
 	"sync"
 	"time"
+	"unsafe"
 )
 
 type Graphics struct {
@@ -22,21 +23,44 @@ type Graphics struct {
 	defaultReloadOnUpdate bool
 	defaultShader         string
 	defaultFrequency      time.Duration
+	defaultTransition     types.Transition
+	useCpuRenderer        bool
 
-	shaderList            graphicsShader.ShaderIdentifiers
+	shaderList            ShaderIdentifiers
+	shaderUniforms        map[ShaderKey][]types.ShaderUniform
+	uniformValues         map[string]map[string]float32
 	pb                    *types.PixelBuffer
-	ud                    graphicsShader.UniformDict
-	gs                    *graphicsShader.GraphicsShader
+	ud                    UniformDict
+	gs                    shaderRenderer
 	runningReloadOnUpdate bool
 	runningShader         string
 	runningFrequency      time.Duration
+	runningTransition     types.Transition
 	lastTimeStep          time.Time
+
+	// frameSize is how many pixels the renderer reads back; transition is nil unless one is in progress
+	frameSize  int
+	transition *transition
+
+	// playlist is nil unless one of playlists is running
+	playlists []types.Playlist
+	playlist  *playlistRun
+
+	// tasks run on the graphics loop, between frames; retired shaders were deleted or renamed, but the
+	// renderer can't let go of them until the loop restarts
+	tasks       chan func()
+	taskTimeout time.Duration
+	retired     map[ShaderKey]bool
 }
 
 func newGraphics(s *service, cfg Config) (*Graphics, error) {
 	programName := cfg.GetProgramName()
-	shaderPath, err := graphicsShader.GetShaderPathIfAvailable(programName)
-	if err != nil {
+	useCpuRenderer := cfg.GetGraphicsUseCpuRenderer()
+	shaderPath, err := getShaderPath(programName)
+	if err != nil && useCpuRenderer {
+		log.Println("Graphics Service, newGraphics: no shader path found; cpu renderer only uses built in patterns")
+		shaderPath = ""
+	} else if err != nil {
 		return nil, errors.New(fmt.Sprintf(
 			"Graphics Service, newGraphics: couldn't find shader path in program %s", programName,
 		))
@@ -51,6 +75,8 @@ func newGraphics(s *service, cfg Config) (*Graphics, error) {
 		defaultReloadOnUpdate: cfg.GetGraphicsReloadOnUpdate(),
 		defaultShader:         cfg.GetGraphicsDefaultShader(),
 		defaultFrequency:      cfg.GetGraphicsFrequency(),
+		defaultTransition:     cfg.GetGraphicsTransition(),
+		useCpuRenderer:        useCpuRenderer,
 
 		shaderList:            nil,
 		pb:                    nil,
@@ -59,6 +85,9 @@ func newGraphics(s *service, cfg Config) (*Graphics, error) {
 		runningReloadOnUpdate: false,
 		runningShader:         "",
 		runningFrequency:      time.Minute,
+
+		tasks:       make(chan func()),
+		taskTimeout: cfg.GetServiceBusBusyTimeout() / loopTaskShare,
 	}, nil
 }
 
@@ -85,10 +114,22 @@ CloseMainLoop:
 
 func (g *Graphics) runGraphicsLoop() error {
 	defer g.cleanupGraphicsLoop()
-	err := g.setupGraphicsLoop()
+	/*
+		the grid comes off the event loop, which may itself be waiting on g.mu (handing over a frame, say); asking
+		for it while holding the lock would stall both until the bus times out, and leave us on the default grid
+	*/
+	grid := g.s.bus.GetGridDimensions()
+	err := g.setupGraphicsLoop(grid)
+	if err != nil {
+		return err
+	}
+	files, err := g.scanShaders()
 	if err != nil {
 		return err
 	}
+	watcherDone := make(chan struct{})
+	defer close(watcherDone)
+	go g.runShaderWatcher(files, watcherDone)
 	for {
 		dur := g.getRunningFrequency()
 		select {
@@ -96,17 +137,12 @@ func (g *Graphics) runGraphicsLoop() error {
 			if !ok {
 				return nil
 			}
+		case task := <-g.tasks:
+			task()
 		case <-time.After(dur):
 			g.stepTime()
-			err = g.tryReloadShader()
-			if err != nil {
-				return err
-			}
-			err = g.doRunShader()
-			if err != nil {
-				return err
-			}
-			err = g.gs.ReadToPixels(g.pb.GetUnsafePointer())
+			g.stepPlaylist()
+			err = g.doRenderFrame()
 			if err != nil {
 				return err
 			}
@@ -115,7 +151,7 @@ func (g *Graphics) runGraphicsLoop() error {
 	}
 }
 
-func (g *Graphics) setupGraphicsLoop() error {
+func (g *Graphics) setupGraphicsLoop(grid *types.Grid) error {
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
@@ -126,23 +162,23 @@ func (g *Graphics) setupGraphicsLoop() error {
 		return errors.New(fmt.Sprintf("No shaders found in path %s", g.shaderPath))
 	}
 	g.shaderList = shaders
+	g.shaderUniforms = g.getShaderUniforms(shaders)
+	g.retired = make(map[ShaderKey]bool)
 
-	grid := g.s.bus.GetGridDimensions()
 	gridWidth := grid.MaxX - grid.MinX + 1
 	gridHeight := grid.MaxY - grid.MinY + 1
 
 	gridWidth = gridWidth * g.pixelSize
 	gridHeight = gridHeight * g.pixelSize
+	g.frameSize = gridWidth * gridHeight
 	g.pb = types.NewPixelBuffer(gridWidth, gridHeight, grid.MinX, grid.MinY, g.pixelSize)
 
-	g.ud = make(graphicsShader.UniformDict)
+	g.ud = make(UniformDict)
 	g.lastTimeStep = time.Now()
 	g.ud["time"] = 0.0
 	g.ud["pixel"] = float32(g.pixelSize)
 
-	gs, err := graphicsShader.NewGraphicsShader(
-		g.shaderPath, int32(gridWidth), int32(gridHeight), g.ud, g.mu,
-	)
+	gs, err := g.newRenderer(gridWidth, gridHeight)
 	if err != nil {
 		return err
 	}
@@ -158,12 +194,41 @@ func (g *Graphics) setupGraphicsLoop() error {
 	return nil
 }
 
-func (g *Graphics) getShaders() (graphicsShader.ShaderIdentifiers, error) {
-	files, err := ioutil.ReadDir(g.shaderPath)
+// getShaders lists the built in shaders, then the uploaded ones; an upload can't shadow a built in
+func (g *Graphics) getShaders() (ShaderIdentifiers, error) {
+	var shaderNameList ShaderIdentifiers
+	if g.useCpuRenderer {
+		shaderNameList = getPatterns()
+	} else {
+		builtIn, err := listShaders(g.shaderPath, "")
+		if err != nil {
+			return nil, err
+		}
+		shaderNameList = builtIn
+	}
+	if g.shaderPath == "" {
+		return shaderNameList, nil
+	}
+	userShaders, err := listShaders(path.Join(g.shaderPath, userShaderDir), userShaderDir)
+	if err != nil && !os.IsNotExist(err) {
+		log.Println(fmt.Sprintf("Graphics, getShaders: couldn't list uploaded shaders; %s", err.Error()))
+	}
+	for k, v := range userShaders {
+		if _, ok := shaderNameList[k]; ok {
+			log.Println(fmt.Sprintf("Graphics, getShaders: uploaded shader %s shadows a built in; skipping", k))
+			continue
+		}
+		shaderNameList[k] = v
+	}
+	return shaderNameList, nil
+}
+
+func listShaders(dir string, prefix string) (ShaderIdentifiers, error) {
+	files, err := ioutil.ReadDir(dir)
 	if err != nil {
 		return nil, err
 	}
-	shaderNameList := make(graphicsShader.ShaderIdentifiers)
+	shaderNameList := make(ShaderIdentifiers)
 	for _, f := range files {
 		if f.IsDir() {
 			continue
@@ -173,7 +238,7 @@ func (g *Graphics) getShaders() (graphicsShader.ShaderIdentifiers, error) {
 			continue
 		}
 		fName := strings.TrimSuffix(f.Name(), ext)
-		shaderNameList[graphicsShader.ShaderKey(fName)] = fName
+		shaderNameList[ShaderKey(fName)] = path.Join(prefix, fName)
 	}
 	return shaderNameList, nil
 }
@@ -193,17 +258,17 @@ func (g *Graphics) initializeVariables() error {
 		log.Println("Graphics, initializeVariables: no shader saved, using default")
 		shaderName = g.defaultShader
 	}
-	if _, ok = g.shaderList[graphicsShader.ShaderKey(shaderName)]; !ok {
+	if _, ok = g.shaderList[ShaderKey(shaderName)]; !ok {
 		var firstShaderFound string
-		for _, v := range g.shaderList {
-			firstShaderFound = v
+		for k := range g.shaderList {
+			firstShaderFound = string(k)
 			break
 		}
 		log.Println(fmt.Sprintf("Couldn't find default shader %s; using %s", shaderName, firstShaderFound))
 		shaderName = firstShaderFound
 	}
 	g.runningShader = shaderName
-	err := g.gs.SetShader(graphicsShader.ShaderKey(g.runningShader))
+	err := g.gs.SetShader(ShaderKey(g.runningShader))
 	if err != nil {
 		return err
 	}
@@ -214,28 +279,151 @@ func (g *Graphics) initializeVariables() error {
 		frequency = g.defaultFrequency
 	}
 	g.runningFrequency = frequency
+	var transition types.Transition
+	transition, ok = g.s.repo.GetGraphicsTransition()
+	if !ok {
+		log.Println("Graphics, initializeVariables: no transition, using default")
+		transition = g.defaultTransition
+	}
+	g.runningTransition = transition
+	var uniformValues map[string]map[string]float32
+	uniformValues, ok = g.s.repo.GetGraphicsUniforms()
+	if !ok {
+		log.Println("Graphics, initializeVariables: no uniform values saved, using defaults")
+		uniformValues = make(map[string]map[string]float32)
+	}
+	g.uniformValues = uniformValues
+	var playlists []types.Playlist
+	playlists, ok = g.s.repo.GetGraphicsPlaylists()
+	if !ok {
+		log.Println("Graphics, initializeVariables: no playlists saved")
+	}
+	g.playlists = playlists
+	var activePlaylist string
+	activePlaylist, ok = g.s.repo.GetGraphicsActivePlaylist()
+	if !ok || activePlaylist == "" {
+		return nil
+	}
+	playlist, ok := findPlaylist(g.playlists, activePlaylist)
+	if !ok {
+		log.Println(fmt.Sprintf("Graphics, initializeVariables: couldn't find playlist %s; not resuming", activePlaylist))
+		return nil
+	}
+	err = g.startPlaylist(playlist)
+	if err != nil {
+		log.Println(fmt.Sprintf("Graphics, initializeVariables: couldn't resume playlist %s; %s", activePlaylist, err.Error()))
+		g.stopPlaylist()
+	}
+	// nothing has been shown yet, so there's nothing to fade in from
+	g.transition = nil
 	return nil
 }
 
+func findPlaylist(playlists []types.Playlist, name string) (types.Playlist, bool) {
+	for _, p := range playlists {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return types.Playlist{}, false
+}
+
 func (g *Graphics) getRunningFrequency() time.Duration {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
+	if g.playlist != nil && g.playlist.item().Frequency > 0 {
+		return g.playlist.item().Frequency
+	}
 	return g.runningFrequency
 }
 
-func (g *Graphics) tryReloadShader() error {
+func (g *Graphics) doRunShader() error {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
-	if g.runningReloadOnUpdate {
-		return g.gs.ReloadShader()
+	return g.gs.RunShader()
+}
+
+func (g *Graphics) doRenderFrame() error {
+	g.mu.Lock()
+	t := g.transition
+	if t != nil && t.isDone(time.Now()) {
+		g.transition = nil
+		t = nil
+	}
+	if t == nil {
+		g.applyUniforms(ShaderKey(g.runningShader))
+	}
+	g.mu.Unlock()
+	if t != nil {
+		return g.doRunTransition(t)
+	}
+	err := g.doRunShader()
+	if err != nil {
+		return err
+	}
+	return g.gs.ReadToPixels(g.pb.GetUnsafePointer())
+}
+
+// doRunTransition renders each of the transition's shaders into its own frame, finishing on the incoming
+// shader so the renderer is left on the running one
+func (g *Graphics) doRunTransition(t *transition) error {
+	now := time.Now()
+	err := g.doRunShaderInto(t.from, t.fromFrame)
+	if err != nil {
+		return err
+	}
+	if t.mask != "" {
+		err = g.doRunMaskInto(t, now)
+		if err != nil {
+			return err
+		}
+	}
+	err = g.doRunShaderInto(t.to, t.toFrame)
+	if err != nil {
+		return err
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	// a newer switch replaced the transition while this one was rendering; put the renderer back on the
+	// running shader and let the next frame pick things up
+	if g.transition != t {
+		return g.gs.SetShader(ShaderKey(g.runningShader))
 	}
+	t.blend(unsafe.Slice((*types.Color)(g.pb.GetUnsafePointer()), g.frameSize), now)
 	return nil
 }
 
-func (g *Graphics) doRunShader() error {
-	g.mu.RLock()
-	defer g.mu.RUnlock()
-	return g.gs.RunShader()
+// doRunMaskInto runs the mask on the transition's clock instead of the shaders' one, putting time back after
+func (g *Graphics) doRunMaskInto(t *transition, now time.Time) error {
+	g.mu.Lock()
+	running := g.ud["time"]
+	g.ud["time"] = t.maskTime(now)
+	g.mu.Unlock()
+	defer func() {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		g.ud["time"] = running
+	}()
+	return g.doRunShaderInto(t.mask, t.maskFrame)
+}
+
+// doRunShaderInto only holds mu for reading while the shader runs, as the renderer takes it for reading
+// itself; a switch that lands in between replaces the transition, so the frame is thrown away
+func (g *Graphics) doRunShaderInto(shader ShaderKey, frame []types.Color) error {
+	err := func() error {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		g.applyUniforms(shader)
+		return g.gs.SetShader(shader)
+	}()
+	if err != nil {
+		return err
+	}
+	err = g.doRunShader()
+	if err != nil {
+		return err
+	}
+	return g.gs.ReadToPixels(unsafe.Pointer(&frame[0]))
 }
 
 func (g *Graphics) stepTime() {
@@ -248,12 +436,86 @@ func (g *Graphics) stepTime() {
 	g.lastTimeStep = nt
 }
 
-func (g *Graphics) setShader(shader graphicsShader.ShaderKey) error {
+func (g *Graphics) setShader(shader ShaderKey) error {
+	return g.switchShader(shader, g.runningTransition)
+}
+
+func (g *Graphics) switchShader(shader ShaderKey, transition types.Transition) error {
+	from := ShaderKey(g.runningShader)
 	g.runningShader = string(shader)
-	if g.gs != nil {
-		return g.gs.SetShader(shader)
+	if g.gs == nil {
+		return errors.New("setShaderError")
+	}
+	err := g.gs.SetShader(shader)
+	if err != nil {
+		return err
+	}
+	g.startTransition(from, shader, transition)
+	return nil
+}
+
+// startTransition replaces any transition in progress; it starts over from the shader that one was headed
+// to, so switching mid transition jumps. Setting the same shader again leaves things be
+func (g *Graphics) startTransition(from, to ShaderKey, settings types.Transition) {
+	if from == to {
+		return
+	}
+	g.transition = nil
+	if settings.Duration <= 0 || g.pb == nil {
+		return
+	}
+	var mask ShaderKey
+	if settings.MaskShader != "" {
+		mask = ShaderKey(settings.MaskShader)
+		if _, ok := g.shaderList[mask]; !ok {
+			log.Println(fmt.Sprintf("Graphics, startTransition: no mask shader %s; crossfading", mask))
+			mask = ""
+		}
+	}
+	g.transition = newTransition(from, to, mask, settings, g.frameSize, time.Now())
+}
+
+// startPlaylist replaces any playlist that's running, starting from the top
+func (g *Graphics) startPlaylist(playlist types.Playlist) error {
+	g.playlist = newPlaylistRun(playlist, time.Now())
+	return g.playPlaylistItem()
+}
+
+func (g *Graphics) playPlaylistItem() error {
+	item := g.playlist.item()
+	transition := g.runningTransition
+	if item.Transition != nil {
+		transition = *item.Transition
+	}
+	return g.switchShader(ShaderKey(item.ShaderName), transition)
+}
+
+// stopPlaylist leaves the item that was on running as the shader
+func (g *Graphics) stopPlaylist() {
+	g.playlist = nil
+}
+
+func (g *Graphics) skipPlaylistItem(now time.Time) error {
+	g.playlist.advance(now)
+	return g.playPlaylistItem()
+}
+
+// stepPlaylist moves on to the next item once the current one's run its course; an item that can't play
+// stops the playlist rather than taking the graphics loop down with it
+func (g *Graphics) stepPlaylist() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	now := time.Now()
+	if g.playlist == nil || !g.playlist.isDue(now) {
+		return
+	}
+	err := g.skipPlaylistItem(now)
+	if err != nil {
+		log.Println(fmt.Sprintf(
+			"Graphics, stepPlaylist: stopping playlist %s; %s", g.playlist.playlist.Name, err.Error(),
+		))
+		g.stopPlaylist()
 	}
-	return errors.New("setShaderError")
 }
 
 func (g *Graphics) cleanupGraphicsLoop() {
@@ -268,8 +530,11 @@ func (g *Graphics) cleanupGraphicsLoop() {
 	}
 	if g.shaderList != nil {
 		g.shaderList = nil
+		g.shaderUniforms = nil
 	}
 	if g.ud != nil {
 		g.ud = nil
 	}
+	g.transition = nil
+	g.playlist = nil
 }
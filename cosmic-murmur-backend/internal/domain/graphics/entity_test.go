@@ -0,0 +1,34 @@
+package graphics
+
+import (
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/infrastructure/repository/memory"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGraphics_runGraphicsLoop_gridWithoutLock(t *testing.T) {
+	bus := &testBus{}
+	s := &service{repo: memory.NewMemoryRepository(), bus: bus, shutdowns: make(chan struct{})}
+	g := &Graphics{
+		s:                s,
+		mu:               &sync.RWMutex{},
+		pixelSize:        2,
+		defaultShader:    "basic",
+		defaultFrequency: 33 * time.Millisecond,
+		useCpuRenderer:   true,
+		tasks:            make(chan func()),
+	}
+	// the event loop takes g.mu to hand over frames; it has to be free while graphics waits on the grid
+	bus.onGridDimensions = func() {
+		if !g.mu.TryLock() {
+			t.Error("graphics asked for the grid while holding its lock")
+			return
+		}
+		g.mu.Unlock()
+	}
+	close(s.shutdowns)
+	if err := g.runGraphicsLoop(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,278 @@
+package graphics
+
+import (
+	"errors"
+	"fmt"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/domain"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+/*
+	uploaded shaders live in a user directory under the shader path, so the renderer finds them the same way
+	as the built ins; the built ins are never written to. Compiling has to happen on the graphics loop, the
+	only goroutine with the gl context, so the service hands uploads / renames over as tasks
+*/
+
+const userShaderDir = "user"
+
+/*
+	the api gives up on a command after the bus busy timeout, so the loop gets half of it to pick a task up and
+	the task the rest to run; a task that missed the pickup never runs, rather than landing after the caller
+	was told it failed
+*/
+const loopTaskShare = 2
+
+var shaderNamePattern = regexp.MustCompile(`^[a-z0-9_]{1,64}$`)
+
+func isUserShader(fileName string) bool {
+	return strings.HasPrefix(fileName, userShaderDir+"/")
+}
+
+func validateShaderName(name string) error {
+	if !shaderNamePattern.MatchString(name) {
+		return fmt.Errorf(
+			"%w: shader names are 1 to 64 lower case letters, digits or underscores, got %q",
+			domain.ErrInvalidSettings, name,
+		)
+	}
+	return nil
+}
+
+// checkShaderSyntax catches what it can before the loop is asked to compile; the compile has the last word
+func checkShaderSyntax(frag string, vert string) error {
+	for kind, source := range map[string]string{"fragment": frag, "vertex": vert} {
+		if !strings.Contains(source, "void main") {
+			return fmt.Errorf("%w: %s shader has no main", domain.ErrInvalidSettings, kind)
+		}
+		for _, pair := range []string{"{}", "()"} {
+			if strings.Count(source, pair[:1]) != strings.Count(source, pair[1:]) {
+				return fmt.Errorf("%w: %s shader has unbalanced %s", domain.ErrInvalidSettings, kind, pair)
+			}
+		}
+	}
+	_, err := parseUniforms(frag)
+	if err != nil {
+		return fmt.Errorf("%w: %s", domain.ErrInvalidSettings, err.Error())
+	}
+	return nil
+}
+
+// runOnLoop only gives up on a task the loop hasn't picked up; once it's running, its result is what happened
+func (g *Graphics) runOnLoop(task func() error) error {
+	result := make(chan error, 1)
+	select {
+	case g.tasks <- func() { result <- task() }:
+		return <-result
+	case <-time.After(g.taskTimeout):
+		return fmt.Errorf("%w: GraphicsLoop is busy or down", domain.ErrUnavailable)
+	}
+}
+
+func (g *Graphics) getUserShaders() []string {
+	var shaders []string
+	for k, fileName := range g.shaderList {
+		if isUserShader(fileName) {
+			shaders = append(shaders, string(k))
+		}
+	}
+	sort.Strings(shaders)
+	return shaders
+}
+
+// compileShader loads key into the renderer, or reloads it if it was there already; the gl library only
+// reports compile errors on a reload. The renderer is left on the running shader either way
+func (g *Graphics) compileShader(key ShaderKey, fileName string) error {
+	if _, ok := g.shaderList[key]; !ok && !g.retired[key] {
+		err := g.gs.AttachShaders(ShaderIdentifiers{key: fileName})
+		if err != nil {
+			return err
+		}
+		g.retired[key] = true
+	}
+	err := g.gs.SetShader(key)
+	if err == nil {
+		err = g.gs.ReloadShader()
+	}
+	restoreErr := g.gs.SetShader(ShaderKey(g.runningShader))
+	if err != nil {
+		return err
+	}
+	return restoreErr
+}
+
+// shaderInUse names what still refers to key, if anything; those have to move off it before it goes
+func (g *Graphics) shaderInUse(key ShaderKey) string {
+	name := string(key)
+	if g.runningShader == name || (g.transition != nil && (g.transition.from == key || g.transition.to == key)) {
+		return "it's running"
+	} else if g.runningTransition.MaskShader == name {
+		return "it masks the transition"
+	}
+	for _, p := range g.playlists {
+		for _, item := range p.Items {
+			if item.ShaderName == name || (item.Transition != nil && item.Transition.MaskShader == name) {
+				return fmt.Sprintf("playlist %s uses it", p.Name)
+			}
+		}
+	}
+	return ""
+}
+
+func (g *Graphics) getUserShader(name string) (ShaderKey, error) {
+	key := ShaderKey(name)
+	fileName, ok := g.shaderList[key]
+	if !ok {
+		return key, fmt.Errorf("%w: Shader %s", domain.ErrNotFound, name)
+	} else if !isUserShader(fileName) {
+		return key, fmt.Errorf("%w: shader %s is built in", domain.ErrInvalidSettings, name)
+	}
+	return key, nil
+}
+
+// uploadShader adds the shader, or replaces an uploaded one of the same name; a shader that doesn't compile
+// leaves whatever was there before in place
+func (g *Graphics) uploadShader(name string, frag string, vert string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.shaderList == nil || g.shaderPath == "" {
+		return fmt.Errorf("%w: GraphicsLoop is Down", domain.ErrUnavailable)
+	}
+	key := ShaderKey(name)
+	_, replacing := g.shaderList[key]
+	if replacing {
+		if _, err := g.getUserShader(name); err != nil {
+			return err
+		}
+	}
+	fileName := path.Join(userShaderDir, name)
+	base := path.Join(g.shaderPath, fileName)
+	err := os.MkdirAll(path.Dir(base), 0755)
+	if err != nil {
+		return err
+	}
+	var oldFrag, oldVert []byte
+	if replacing {
+		oldFrag, err = os.ReadFile(base + ".frag")
+		if err == nil {
+			oldVert, err = os.ReadFile(base + ".vert")
+		}
+		if err != nil {
+			return err
+		}
+	}
+	restore := func() {
+		if replacing {
+			_ = os.WriteFile(base+".frag", oldFrag, 0644)
+			_ = os.WriteFile(base+".vert", oldVert, 0644)
+			_ = g.compileShader(key, fileName)
+		} else {
+			_ = os.Remove(base + ".frag")
+			_ = os.Remove(base + ".vert")
+		}
+	}
+	err = os.WriteFile(base+".frag", []byte(frag), 0644)
+	if err == nil {
+		err = os.WriteFile(base+".vert", []byte(vert), 0644)
+	}
+	if err != nil {
+		restore()
+		return err
+	}
+	err = g.compileShader(key, fileName)
+	if err != nil {
+		restore()
+		return fmt.Errorf("%w: shader %s doesn't compile; %s", domain.ErrInvalidSettings, name, err.Error())
+	}
+	// checkShaderSyntax already parsed these
+	uniforms, _ := parseUniforms(frag)
+	g.shaderList[key] = fileName
+	g.shaderUniforms[key] = uniforms
+	delete(g.retired, key)
+	return nil
+}
+
+func (g *Graphics) deleteShader(name string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.shaderList == nil {
+		return fmt.Errorf("%w: GraphicsLoop is Down", domain.ErrUnavailable)
+	}
+	key, err := g.getUserShader(name)
+	if err != nil {
+		return err
+	}
+	if inUse := g.shaderInUse(key); inUse != "" {
+		return fmt.Errorf("%w: can't delete shader %s; %s", domain.ErrInvalidSettings, name, inUse)
+	}
+	base := path.Join(g.shaderPath, g.shaderList[key])
+	for _, ext := range []string{".frag", ".vert"} {
+		err = os.Remove(base + ext)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	delete(g.shaderList, key)
+	delete(g.shaderUniforms, key)
+	g.retired[key] = true
+	return nil
+}
+
+func (g *Graphics) renameShader(name string, newName string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.shaderList == nil {
+		return fmt.Errorf("%w: GraphicsLoop is Down", domain.ErrUnavailable)
+	}
+	key, err := g.getUserShader(name)
+	if err != nil {
+		return err
+	}
+	newKey := ShaderKey(newName)
+	if _, ok := g.shaderList[newKey]; ok {
+		return fmt.Errorf("%w: shader %s already exists", domain.ErrInvalidSettings, newName)
+	}
+	if inUse := g.shaderInUse(key); inUse != "" {
+		return fmt.Errorf("%w: can't rename shader %s; %s", domain.ErrInvalidSettings, name, inUse)
+	}
+	base := path.Join(g.shaderPath, g.shaderList[key])
+	newFileName := path.Join(userShaderDir, newName)
+	newBase := path.Join(g.shaderPath, newFileName)
+	err = os.Rename(base+".frag", newBase+".frag")
+	if err != nil {
+		return err
+	}
+	err = os.Rename(base+".vert", newBase+".vert")
+	if err == nil {
+		err = g.compileShader(newKey, newFileName)
+	}
+	if err != nil {
+		_ = os.Rename(newBase+".frag", base+".frag")
+		_ = os.Rename(newBase+".vert", base+".vert")
+		return err
+	}
+	g.shaderList[newKey] = newFileName
+	g.shaderUniforms[newKey] = g.shaderUniforms[key]
+	delete(g.shaderList, key)
+	delete(g.shaderUniforms, key)
+	delete(g.retired, newKey)
+	g.retired[key] = true
+	return nil
+}
+
+// moveUniformValues carries a shader's saved uniform values over to its new name, or drops them with it
+func moveUniformValues(values map[string]map[string]float32, name string, newName string) map[string]map[string]float32 {
+	moved := make(map[string]map[string]float32, len(values))
+	for k, v := range values {
+		if k != name {
+			moved[k] = v
+		} else if newName != "" {
+			moved[newName] = v
+		}
+	}
+	return moved
+}
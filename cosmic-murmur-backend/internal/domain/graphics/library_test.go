@@ -0,0 +1,175 @@
+package graphics
+
+import (
+	"errors"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/domain"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/infrastructure/repository/memory"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/types"
+	"os"
+	"path"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testFrag = `
+uniform float time;
+uniform float speed; // @range 0 10 2
+
+void main() {
+	gl_FragColor = vec4(sin(time * speed));
+}
+`
+
+const testVert = `
+void main() {
+	gl_Position = vec4(0.0);
+}
+`
+
+func TestCheckShaderSyntax(t *testing.T) {
+	cases := []struct {
+		name string
+		frag string
+		err  bool
+	}{
+		{"valid", testFrag, false},
+		{"no main", "uniform float speed;", true},
+		{"unbalanced", "void main() {", true},
+		{"reserved uniform", "uniform float time; // @range 0 1\nvoid main() {}", true},
+	}
+	for _, c := range cases {
+		err := checkShaderSyntax(c.frag, testVert)
+		if c.err != (err != nil) {
+			t.Errorf("%s: got %v", c.name, err)
+		}
+	}
+}
+
+func TestGraphics_userShaders(t *testing.T) {
+	g := &Graphics{
+		mu:               &sync.RWMutex{},
+		ud:               UniformDict{"time": 0.0, "pixel": 1.0},
+		useCpuRenderer:   true,
+		shaderPath:       t.TempDir(),
+		shaderList:       getPatterns(),
+		shaderUniforms:   map[ShaderKey][]types.ShaderUniform{},
+		uniformValues:    map[string]map[string]float32{},
+		runningShader:    "basic",
+		runningFrequency: 33 * time.Millisecond,
+		retired:          map[ShaderKey]bool{},
+	}
+	g.gs = newPatternShader(4, 2, g.ud, g.mu)
+	if err := g.gs.AttachShaders(g.shaderList); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := g.uploadShader("ripple", testFrag, testVert); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path.Join(g.shaderPath, userShaderDir, "ripple.frag")); err != nil {
+		t.Errorf("upload didn't write the shader; %v", err)
+	}
+	if u := g.shaderUniforms["ripple"]; len(u) != 1 || u[0].Default != 2 {
+		t.Errorf("uniforms = %v", u)
+	}
+	if shaders := g.getUserShaders(); len(shaders) != 1 || shaders[0] != "ripple" {
+		t.Errorf("user shaders = %v", shaders)
+	}
+	// what's listed has to be selectable
+	s := &service{repo: memory.NewMemoryRepository(), mu: &sync.RWMutex{}, shutdowns: make(chan struct{}), g: g}
+	g.s = s
+	settings, err := s.GetSettings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	listed := false
+	for _, shader := range settings.Shaders {
+		listed = listed || shader == "ripple"
+	}
+	if !listed {
+		t.Errorf("shaders = %v; expected the upload by name", settings.Shaders)
+	}
+	err = s.SetSettings(&domain.GraphicsSettableSettings{ShaderName: "ripple", Frequency: 33 * time.Millisecond})
+	if err != nil || g.runningShader != "ripple" {
+		t.Errorf("selecting the upload ran %s; %v", g.runningShader, err)
+	}
+	if err = g.setShader("basic"); err != nil {
+		t.Fatal(err)
+	}
+	// replacing keeps the renderer's program
+	if err := g.uploadShader("ripple", testFrag, testVert); err != nil {
+		t.Errorf("replace: %v", err)
+	}
+	if err := g.uploadShader("basic", testFrag, testVert); !errors.Is(err, domain.ErrInvalidSettings) {
+		t.Errorf("overwriting a built in: %v", err)
+	}
+	if err := g.deleteShader("basic"); !errors.Is(err, domain.ErrInvalidSettings) {
+		t.Errorf("deleting a built in: %v", err)
+	}
+
+	if err := g.setShader("ripple"); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.deleteShader("ripple"); !errors.Is(err, domain.ErrInvalidSettings) {
+		t.Errorf("deleting the running shader: %v", err)
+	}
+	if err := g.setShader("basic"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := g.renameShader("ripple", "snake_wipe"); !errors.Is(err, domain.ErrInvalidSettings) {
+		t.Errorf("renaming onto a built in: %v", err)
+	}
+	if err := g.renameShader("ripple", "waves"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := g.shaderList["ripple"]; ok || g.shaderList["waves"] != "user/waves" || !g.retired["ripple"] {
+		t.Errorf("after rename, shaders = %v, retired = %v", g.shaderList, g.retired)
+	}
+	// the old name's program is retired, not gone, so it can come back
+	if err := g.uploadShader("ripple", testFrag, testVert); err != nil {
+		t.Errorf("re-upload after rename: %v", err)
+	}
+
+	if err := g.deleteShader("waves"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path.Join(g.shaderPath, userShaderDir, "waves.frag")); !os.IsNotExist(err) {
+		t.Errorf("delete left the shader on disk; %v", err)
+	}
+	if err := g.deleteShader("waves"); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("deleting twice: %v", err)
+	}
+}
+
+func TestService_uploadShaderCpuRenderer(t *testing.T) {
+	g := &Graphics{mu: &sync.RWMutex{}, useCpuRenderer: true, shaderPath: t.TempDir(), tasks: make(chan func())}
+	s := &service{g: g}
+	if err := s.UploadShader("ripple", testFrag, testVert); !errors.Is(err, domain.ErrInvalidSettings) {
+		t.Errorf("got %v; expected the cpu renderer to refuse uploads", err)
+	}
+	if _, err := os.Stat(path.Join(g.shaderPath, userShaderDir)); !os.IsNotExist(err) {
+		t.Errorf("refused upload was stored anyway; %v", err)
+	}
+}
+
+func TestGraphics_runOnLoop(t *testing.T) {
+	g := &Graphics{tasks: make(chan func()), taskTimeout: 50 * time.Millisecond}
+	if err := g.runOnLoop(func() error { return nil }); !errors.Is(err, domain.ErrUnavailable) {
+		t.Errorf("with no loop: %v", err)
+	}
+	// a task the loop picks up just in time runs to completion, however long it takes
+	go func() {
+		time.Sleep(30 * time.Millisecond)
+		task := <-g.tasks
+		task()
+	}()
+	err := g.runOnLoop(func() error {
+		time.Sleep(100 * time.Millisecond)
+		return domain.ErrNotFound
+	})
+	if !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("got %v; expected the task's own error", err)
+	}
+}
@@ -0,0 +1,250 @@
+package graphics
+
+import (
+	"errors"
+	"fmt"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/types"
+	"math"
+	"sync"
+	"unsafe"
+)
+
+/*
+	go ports of the fragment shaders in data/shaders; each pattern is evaluated once per pixel center,
+	the same as gl_FragCoord, with the origin in the bottom left
+*/
+
+type vec2 struct {
+	x float64
+	y float64
+}
+
+type vec3 struct {
+	r float64
+	g float64
+	b float64
+}
+
+type patternUniforms struct {
+	time       float64
+	pixel      float64
+	resolution vec2
+}
+
+type pattern func(fragCoord vec2, u *patternUniforms) vec3
+
+var patterns = map[ShaderKey]pattern{
+	"basic":           basicPattern,
+	"cosmic_murmur":   basicPattern,
+	"horizontal_wipe": horizontalWipePattern,
+	"vertical_wipe":   verticalWipePattern,
+	"snake_wipe":      snakeWipePattern,
+}
+
+func getPatterns() ShaderIdentifiers {
+	shaderNameList := make(ShaderIdentifiers)
+	for k := range patterns {
+		shaderNameList[k] = string(k)
+	}
+	return shaderNameList
+}
+
+func basicPattern(fragCoord vec2, u *patternUniforms) vec3 {
+	uvX := fragCoord.x / u.resolution.x
+	uvY := fragCoord.y / u.resolution.y
+	gridX := math.Floor(uvX * 11.0)
+	gridY := math.Floor(uvY * 3.0)
+	separation := (0.5*0.5)*2.0*3.14159 + 1.0
+	modOffset := glslMod(gridX+gridY, 2.0) * separation
+	pct := -math.Pow(math.Sin(modOffset+u.time/2.0), 2.0) + u.time/10.0
+	pct = math.Pow(math.Sin(pct), 2.0)*0.5 + 0.1
+	return hsb2rgb(vec3{pct, 1.0, 0.7})
+}
+
+func horizontalWipePattern(fragCoord vec2, u *patternUniforms) vec3 {
+	uvX := math.Floor(fragCoord.x/u.pixel) * u.pixel / u.resolution.x
+	return wipe(uvX, u.time, 10.0)
+}
+
+func verticalWipePattern(fragCoord vec2, u *patternUniforms) vec3 {
+	uvY := math.Floor(fragCoord.y/u.pixel) * u.pixel / u.resolution.y
+	return wipe(uvY, u.time, 8.0)
+}
+
+func wipe(position float64, time float64, redSharpness float64) vec3 {
+	t := time * 0.1 * math.Pi
+	color := vec3{}
+	color.r += math.Max(0.0, math.Sin(position-t)*redSharpness-(redSharpness-1.0))
+	color.b += math.Max(0.0, math.Sin(position-t+0.5*math.Pi)*10.0-9.0)
+	color.g += math.Max(0.0, math.Sin(position-t+1.0*math.Pi)*10.0-9.0)
+	white := math.Max(0.0, math.Sin(position-t+1.5*math.Pi)*10.0-9.0)
+	color.r += white
+	color.g += white
+	color.b += white
+	return color
+}
+
+var snakeColors = [4]vec3{
+	{1.0, 0.0, 0.0}, {0.0, 0.0, 1.0}, {0.0, 1.0, 0.0}, {1.0, 1.0, 1.0},
+}
+
+func snakeWipePattern(fragCoord vec2, u *patternUniforms) vec3 {
+	gridX := u.resolution.x / u.pixel
+	gridY := u.resolution.y / u.pixel
+	uvGridX := math.Floor(fragCoord.x / u.pixel)
+	uvGridY := math.Floor(fragCoord.y / u.pixel)
+	snakeNumber := gridY * uvGridX
+	if glslMod(uvGridX, 2.0) == 0.0 {
+		snakeNumber += uvGridY
+	} else {
+		snakeNumber += gridY - uvGridY - 1.0
+	}
+	color := vec3{}
+	for i, c := range snakeColors {
+		t := glslMod(u.time+gridX/4.0*float64(i), gridX)
+		y := math.Max(0.0, parabola(snakeNumber/12.0-t, 1.0))
+		color.r += c.r * y
+		color.g += c.g * y
+		color.b += c.b * y
+	}
+	return color
+}
+
+func darkPattern(fragCoord vec2, u *patternUniforms) vec3 {
+	return vec3{}
+}
+
+func parabola(x float64, k float64) float64 {
+	return math.Pow(4.0*x*(1.0-x), k)
+}
+
+func hsb2rgb(c vec3) vec3 {
+	channel := func(offset float64) float64 {
+		v := clamp(math.Abs(glslMod(c.r*6.0+offset, 6.0)-3.0)-1.0, 0.0, 1.0)
+		v = v * v * (3.0 - 2.0*v)
+		return c.b * (1.0 + (v-1.0)*c.g)
+	}
+	return vec3{channel(0.0), channel(4.0), channel(2.0)}
+}
+
+// glslMod matches glsl's mod, which floors rather than truncating like math.Mod
+func glslMod(x float64, y float64) float64 {
+	return x - y*math.Floor(x/y)
+}
+
+func clamp(x float64, min float64, max float64) float64 {
+	return math.Min(math.Max(x, min), max)
+}
+
+func toColorChannel(v float64) uint8 {
+	return uint8(math.Round(clamp(v, 0.0, 1.0) * 255.0))
+}
+
+type patternShader struct {
+	width         int
+	height        int
+	uniformDict   UniformDict
+	mu            *sync.RWMutex
+	programs      map[ShaderKey]pattern
+	currentShader ShaderKey
+	frame         []types.Color
+}
+
+func newPatternShader(
+	width int32, height int32, uniformDict UniformDict, mu *sync.RWMutex,
+) *patternShader {
+	return &patternShader{
+		width:         int(width),
+		height:        int(height),
+		uniformDict:   uniformDict,
+		mu:            mu,
+		programs:      make(map[ShaderKey]pattern),
+		currentShader: "",
+		frame:         make([]types.Color, width*height),
+	}
+}
+
+func (ps *patternShader) AttachShader(id ShaderKey, fileName string) error {
+	if _, ok := ps.programs[id]; ok {
+		return errors.New(fmt.Sprintf("shader with key %s already exists", id))
+	}
+	p, ok := patterns[ShaderKey(fileName)]
+	if !ok && isUserShader(fileName) {
+		// uploaded shaders have no go port; they stay dark on the cpu renderer
+		p, ok = darkPattern, true
+	}
+	if !ok {
+		return errors.New(fmt.Sprintf("no pattern available for shader %s", fileName))
+	}
+	if ps.currentShader == "" {
+		ps.currentShader = id
+	}
+	ps.programs[id] = p
+	return nil
+}
+
+func (ps *patternShader) AttachShaders(si ShaderIdentifiers) error {
+	for k, v := range si {
+		err := ps.AttachShader(k, v)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ps *patternShader) SetShader(key ShaderKey) error {
+	if _, ok := ps.programs[key]; !ok {
+		return errors.New(fmt.Sprintf("couldn't find shader with key %s", key))
+	}
+	ps.currentShader = key
+	return nil
+}
+
+func (ps *patternShader) ReloadShader() error {
+	// patterns are compiled in, so there is nothing on disk to reload
+	if ps.currentShader == "" {
+		return errors.New("nothing to reload")
+	}
+	return nil
+}
+
+func (ps *patternShader) RunShader() error {
+	p, ok := ps.programs[ps.currentShader]
+	if !ok {
+		return errors.New(fmt.Sprintf("couldn't find shader %s", ps.currentShader))
+	}
+	// the graphics loop holds mu for reading while running the shader
+	u := &patternUniforms{
+		time:       float64(ps.uniformDict["time"]),
+		pixel:      float64(ps.uniformDict["pixel"]),
+		resolution: vec2{float64(ps.width), float64(ps.height)},
+	}
+	if u.pixel <= 0.0 {
+		u.pixel = 1.0
+	}
+	for y := 0; y < ps.height; y++ {
+		for x := 0; x < ps.width; x++ {
+			c := p(vec2{float64(x) + 0.5, float64(y) + 0.5}, u)
+			ps.frame[x+y*ps.width] = types.Color{
+				R: toColorChannel(c.r),
+				G: toColorChannel(c.g),
+				B: toColorChannel(c.b),
+				W: 255,
+			}
+		}
+	}
+	return nil
+}
+
+func (ps *patternShader) ReadToPixels(pb unsafe.Pointer) error {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	copy(unsafe.Slice((*types.Color)(pb), len(ps.frame)), ps.frame)
+	return nil
+}
+
+func (ps *patternShader) Cleanup() {
+	ps.programs = make(map[ShaderKey]pattern)
+	ps.currentShader = ""
+}
@@ -0,0 +1,77 @@
+package graphics
+
+import (
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/types"
+	"sync"
+	"testing"
+)
+
+func testChannelEq(a, b uint8) bool {
+	// allow for rounding differences between float64 here and highp float on the gpu
+	return a == b || a+1 == b || a == b+1
+}
+
+func TestHsb2rgb(t *testing.T) {
+	c := hsb2rgb(vec3{0.1, 1.0, 0.7})
+	if !testChannelEq(toColorChannel(c.r), 178) ||
+		!testChannelEq(toColorChannel(c.g), 116) ||
+		!testChannelEq(toColorChannel(c.b), 0) {
+		t.Errorf("hsb2rgb(0.1, 1.0, 0.7) = %v", c)
+	}
+}
+
+func TestPatterns_matchShaderOutput(t *testing.T) {
+	u := &patternUniforms{
+		time:       0.0,
+		pixel:      7.0,
+		resolution: vec2{70.0, 35.0},
+	}
+	// at time 0 the blue band of the wipes sits on the left / bottom edge
+	c := horizontalWipePattern(vec2{0.5, 0.5}, u)
+	if c.r != 0.0 || c.g != 0.0 || c.b != 1.0 {
+		t.Errorf("horizontal wipe at origin = %v; expected blue", c)
+	}
+	// first grid cell of the basic shader starts at hue 0.1
+	c = basicPattern(vec2{0.5, 0.5}, u)
+	if !testChannelEq(toColorChannel(c.r), 178) || !testChannelEq(toColorChannel(c.g), 116) {
+		t.Errorf("basic at origin = %v", c)
+	}
+	// the red head of the snake wipe is still entering at time 0
+	c = snakeWipePattern(vec2{0.5, 0.5}, u)
+	if c.r != 0.0 {
+		t.Errorf("snake wipe at origin = %v; expected no red", c)
+	}
+}
+
+func TestPatternShader_runAndRead(t *testing.T) {
+	ud := UniformDict{"time": 0.0, "pixel": 1.0}
+	ps := newPatternShader(4, 2, ud, &sync.RWMutex{})
+	err := ps.AttachShaders(getPatterns())
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ps.SetShader("basic")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ps.RunShader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pb := types.NewPixelBuffer(4, 2, 0, 0, 1)
+	err = ps.ReadToPixels(pb.GetUnsafePointer())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for x := 0; x < 4; x++ {
+		for y := 0; y < 2; y++ {
+			p := types.CreatePoint(x, y)
+			if c := pb.GetPixel(&p); c.W != 255 || c.R == 0 {
+				t.Errorf("pixel (%d, %d) not rendered: %v", x, y, c)
+			}
+		}
+	}
+	if err = ps.SetShader("not_a_shader"); err == nil {
+		t.Error("expected error setting unknown shader")
+	}
+}
@@ -0,0 +1,58 @@
+package graphics
+
+import (
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/types"
+	"math/rand"
+	"time"
+)
+
+// playlistRun tracks where a running playlist is; order holds item indexes for the current time through
+type playlistRun struct {
+	playlist  types.Playlist
+	order     []int
+	position  int
+	itemStart time.Time
+}
+
+func newPlaylistRun(playlist types.Playlist, now time.Time) *playlistRun {
+	r := &playlistRun{
+		playlist:  playlist,
+		itemStart: now,
+	}
+	r.order = r.nextOrder(-1)
+	return r
+}
+
+func (r *playlistRun) item() types.PlaylistItem {
+	return r.playlist.Items[r.order[r.position]]
+}
+
+func (r *playlistRun) isDue(now time.Time) bool {
+	return now.Sub(r.itemStart) >= r.item().Duration
+}
+
+func (r *playlistRun) advance(now time.Time) {
+	r.position += 1
+	if r.position >= len(r.order) {
+		r.order = r.nextOrder(r.order[len(r.order)-1])
+		r.position = 0
+	}
+	r.itemStart = now
+}
+
+// nextOrder shuffles without playing the last item twice in a row across the wrap around
+func (r *playlistRun) nextOrder(last int) []int {
+	n := len(r.playlist.Items)
+	if r.playlist.Mode != types.PlaylistShuffle {
+		order := make([]int, n)
+		for i := range order {
+			order[i] = i
+		}
+		return order
+	}
+	order := rand.Perm(n)
+	if n > 1 && order[0] == last {
+		order[0], order[n-1] = order[n-1], order[0]
+	}
+	return order
+}
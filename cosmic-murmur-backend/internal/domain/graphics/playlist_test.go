@@ -0,0 +1,141 @@
+package graphics
+
+import (
+	"errors"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/domain"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/types"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestPlaylistRun_shuffle(t *testing.T) {
+	playlist := types.Playlist{Name: "evening", Mode: types.PlaylistShuffle}
+	for _, shader := range []string{"basic", "snake_wipe", "vertical_wipe", "horizontal_wipe"} {
+		playlist.Items = append(playlist.Items, types.PlaylistItem{ShaderName: shader, Duration: time.Second})
+	}
+	now := time.Now()
+	r := newPlaylistRun(playlist, now)
+	last := -1
+	for cycle := 0; cycle < 20; cycle++ {
+		seen := make(map[int]bool)
+		for range playlist.Items {
+			i := r.order[r.position]
+			if i == last {
+				t.Fatalf("item %d played twice in a row", i)
+			}
+			seen[i] = true
+			last = i
+			now = now.Add(time.Second)
+			if !r.isDue(now) {
+				t.Fatal("expected the item to be due after its duration")
+			}
+			r.advance(now)
+		}
+		if len(seen) != len(playlist.Items) {
+			t.Fatalf("cycle %d played %v; expected every item once", cycle, seen)
+		}
+	}
+}
+
+func TestGraphics_playlist(t *testing.T) {
+	g := &Graphics{
+		mu:         &sync.RWMutex{},
+		ud:         UniformDict{"time": 0.0, "pixel": 1.0},
+		shaderList: getPatterns(),
+		shaderUniforms: map[ShaderKey][]types.ShaderUniform{
+			"snake_wipe": {{Name: "speed", Type: types.UniformTypeFloat, Max: 5, Default: 1}},
+		},
+		uniformValues:    map[string]map[string]float32{},
+		runningShader:    "basic",
+		runningFrequency: 33 * time.Millisecond,
+	}
+	g.gs = newPatternShader(4, 2, g.ud, g.mu)
+	if err := g.gs.AttachShaders(g.shaderList); err != nil {
+		t.Fatal(err)
+	}
+
+	err := g.startPlaylist(types.Playlist{
+		Name: "evening", Mode: types.PlaylistSequential,
+		Items: []types.PlaylistItem{
+			{
+				ShaderName: "snake_wipe", Duration: time.Minute, Frequency: 20 * time.Millisecond,
+				Uniforms: map[string]float32{"speed": 3},
+			},
+			{ShaderName: "vertical_wipe", Duration: time.Minute},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.runningShader != "snake_wipe" || g.getRunningFrequency() != 20*time.Millisecond {
+		t.Errorf("running %s every %v", g.runningShader, g.getRunningFrequency())
+	}
+	g.applyUniforms("snake_wipe")
+	if g.ud["speed"] != 3 {
+		t.Errorf("uniforms = %v; expected the item's override", g.ud)
+	}
+
+	// not due yet
+	g.stepPlaylist()
+	if g.runningShader != "snake_wipe" {
+		t.Errorf("moved on to %s early", g.runningShader)
+	}
+	g.playlist.itemStart = time.Now().Add(-time.Minute)
+	g.stepPlaylist()
+	if g.runningShader != "vertical_wipe" || g.getRunningFrequency() != 33*time.Millisecond {
+		t.Errorf("running %s every %v", g.runningShader, g.getRunningFrequency())
+	}
+	g.applyUniforms("snake_wipe")
+	if g.ud["speed"] != 1 {
+		t.Errorf("uniforms = %v; expected the default once the item is done", g.ud)
+	}
+
+	if err = g.skipPlaylistItem(time.Now()); err != nil || g.runningShader != "snake_wipe" {
+		t.Errorf("skip from the last item ran %s; %v", g.runningShader, err)
+	}
+	g.stopPlaylist()
+	g.applyUniforms("snake_wipe")
+	if g.playlist != nil || g.ud["speed"] != 1 || g.runningShader != "snake_wipe" {
+		t.Errorf("after stopping, running %s with uniforms %v", g.runningShader, g.ud)
+	}
+}
+
+func TestValidatePlaylists(t *testing.T) {
+	item := types.PlaylistItem{ShaderName: "basic", Duration: time.Minute}
+	cases := []struct {
+		name      string
+		playlists []types.Playlist
+		expected  error
+	}{
+		{"valid", []types.Playlist{{Name: "a", Mode: types.PlaylistShuffle, Items: []types.PlaylistItem{item}}}, nil},
+		{"unnamed", []types.Playlist{{Mode: types.PlaylistShuffle, Items: []types.PlaylistItem{item}}}, domain.ErrInvalidSettings},
+		{"duplicate", []types.Playlist{
+			{Name: "a", Mode: types.PlaylistShuffle, Items: []types.PlaylistItem{item}},
+			{Name: "a", Mode: types.PlaylistSequential, Items: []types.PlaylistItem{item}},
+		}, domain.ErrInvalidSettings},
+		{"bad mode", []types.Playlist{{Name: "a", Mode: "random", Items: []types.PlaylistItem{item}}}, domain.ErrInvalidSettings},
+		{"empty", []types.Playlist{{Name: "a", Mode: types.PlaylistShuffle}}, domain.ErrInvalidSettings},
+		{"unknown shader", []types.Playlist{{Name: "a", Mode: types.PlaylistShuffle, Items: []types.PlaylistItem{
+			{ShaderName: "nope", Duration: time.Minute},
+		}}}, domain.ErrNotFound},
+		{"undeclared uniform", []types.Playlist{{Name: "a", Mode: types.PlaylistShuffle, Items: []types.PlaylistItem{
+			{ShaderName: "basic", Duration: time.Minute, Uniforms: map[string]float32{"time": 0}},
+		}}}, domain.ErrNotFound},
+		{"uniform out of range", []types.Playlist{{Name: "a", Mode: types.PlaylistShuffle, Items: []types.PlaylistItem{
+			{ShaderName: "basic", Duration: time.Minute, Uniforms: map[string]float32{"speed": 11}},
+		}}}, domain.ErrInvalidSettings},
+		{"bad transition", []types.Playlist{{Name: "a", Mode: types.PlaylistShuffle, Items: []types.PlaylistItem{
+			{ShaderName: "basic", Duration: time.Minute, Transition: &types.Transition{Easing: "bounce"}},
+		}}}, domain.ErrInvalidSettings},
+	}
+	uniforms := map[ShaderKey][]types.ShaderUniform{
+		"basic": {{Name: "speed", Type: types.UniformTypeFloat, Max: 10}},
+	}
+	for _, c := range cases {
+		err := validatePlaylists(c.playlists, getPatterns(), uniforms)
+		if c.expected == nil && err != nil || c.expected != nil && !errors.Is(err, c.expected) {
+			t.Errorf("%s: got %v, expected %v", c.name, err, c.expected)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package graphics
+
+import (
+	"unsafe"
+)
+
+/*
+	the gl renderer comes from graphicsShader, which links glfw through cgo; it's only built with cgo and
+	without the nogl tag, so the cpu renderer (and everything tested against it) builds on a headless machine:
+
+		go test -tags nogl ./...
+
+	ShaderKey, ShaderIdentifiers, UniformKey, UniformDict and getShaderPath come from graphicsShader in
+	renderer_gl.go, and have headless stand-ins in renderer_nogl.go
+*/
+
+// shaderRenderer is what the graphics loop needs from a renderer; graphicsShader.GraphicsShader is the gl one,
+// and patternShader provides a pure go fallback for machines without a working gpu / display
+type shaderRenderer interface {
+	AttachShaders(si ShaderIdentifiers) error
+	SetShader(key ShaderKey) error
+	ReloadShader() error
+	RunShader() error
+	ReadToPixels(pb unsafe.Pointer) error
+	Cleanup()
+}
+
+var _ shaderRenderer = (*patternShader)(nil)
+
+func (g *Graphics) newRenderer(width int, height int) (shaderRenderer, error) {
+	if g.useCpuRenderer {
+		return newPatternShader(int32(width), int32(height), g.ud, g.mu), nil
+	}
+	return newGLRenderer(g.shaderPath, int32(width), int32(height), g.ud, g.mu)
+}
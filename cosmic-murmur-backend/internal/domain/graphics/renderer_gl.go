@@ -0,0 +1,26 @@
+//go:build cgo && !nogl
+// +build cgo,!nogl
+
+package graphics
+
+import (
+	"github.com/polis-interactive/go-lighting-utils/pkg/graphicsShader"
+	"sync"
+)
+
+type (
+	ShaderKey         = graphicsShader.ShaderKey
+	ShaderIdentifiers = graphicsShader.ShaderIdentifiers
+	UniformKey        = graphicsShader.UniformKey
+	UniformDict       = graphicsShader.UniformDict
+)
+
+var _ shaderRenderer = (*graphicsShader.GraphicsShader)(nil)
+
+var getShaderPath = graphicsShader.GetShaderPathIfAvailable
+
+func newGLRenderer(
+	shaderPath string, width int32, height int32, ud UniformDict, mu *sync.RWMutex,
+) (shaderRenderer, error) {
+	return graphicsShader.NewGraphicsShader(shaderPath, width, height, ud, mu)
+}
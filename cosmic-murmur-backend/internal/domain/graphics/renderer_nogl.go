@@ -0,0 +1,31 @@
+//go:build !cgo || nogl
+// +build !cgo nogl
+
+package graphics
+
+import (
+	"errors"
+	"sync"
+)
+
+// without graphicsShader there's only the cpu renderer, and it only draws the built in patterns
+
+type ShaderKey string
+
+type ShaderIdentifiers map[ShaderKey]string
+
+type UniformKey string
+
+type UniformDict map[UniformKey]float32
+
+var errBuiltWithoutGl = errors.New("built without gl; set UseCpuRenderer to run on the cpu")
+
+func getShaderPath(programName string) (string, error) {
+	return "", errBuiltWithoutGl
+}
+
+func newGLRenderer(
+	shaderPath string, width int32, height int32, ud UniformDict, mu *sync.RWMutex,
+) (shaderRenderer, error) {
+	return nil, errBuiltWithoutGl
+}
@@ -1,6 +1,9 @@
 package graphics
 
-import "time"
+import (
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/types"
+	"time"
+)
 
 type Repository interface {
 	GetGraphicsReloadOnUpdate() (reloadOnUpdate bool, ok bool)
@@ -9,4 +12,14 @@ type Repository interface {
 	SetGraphicsShader(shaderName string) error
 	GetGraphicsFrequency() (frequency time.Duration, ok bool)
 	SetGraphicsFrequency(frequency time.Duration) error
+	GetGraphicsTransition() (transition types.Transition, ok bool)
+	SetGraphicsTransition(transition types.Transition) error
+	// GetGraphicsUniforms is keyed by shader, then uniform
+	GetGraphicsUniforms() (values map[string]map[string]float32, ok bool)
+	SetGraphicsUniforms(values map[string]map[string]float32) error
+	GetGraphicsPlaylists() (playlists []types.Playlist, ok bool)
+	SetGraphicsPlaylists(playlists []types.Playlist) error
+	// GetGraphicsActivePlaylist is empty when the playlist was stopped
+	GetGraphicsActivePlaylist() (name string, ok bool)
+	SetGraphicsActivePlaylist(name string) error
 }
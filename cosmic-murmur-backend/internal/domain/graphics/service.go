@@ -1,13 +1,13 @@
 package graphics
 
 import (
-	"errors"
 	"fmt"
 	"github.com/polis-interactive/2023-CosmicMurmur/internal/domain"
 	"github.com/polis-interactive/2023-CosmicMurmur/internal/types"
-	"github.com/polis-interactive/go-lighting-utils/pkg/graphicsShader"
 	"log"
+	"sort"
 	"sync"
+	"time"
 )
 
 type service struct {
@@ -93,35 +93,83 @@ func (s *service) GetSettings() (*domain.GraphicsSettings, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 	if s.shutdowns == nil {
-		return nil, errors.New("GraphicsService is down")
+		return nil, fmt.Errorf("%w: GraphicsService is down", domain.ErrUnavailable)
 	}
 	s.g.mu.RLock()
 	defer s.g.mu.RUnlock()
 	if s.g.shaderList == nil {
-		return nil, errors.New("GraphicsLoop is down")
+		return nil, fmt.Errorf("%w: GraphicsLoop is down", domain.ErrUnavailable)
 	}
 	var shaders []string
-	for _, v := range s.g.shaderList {
-		shaders = append(shaders, v)
+	for k := range s.g.shaderList {
+		shaders = append(shaders, string(k))
 	}
-	return &domain.GraphicsSettings{
+	sort.Strings(shaders)
+	settings := &domain.GraphicsSettings{
 		Shaders:        shaders,
+		UserShaders:    s.g.getUserShaders(),
 		RunningShader:  s.g.runningShader,
 		Frequency:      s.g.runningFrequency,
 		ReloadOnUpdate: s.g.runningReloadOnUpdate,
-	}, nil
+		Transition:     s.g.runningTransition,
+		Playlists:      append([]types.Playlist(nil), s.g.playlists...),
+		Uniforms:       make(map[string][]domain.UniformSetting),
+	}
+	for k, declared := range s.g.shaderUniforms {
+		uniforms := make([]domain.UniformSetting, 0, len(declared))
+		for _, u := range declared {
+			value := u.Default
+			if v, ok := s.g.uniformValues[string(k)][u.Name]; ok {
+				value = u.Clamp(v)
+			}
+			uniforms = append(uniforms, domain.UniformSetting{ShaderUniform: u, Value: value})
+		}
+		settings.Uniforms[string(k)] = uniforms
+	}
+	if s.g.playlist != nil {
+		settings.ActivePlaylist = s.g.playlist.playlist.Name
+		settings.PlaylistItem = s.g.playlist.order[s.g.playlist.position]
+	}
+	return settings, nil
+}
+
+// transitions longer than this are more likely a unit mix up than a deliberate choice
+const maxTransitionDuration = 5 * time.Minute
+
+func validateTransition(transition *types.Transition, shaderList ShaderIdentifiers) error {
+	if transition.Duration < 0 || transition.Duration > maxTransitionDuration {
+		return fmt.Errorf(
+			"%w: transition duration must be between 0 and %v, got %v", domain.ErrInvalidSettings,
+			maxTransitionDuration, transition.Duration,
+		)
+	}
+	if !transition.Easing.IsValid() {
+		return fmt.Errorf("%w: easing %q is unknown", domain.ErrInvalidSettings, transition.Easing)
+	}
+	if transition.MaskShader != "" {
+		if _, ok := shaderList[ShaderKey(transition.MaskShader)]; !ok {
+			return fmt.Errorf("%w: Shader %s", domain.ErrNotFound, transition.MaskShader)
+		}
+	}
+	return nil
 }
 
 func (s *service) SetSettings(settings *domain.GraphicsSettableSettings) error {
-	shaderKey := graphicsShader.ShaderKey(settings.ShaderName)
+	if settings.Frequency <= 0 {
+		return fmt.Errorf("%w: frequency must be positive, got %v", domain.ErrInvalidSettings, settings.Frequency)
+	}
+	shaderKey := ShaderKey(settings.ShaderName)
 	err := func() error {
 		s.g.mu.RLock()
 		defer s.g.mu.RUnlock()
 		if s.g.shaderList == nil {
-			return errors.New("GraphicsLoop is Down")
+			return fmt.Errorf("%w: GraphicsLoop is Down", domain.ErrUnavailable)
 		}
 		if _, ok := s.g.shaderList[shaderKey]; !ok {
-			return errors.New(fmt.Sprintf("Shader %s not found", settings.ShaderName))
+			return fmt.Errorf("%w: Shader %s", domain.ErrNotFound, settings.ShaderName)
+		}
+		if settings.Transition != nil {
+			return validateTransition(settings.Transition, s.g.shaderList)
 		}
 		return nil
 	}()
@@ -140,9 +188,261 @@ func (s *service) SetSettings(settings *domain.GraphicsSettableSettings) error {
 	if err != nil {
 		return err
 	}
+	if settings.Transition != nil {
+		err = s.repo.SetGraphicsTransition(*settings.Transition)
+		if err != nil {
+			return err
+		}
+	}
+	err = s.repo.SetGraphicsActivePlaylist("")
+	if err != nil {
+		return err
+	}
 	s.g.mu.Lock()
 	defer s.g.mu.Unlock()
+	s.g.stopPlaylist()
 	s.g.runningFrequency = settings.Frequency
 	s.g.runningReloadOnUpdate = settings.ReloadOnUpdate
+	if settings.Transition != nil {
+		s.g.runningTransition = *settings.Transition
+	}
 	return s.g.setShader(shaderKey)
 }
+
+func validateUniformValues(
+	shaderName string, values map[string]float32,
+	shaderUniforms map[ShaderKey][]types.ShaderUniform,
+) error {
+	for name, v := range values {
+		u, ok := findUniform(shaderUniforms[ShaderKey(shaderName)], name)
+		if !ok {
+			return fmt.Errorf("%w: Shader %s uniform %s", domain.ErrNotFound, shaderName, name)
+		} else if v < u.Min || v > u.Max {
+			return fmt.Errorf(
+				"%w: shader %s uniform %s must be between %g and %g, got %g", domain.ErrInvalidSettings,
+				shaderName, name, u.Min, u.Max, v,
+			)
+		}
+	}
+	return nil
+}
+
+func validatePlaylists(
+	playlists []types.Playlist, shaderList ShaderIdentifiers,
+	shaderUniforms map[ShaderKey][]types.ShaderUniform,
+) error {
+	names := make(map[string]bool)
+	for _, p := range playlists {
+		if p.Name == "" {
+			return fmt.Errorf("%w: playlists need a name", domain.ErrInvalidSettings)
+		} else if names[p.Name] {
+			return fmt.Errorf("%w: playlist %s is defined twice", domain.ErrInvalidSettings, p.Name)
+		}
+		names[p.Name] = true
+		if !p.Mode.IsValid() {
+			return fmt.Errorf("%w: playlist %s has unknown mode %q", domain.ErrInvalidSettings, p.Name, p.Mode)
+		} else if len(p.Items) == 0 {
+			return fmt.Errorf("%w: playlist %s has no items", domain.ErrInvalidSettings, p.Name)
+		}
+		for i, item := range p.Items {
+			if _, ok := shaderList[ShaderKey(item.ShaderName)]; !ok {
+				return fmt.Errorf("%w: Shader %s", domain.ErrNotFound, item.ShaderName)
+			}
+			if item.Duration < time.Second {
+				return fmt.Errorf(
+					"%w: playlist %s item %d must run for at least a second, got %v", domain.ErrInvalidSettings,
+					p.Name, i, item.Duration,
+				)
+			} else if item.Frequency < 0 {
+				return fmt.Errorf(
+					"%w: playlist %s item %d has a negative frequency", domain.ErrInvalidSettings, p.Name, i,
+				)
+			}
+			err := validateUniformValues(item.ShaderName, item.Uniforms, shaderUniforms)
+			if err != nil {
+				return err
+			}
+			if item.Transition != nil {
+				err = validateTransition(item.Transition, shaderList)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// SetPlaylists replaces every playlist; a running playlist that's still defined starts over with its new
+// items, and one that was removed stops
+func (s *service) SetPlaylists(playlists []types.Playlist) error {
+	err := func() error {
+		s.g.mu.RLock()
+		defer s.g.mu.RUnlock()
+		if s.g.shaderList == nil {
+			return fmt.Errorf("%w: GraphicsLoop is Down", domain.ErrUnavailable)
+		}
+		return validatePlaylists(playlists, s.g.shaderList, s.g.shaderUniforms)
+	}()
+	if err != nil {
+		return err
+	}
+	err = s.repo.SetGraphicsPlaylists(playlists)
+	if err != nil {
+		return err
+	}
+	s.g.mu.Lock()
+	defer s.g.mu.Unlock()
+	s.g.playlists = append([]types.Playlist(nil), playlists...)
+	if s.g.playlist == nil {
+		return nil
+	}
+	if playlist, ok := findPlaylist(s.g.playlists, s.g.playlist.playlist.Name); ok {
+		return s.g.startPlaylist(playlist)
+	}
+	s.g.stopPlaylist()
+	return s.repo.SetGraphicsActivePlaylist("")
+}
+
+func (s *service) StartPlaylist(name string) error {
+	playlist, err := func() (types.Playlist, error) {
+		s.g.mu.RLock()
+		defer s.g.mu.RUnlock()
+		if s.g.shaderList == nil {
+			return types.Playlist{}, fmt.Errorf("%w: GraphicsLoop is Down", domain.ErrUnavailable)
+		}
+		playlist, ok := findPlaylist(s.g.playlists, name)
+		if !ok {
+			return types.Playlist{}, fmt.Errorf("%w: Playlist %s", domain.ErrNotFound, name)
+		}
+		return playlist, nil
+	}()
+	if err != nil {
+		return err
+	}
+	err = s.repo.SetGraphicsActivePlaylist(name)
+	if err != nil {
+		return err
+	}
+	s.g.mu.Lock()
+	defer s.g.mu.Unlock()
+	return s.g.startPlaylist(playlist)
+}
+
+func (s *service) StopPlaylist() error {
+	err := s.repo.SetGraphicsActivePlaylist("")
+	if err != nil {
+		return err
+	}
+	s.g.mu.Lock()
+	defer s.g.mu.Unlock()
+	s.g.stopPlaylist()
+	return nil
+}
+
+func (s *service) SkipPlaylist() error {
+	s.g.mu.Lock()
+	defer s.g.mu.Unlock()
+	if s.g.playlist == nil {
+		return fmt.Errorf("%w: no playlist is running", domain.ErrNotFound)
+	}
+	return s.g.skipPlaylistItem(time.Now())
+}
+
+// SetUniforms saves values for some of a shader's declared uniforms; they apply from the next frame
+func (s *service) SetUniforms(shaderName string, values map[string]float32) error {
+	uniformValues, err := func() (map[string]map[string]float32, error) {
+		s.g.mu.RLock()
+		defer s.g.mu.RUnlock()
+		if s.g.shaderList == nil {
+			return nil, fmt.Errorf("%w: GraphicsLoop is Down", domain.ErrUnavailable)
+		}
+		if _, ok := s.g.shaderList[ShaderKey(shaderName)]; !ok {
+			return nil, fmt.Errorf("%w: Shader %s", domain.ErrNotFound, shaderName)
+		}
+		err := validateUniformValues(shaderName, values, s.g.shaderUniforms)
+		if err != nil {
+			return nil, err
+		}
+		uniformValues := make(map[string]map[string]float32)
+		for k, v := range s.g.uniformValues {
+			uniformValues[k] = v
+		}
+		shaderValues := make(map[string]float32)
+		for k, v := range s.g.uniformValues[shaderName] {
+			shaderValues[k] = v
+		}
+		for k, v := range values {
+			shaderValues[k] = v
+		}
+		uniformValues[shaderName] = shaderValues
+		return uniformValues, nil
+	}()
+	if err != nil {
+		return err
+	}
+	err = s.repo.SetGraphicsUniforms(uniformValues)
+	if err != nil {
+		return err
+	}
+	s.g.mu.Lock()
+	defer s.g.mu.Unlock()
+	s.g.uniformValues = uniformValues
+	return nil
+}
+
+// UploadShader adds a shader, or replaces one uploaded before, once it compiles
+func (s *service) UploadShader(name string, frag string, vert string) error {
+	err := validateShaderName(name)
+	if err != nil {
+		return err
+	}
+	// the cpu renderer only runs its built in patterns; an upload would be stored but render black
+	if s.g.useCpuRenderer {
+		return fmt.Errorf("%w: the cpu renderer can't run uploaded shaders", domain.ErrInvalidSettings)
+	}
+	err = checkShaderSyntax(frag, vert)
+	if err != nil {
+		return err
+	}
+	return s.g.runOnLoop(func() error {
+		return s.g.uploadShader(name, frag, vert)
+	})
+}
+
+func (s *service) DeleteShader(name string) error {
+	err := s.g.deleteShader(name)
+	if err != nil {
+		return err
+	}
+	return s.moveUniformValues(name, "")
+}
+
+func (s *service) RenameShader(name string, newName string) error {
+	err := validateShaderName(newName)
+	if err != nil {
+		return err
+	}
+	err = s.g.runOnLoop(func() error {
+		return s.g.renameShader(name, newName)
+	})
+	if err != nil {
+		return err
+	}
+	return s.moveUniformValues(name, newName)
+}
+
+func (s *service) moveUniformValues(name string, newName string) error {
+	s.g.mu.Lock()
+	defer s.g.mu.Unlock()
+	if _, ok := s.g.uniformValues[name]; !ok {
+		return nil
+	}
+	uniformValues := moveUniformValues(s.g.uniformValues, name, newName)
+	err := s.repo.SetGraphicsUniforms(uniformValues)
+	if err != nil {
+		return err
+	}
+	s.g.uniformValues = uniformValues
+	return nil
+}
@@ -0,0 +1,99 @@
+package graphics
+
+import (
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/types"
+	"math"
+	"time"
+)
+
+/*
+	a transition renders the outgoing shader, the mask (if any) and the incoming shader every frame, and blends
+	them into the pixel buffer. The mask is latched; once a pixel of the mask has lit up, that pixel stays as
+	far switched over as the mask took it, so a wipe's band leaves the new shader behind it. The eased
+	progress is a floor under every pixel, so the whole piece has switched once the duration is up.
+	The mask doesn't run on the shaders' time; its time goes from 0 to maskSweep over the duration, which is
+	one full turn of the wipes' bands (time * 0.1 * PI), so the band has passed every pixel by the end
+*/
+
+const maskSweep = 20.0
+
+type transition struct {
+	from     ShaderKey
+	to       ShaderKey
+	mask     ShaderKey
+	easing   types.Easing
+	start    time.Time
+	duration time.Duration
+
+	fromFrame []types.Color
+	toFrame   []types.Color
+	maskFrame []types.Color
+	revealed  []float64
+}
+
+func newTransition(
+	from, to, mask ShaderKey, settings types.Transition, frameSize int, start time.Time,
+) *transition {
+	t := &transition{
+		from:      from,
+		to:        to,
+		mask:      mask,
+		easing:    settings.Easing,
+		start:     start,
+		duration:  settings.Duration,
+		fromFrame: make([]types.Color, frameSize),
+		toFrame:   make([]types.Color, frameSize),
+	}
+	if mask != "" {
+		t.maskFrame = make([]types.Color, frameSize)
+		t.revealed = make([]float64, frameSize)
+	}
+	return t
+}
+
+func (t *transition) progress(now time.Time) float64 {
+	return float64(now.Sub(t.start)) / float64(t.duration)
+}
+
+func (t *transition) maskTime(now time.Time) float32 {
+	return float32(math.Min(math.Max(t.progress(now), 0.0), 1.0) * maskSweep)
+}
+
+func (t *transition) isDone(now time.Time) bool {
+	return t.progress(now) >= 1.0
+}
+
+// blend mixes the last rendered from / to frames into out
+func (t *transition) blend(out []types.Color, now time.Time) {
+	eased := t.easing.Apply(t.progress(now))
+	for i := range out {
+		mix := eased
+		if t.maskFrame != nil {
+			m := t.maskFrame[i]
+			lit := float64(max3(m.R, m.G, m.B)) / 255.0
+			t.revealed[i] = math.Max(t.revealed[i], lit)
+			mix = math.Max(mix, t.revealed[i])
+		}
+		from, to := t.fromFrame[i], t.toFrame[i]
+		out[i] = types.Color{
+			R: mixChannel(from.R, to.R, mix),
+			G: mixChannel(from.G, to.G, mix),
+			B: mixChannel(from.B, to.B, mix),
+			W: to.W,
+		}
+	}
+}
+
+func mixChannel(from, to uint8, mix float64) uint8 {
+	return uint8(math.Round(float64(from) + (float64(to)-float64(from))*mix))
+}
+
+func max3(a, b, c uint8) uint8 {
+	if b > a {
+		a = b
+	}
+	if c > a {
+		a = c
+	}
+	return a
+}
@@ -0,0 +1,145 @@
+package graphics
+
+import (
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/types"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestEasing_apply(t *testing.T) {
+	for _, e := range []types.Easing{types.EasingLinear, types.EasingIn, types.EasingOut, types.EasingInOut} {
+		if e.Apply(-1) != 0 || e.Apply(0) != 0 || e.Apply(1) != 1 || e.Apply(2) != 1 {
+			t.Errorf("%s doesn't run from 0 to 1", e)
+		}
+	}
+	if v := types.EasingInOut.Apply(0.5); v != 0.5 {
+		t.Errorf("easeInOut at half way = %g", v)
+	}
+	if types.EasingIn.Apply(0.5) >= 0.5 || types.EasingOut.Apply(0.5) <= 0.5 {
+		t.Error("expected easeIn to lag and easeOut to lead linear")
+	}
+}
+
+func TestTransition_blend(t *testing.T) {
+	start := time.Now()
+	settings := types.Transition{Duration: time.Second, Easing: types.EasingLinear}
+	tr := newTransition("basic", "snake_wipe", "", settings, 2, start)
+	tr.fromFrame[0] = types.Color{R: 200, W: 255}
+	tr.toFrame[0] = types.Color{B: 100, W: 255}
+	out := make([]types.Color, 2)
+
+	tr.blend(out, start.Add(500*time.Millisecond))
+	if out[0] != (types.Color{R: 100, B: 50, W: 255}) {
+		t.Errorf("crossfade at half way = %v", out[0])
+	}
+	if tr.isDone(start.Add(999*time.Millisecond)) || !tr.isDone(start.Add(time.Second)) {
+		t.Error("expected the transition to be done at its duration")
+	}
+
+	tr = newTransition("basic", "snake_wipe", "horizontal_wipe", settings, 2, start)
+	tr.fromFrame[0], tr.fromFrame[1] = types.Color{R: 255}, types.Color{R: 255}
+	tr.toFrame[0], tr.toFrame[1] = types.Color{G: 255}, types.Color{G: 255}
+	// the mask's band passes over the first pixel, then moves off
+	tr.maskFrame[0] = types.Color{R: 255, G: 255, B: 255}
+	tr.blend(out, start.Add(100*time.Millisecond))
+	tr.maskFrame[0] = types.Color{}
+	tr.blend(out, start.Add(200*time.Millisecond))
+	if out[0] != (types.Color{G: 255}) {
+		t.Errorf("pixel the mask passed over = %v; expected it to stay switched", out[0])
+	}
+	if out[1] != (types.Color{R: 204, G: 51}) {
+		t.Errorf("pixel the mask hasn't reached = %v; expected it to follow the crossfade", out[1])
+	}
+}
+
+func TestGraphics_transitionLeavesRendererOnIncomingShader(t *testing.T) {
+	g := &Graphics{
+		mu:                &sync.RWMutex{},
+		ud:                UniformDict{"time": 0.0, "pixel": 1.0},
+		shaderList:        getPatterns(),
+		runningShader:     "basic",
+		runningTransition: types.Transition{Duration: time.Hour, Easing: types.EasingLinear},
+		frameSize:         8,
+		pb:                types.NewPixelBuffer(4, 2, 0, 0, 1),
+	}
+	ps := newPatternShader(4, 2, g.ud, g.mu)
+	g.gs = ps
+	if err := ps.AttachShaders(g.shaderList); err != nil {
+		t.Fatal(err)
+	}
+	if err := ps.SetShader("basic"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := g.setShader("snake_wipe"); err != nil {
+		t.Fatal(err)
+	}
+	if g.transition == nil || g.transition.from != "basic" {
+		t.Fatalf("transition = %v; expected one from basic", g.transition)
+	}
+	if err := g.doRenderFrame(); err != nil {
+		t.Fatal(err)
+	}
+	if ps.currentShader != "snake_wipe" {
+		t.Errorf("renderer left on %s", ps.currentShader)
+	}
+	// an hour long transition has barely started, so the frame is still the outgoing shader
+	p := types.CreatePoint(0, 0)
+	if c := g.pb.GetPixel(&p); c != g.transition.fromFrame[0] {
+		t.Errorf("pixel = %v, outgoing = %v", c, g.transition.fromFrame[0])
+	}
+
+	g.transition.start = time.Now().Add(-time.Hour)
+	if err := g.doRenderFrame(); err != nil {
+		t.Fatal(err)
+	}
+	if g.transition != nil {
+		t.Error("expected the transition to finish")
+	}
+}
+
+func TestGraphics_wipeMaskCoversFrame(t *testing.T) {
+	g := &Graphics{
+		mu:            &sync.RWMutex{},
+		ud:            UniformDict{"time": 3.0, "pixel": 1.0},
+		shaderList:    getPatterns(),
+		runningShader: "basic",
+		runningTransition: types.Transition{
+			Duration: time.Hour, Easing: types.EasingLinear, MaskShader: "horizontal_wipe",
+		},
+		frameSize: 8,
+		pb:        types.NewPixelBuffer(4, 2, 0, 0, 1),
+	}
+	ps := newPatternShader(4, 2, g.ud, g.mu)
+	g.gs = ps
+	if err := ps.AttachShaders(g.shaderList); err != nil {
+		t.Fatal(err)
+	}
+	if err := ps.SetShader("basic"); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.setShader("snake_wipe"); err != nil {
+		t.Fatal(err)
+	}
+	tr := g.transition
+	if tr == nil || tr.mask != "horizontal_wipe" {
+		t.Fatalf("transition = %v; expected one masked by horizontal_wipe", tr)
+	}
+
+	// step through the transition, whatever the shaders' own time is
+	for step := 0; step < 100; step++ {
+		tr.start = time.Now().Add(-time.Duration(float64(tr.duration) * float64(step) / 100))
+		if err := g.doRunTransition(tr); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i, r := range tr.revealed {
+		if r < 0.9 {
+			t.Errorf("pixel %d revealed %g; expected the wipe to have passed it", i, r)
+		}
+	}
+	if g.ud["time"] != 3.0 {
+		t.Errorf("time = %g; expected the mask pass to put it back", g.ud["time"])
+	}
+}
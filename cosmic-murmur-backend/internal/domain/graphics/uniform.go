@@ -0,0 +1,121 @@
+package graphics
+
+import (
+	"fmt"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/types"
+	"io/ioutil"
+	"log"
+	"math"
+	"path"
+	"regexp"
+	"strconv"
+)
+
+/*
+	shaders declare settable uniforms with a range comment on the declaration, and optionally a default
+	(otherwise the min) after it:
+
+		uniform float speed; // @range 0 10 2
+
+	only annotated float uniforms are picked up; the ones the graphics loop drives itself can't be declared
+*/
+
+var uniformDeclaration = regexp.MustCompile(
+	`(?m)^[ \t]*uniform[ \t]+float[ \t]+(\w+)[ \t]*;[ \t]*//[ \t]*@range[ \t]+(\S+)[ \t]+(\S+)(?:[ \t]+(\S+))?`,
+)
+
+var reservedUniforms = map[string]bool{"time": true, "pixel": true, "resolution": true}
+
+func parseUniforms(source string) ([]types.ShaderUniform, error) {
+	var uniforms []types.ShaderUniform
+	for _, m := range uniformDeclaration.FindAllStringSubmatch(source, -1) {
+		name := m[1]
+		if reservedUniforms[name] {
+			return nil, fmt.Errorf("uniform %s is set by the graphics loop", name)
+		}
+		bounds := make([]float32, 0, 3)
+		for _, s := range m[2:] {
+			if s == "" {
+				continue
+			}
+			v, err := strconv.ParseFloat(s, 32)
+			if err != nil {
+				return nil, fmt.Errorf("uniform %s has a bad range; %s", name, err.Error())
+			} else if math.IsNaN(v) || math.IsInf(v, 0) {
+				// NaN would slip through every comparison below
+				return nil, fmt.Errorf("uniform %s has a bad range; %s isn't finite", name, s)
+			}
+			bounds = append(bounds, float32(v))
+		}
+		u := types.ShaderUniform{
+			Name: name, Type: types.UniformTypeFloat, Min: bounds[0], Max: bounds[1], Default: bounds[0],
+		}
+		if u.Min >= u.Max {
+			return nil, fmt.Errorf("uniform %s has an empty range", name)
+		}
+		if len(bounds) == 3 {
+			if bounds[2] < u.Min || bounds[2] > u.Max {
+				return nil, fmt.Errorf("uniform %s defaults outside its range", name)
+			}
+			u.Default = bounds[2]
+		}
+		uniforms = append(uniforms, u)
+	}
+	return uniforms, nil
+}
+
+// getShaderUniforms reads each shader's declarations; a shader that declares them badly just gets none
+func (g *Graphics) getShaderUniforms(
+	shaders ShaderIdentifiers,
+) map[ShaderKey][]types.ShaderUniform {
+	uniforms := make(map[ShaderKey][]types.ShaderUniform)
+	for k, fileName := range shaders {
+		if g.useCpuRenderer && !isUserShader(fileName) {
+			// the built in patterns are go ports, there's no source to read
+			continue
+		}
+		source, err := ioutil.ReadFile(path.Join(g.shaderPath, fileName+".frag"))
+		if err != nil {
+			log.Println(fmt.Sprintf("Graphics, getShaderUniforms: couldn't read shader %s; %s", k, err.Error()))
+			continue
+		}
+		declared, err := parseUniforms(string(source))
+		if err != nil {
+			log.Println(fmt.Sprintf("Graphics, getShaderUniforms: ignoring shader %s uniforms; %s", k, err.Error()))
+			continue
+		}
+		uniforms[k] = declared
+	}
+	return uniforms
+}
+
+func findUniform(uniforms []types.ShaderUniform, name string) (types.ShaderUniform, bool) {
+	for _, u := range uniforms {
+		if u.Name == name {
+			return u, true
+		}
+	}
+	return types.ShaderUniform{}, false
+}
+
+// uniformValue is what shader runs a declared uniform with: the running playlist item's override, then the
+// saved value, then the default
+func (g *Graphics) uniformValue(shader ShaderKey, u types.ShaderUniform) float32 {
+	if g.playlist != nil && g.playlist.item().ShaderName == string(shader) {
+		if v, ok := g.playlist.item().Uniforms[u.Name]; ok {
+			return u.Clamp(v)
+		}
+	}
+	if v, ok := g.uniformValues[string(shader)][u.Name]; ok {
+		return u.Clamp(v)
+	}
+	return u.Default
+}
+
+// applyUniforms loads shader's uniforms into the dict the renderer reads; shaders share the dict, so this has
+// to happen before every run
+func (g *Graphics) applyUniforms(shader ShaderKey) {
+	for _, u := range g.shaderUniforms[shader] {
+		g.ud[UniformKey(u.Name)] = g.uniformValue(shader, u)
+	}
+}
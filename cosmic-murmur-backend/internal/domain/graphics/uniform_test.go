@@ -0,0 +1,50 @@
+package graphics
+
+import (
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/types"
+	"testing"
+)
+
+func TestParseUniforms(t *testing.T) {
+	source := `
+precision mediump float;
+uniform vec2 resolution;
+uniform float time;
+uniform float speed; // @range 0 10 2
+  uniform float hue;//@range -1 1
+uniform float unannotated;
+// uniform float commented; @range 0 1
+`
+	uniforms, err := parseUniforms(source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []types.ShaderUniform{
+		{Name: "speed", Type: types.UniformTypeFloat, Min: 0, Max: 10, Default: 2},
+		{Name: "hue", Type: types.UniformTypeFloat, Min: -1, Max: 1, Default: -1},
+	}
+	if len(uniforms) != len(expected) {
+		t.Fatalf("uniforms = %v", uniforms)
+	}
+	for i := range expected {
+		if uniforms[i] != expected[i] {
+			t.Errorf("uniform %d = %v, expected %v", i, uniforms[i], expected[i])
+		}
+	}
+
+	for _, bad := range []string{
+		"uniform float time; // @range 0 10",
+		"uniform float speed; // @range 10 0",
+		"uniform float speed; // @range 0 10 11",
+		"uniform float speed; // @range 0 fast",
+		"uniform float speed; // @range NaN 10",
+		"uniform float speed; // @range 0 NaN",
+		"uniform float speed; // @range -Inf Inf",
+		"uniform float speed; // @range 0 10 NaN",
+		"uniform float speed; // @range 1 1",
+	} {
+		if _, err = parseUniforms(bad); err == nil {
+			t.Errorf("expected %q to be rejected", bad)
+		}
+	}
+}
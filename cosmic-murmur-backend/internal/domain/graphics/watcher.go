@@ -0,0 +1,194 @@
+package graphics
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path"
+	"sort"
+	"time"
+)
+
+/*
+	a watcher goroutine polls the shader path for changes and hands them to the graphics loop as a task.
+	Shaders added or removed on disk always come and go from the list; ReloadOnUpdate only gates recompiling
+	the ones that were edited, which happens on the loop, where the gl context is. A shader that stops compiling is reported to the stream and the loop carries on; while it's broken
+	it renders whatever the driver makes of it, so it may go dark until it's fixed.
+
+	it polls modification times rather than subscribing to the os; a handful of stats every period is nothing
+	next to rendering, and it works the same on every platform the installation runs on
+*/
+
+const shaderWatchPeriod = 250 * time.Millisecond
+
+type shaderFile struct {
+	fileName string
+	// the later of the .frag / .vert times; zero for the cpu renderer's built ins, which have no files
+	modified time.Time
+}
+
+type shaderFiles map[ShaderKey]shaderFile
+
+type shaderChanges struct {
+	added    []ShaderKey
+	removed  []ShaderKey
+	modified []ShaderKey
+}
+
+func (c shaderChanges) isEmpty() bool {
+	return len(c.added) == 0 && len(c.removed) == 0 && len(c.modified) == 0
+}
+
+// scanShaders lists the shaders the way the loop does at startup, with when each last changed
+func (g *Graphics) scanShaders() (shaderFiles, error) {
+	shaders, err := g.getShaders()
+	if err != nil {
+		return nil, err
+	}
+	files := make(shaderFiles, len(shaders))
+	for k, fileName := range shaders {
+		f := shaderFile{fileName: fileName}
+		for _, ext := range []string{".frag", ".vert"} {
+			info, err := os.Stat(path.Join(g.shaderPath, fileName+ext))
+			if err == nil && info.ModTime().After(f.modified) {
+				f.modified = info.ModTime()
+			}
+		}
+		files[k] = f
+	}
+	return files, nil
+}
+
+func diffShaderFiles(before shaderFiles, after shaderFiles) shaderChanges {
+	var changes shaderChanges
+	for k, f := range after {
+		if old, ok := before[k]; !ok {
+			changes.added = append(changes.added, k)
+		} else if !f.modified.Equal(old.modified) {
+			changes.modified = append(changes.modified, k)
+		}
+	}
+	for k := range before {
+		if _, ok := after[k]; !ok {
+			changes.removed = append(changes.removed, k)
+		}
+	}
+	for _, keys := range [][]ShaderKey{changes.added, changes.removed, changes.modified} {
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	}
+	return changes
+}
+
+// withoutModified holds edits back from changes, keeping their old times in files so they still show up as
+// modified once ReloadOnUpdate is turned on
+func withoutModified(before shaderFiles, after shaderFiles, changes shaderChanges) (shaderFiles, shaderChanges) {
+	held := make(shaderFiles, len(after))
+	for k, f := range after {
+		held[k] = f
+	}
+	for _, k := range changes.modified {
+		held[k] = before[k]
+	}
+	changes.modified = nil
+	return held, changes
+}
+
+func (g *Graphics) isReloadingShaders() bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.runningReloadOnUpdate
+}
+
+// runShaderWatcher runs alongside the graphics loop until done closes; files is what the loop started with.
+// While ReloadOnUpdate is off, edits wait, so turning it on picks up whatever changed in the meantime
+func (g *Graphics) runShaderWatcher(files shaderFiles, done <-chan struct{}) {
+	for {
+		select {
+		case <-done:
+			return
+		case <-time.After(shaderWatchPeriod):
+		}
+		scanned, err := g.scanShaders()
+		if err != nil {
+			log.Println(fmt.Sprintf("Graphics, runShaderWatcher: couldn't scan shaders; %s", err.Error()))
+			continue
+		}
+		changes := diffShaderFiles(files, scanned)
+		if !g.isReloadingShaders() {
+			scanned, changes = withoutModified(files, scanned, changes)
+		}
+		if changes.isEmpty() {
+			continue
+		}
+		select {
+		case g.tasks <- func() { g.applyShaderChanges(scanned, changes) }:
+			files = scanned
+		case <-done:
+			return
+		}
+	}
+}
+
+// applyShaderChanges brings the loop in line with what's on disk. Uploads, deletes and renames through the
+// api show up here too, after the fact, so anything already applied is skipped
+func (g *Graphics) applyShaderChanges(files shaderFiles, changes shaderChanges) {
+	type shaderError struct {
+		key ShaderKey
+		err error
+	}
+	var shaderErrors []shaderError
+	listChanged := false
+
+	g.mu.Lock()
+
+	for _, key := range changes.removed {
+		if _, ok := g.shaderList[key]; !ok {
+			continue
+		}
+		if inUse := g.shaderInUse(key); inUse != "" {
+			shaderErrors = append(shaderErrors, shaderError{key, fmt.Errorf("removed from disk, but %s; it stays loaded", inUse)})
+			continue
+		}
+		delete(g.shaderList, key)
+		delete(g.shaderUniforms, key)
+		g.retired[key] = true
+		listChanged = true
+	}
+
+	for _, key := range append(changes.added, changes.modified...) {
+		fileName := files[key].fileName
+		if g.useCpuRenderer && !isUserShader(fileName) {
+			continue
+		}
+		source, err := os.ReadFile(path.Join(g.shaderPath, fileName+".frag"))
+		if err != nil {
+			// most likely mid save; the next change will bring it back round
+			continue
+		}
+		uniforms, err := parseUniforms(string(source))
+		if err == nil {
+			err = g.compileShader(key, fileName)
+		}
+		if err != nil {
+			shaderErrors = append(shaderErrors, shaderError{key, err})
+			continue
+		}
+		_, known := g.shaderList[key]
+		g.shaderUniforms[key] = uniforms
+		if !known {
+			g.shaderList[key] = fileName
+			delete(g.retired, key)
+			listChanged = true
+		}
+	}
+	g.mu.Unlock()
+
+	// the bus calls wait until the lock is released, the event loop might be waiting on it
+	for _, e := range shaderErrors {
+		log.Println(fmt.Sprintf("Graphics, applyShaderChanges: shader %s; %s", e.key, e.err.Error()))
+		g.s.bus.EmitShaderError(string(e.key), e.err)
+	}
+	if listChanged {
+		g.s.bus.EmitGraphicsShadersChanged()
+	}
+}
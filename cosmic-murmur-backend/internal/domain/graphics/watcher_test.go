@@ -0,0 +1,141 @@
+package graphics
+
+import (
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/types"
+	"os"
+	"path"
+	"sync"
+	"testing"
+	"time"
+)
+
+type testBus struct {
+	shaderErrors   []string
+	shadersChanged int
+	// onGridDimensions stands in for the event loop serving the request
+	onGridDimensions func()
+}
+
+func (b *testBus) GetGridDimensions() *types.Grid {
+	if b.onGridDimensions != nil {
+		b.onGridDimensions()
+	}
+	return &types.Grid{MaxX: 2, MaxY: 1}
+}
+
+func (b *testBus) EmitGraphicsCrashed() {}
+
+func (b *testBus) EmitGraphicsReady() {}
+
+func (b *testBus) EmitGraphicsShadersChanged() {
+	b.shadersChanged += 1
+}
+
+func (b *testBus) EmitShaderError(shaderName string, err error) {
+	b.shaderErrors = append(b.shaderErrors, shaderName)
+}
+
+func TestGraphics_applyShaderChanges(t *testing.T) {
+	bus := &testBus{}
+	g := &Graphics{
+		s:                &service{bus: bus},
+		mu:               &sync.RWMutex{},
+		ud:               UniformDict{"time": 0.0, "pixel": 1.0},
+		useCpuRenderer:   true,
+		shaderPath:       t.TempDir(),
+		shaderList:       getPatterns(),
+		shaderUniforms:   map[ShaderKey][]types.ShaderUniform{},
+		runningShader:    "basic",
+		runningFrequency: 33 * time.Millisecond,
+		retired:          map[ShaderKey]bool{},
+	}
+	g.gs = newPatternShader(4, 2, g.ud, g.mu)
+	if err := g.gs.AttachShaders(g.shaderList); err != nil {
+		t.Fatal(err)
+	}
+	base := path.Join(g.shaderPath, userShaderDir, "ripple")
+	if err := os.MkdirAll(path.Dir(base), 0755); err != nil {
+		t.Fatal(err)
+	}
+	files, err := g.scanShaders()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// applies whatever changed since the last scan, the way the watcher hands it to the loop
+	rescan := func() shaderChanges {
+		scanned, err := g.scanShaders()
+		if err != nil {
+			t.Fatal(err)
+		}
+		changes := diffShaderFiles(files, scanned)
+		g.applyShaderChanges(scanned, changes)
+		files = scanned
+		return changes
+	}
+	writeShader := func(frag string, modified time.Time) {
+		for ext, source := range map[string]string{".frag": frag, ".vert": testVert} {
+			if err := os.WriteFile(base+ext, []byte(source), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.Chtimes(base+ext, modified, modified); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	if changes := rescan(); !changes.isEmpty() {
+		t.Errorf("nothing changed, got %v", changes)
+	}
+
+	now := time.Now()
+	writeShader(testFrag, now)
+	changes := rescan()
+	if len(changes.added) != 1 || g.shaderList["ripple"] != "user/ripple" || bus.shadersChanged != 1 {
+		t.Errorf("adding: changes = %v, shaders = %v", changes, g.shaderList)
+	}
+	if u := g.shaderUniforms["ripple"]; len(u) != 1 || u[0].Name != "speed" {
+		t.Errorf("adding: uniforms = %v", u)
+	}
+
+	writeShader("uniform float time; // @range 0 1\nvoid main() {}", now.Add(time.Second))
+	changes = rescan()
+	if len(changes.modified) != 1 || len(bus.shaderErrors) != 1 || len(g.shaderUniforms["ripple"]) != 1 {
+		t.Errorf("breaking: changes = %v, errors = %v", changes, bus.shaderErrors)
+	}
+
+	if err = g.setShader("ripple"); err != nil {
+		t.Fatal(err)
+	}
+	_ = os.Remove(base + ".frag")
+	changes = rescan()
+	if len(changes.removed) != 1 || g.shaderList["ripple"] == "" || len(bus.shaderErrors) != 2 {
+		t.Errorf("removing the running shader: changes = %v, errors = %v", changes, bus.shaderErrors)
+	}
+
+	if err = g.setShader("basic"); err != nil {
+		t.Fatal(err)
+	}
+	writeShader(testFrag, now.Add(2*time.Second))
+	rescan()
+	_ = os.Remove(base + ".frag")
+	rescan()
+	if _, ok := g.shaderList["ripple"]; ok || !g.retired["ripple"] || bus.shadersChanged != 2 {
+		t.Errorf("removing: shaders = %v, retired = %v", g.shaderList, g.retired)
+	}
+}
+
+func TestWithoutModified(t *testing.T) {
+	then := time.Now()
+	before := shaderFiles{"basic": {fileName: "basic", modified: then}, "ripple": {fileName: "user/ripple", modified: then}}
+	after := shaderFiles{"basic": {fileName: "basic", modified: then.Add(time.Second)}, "waves": {fileName: "user/waves"}}
+
+	held, changes := withoutModified(before, after, diffShaderFiles(before, after))
+	if len(changes.added) != 1 || len(changes.removed) != 1 || len(changes.modified) != 0 {
+		t.Errorf("changes = %v; expected the add and remove without the edit", changes)
+	}
+	// once reloading is back on, the edit is still there to pick up
+	changes = diffShaderFiles(held, after)
+	if len(changes.modified) != 1 || changes.modified[0] != "basic" || len(changes.added) != 0 || len(changes.removed) != 0 {
+		t.Errorf("changes = %v; expected only the held back edit", changes)
+	}
+}
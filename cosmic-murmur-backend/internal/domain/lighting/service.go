@@ -1,6 +1,7 @@
 package lighting
 
 import (
+	"fmt"
 	"github.com/polis-interactive/2023-CosmicMurmur/internal/domain"
 	"github.com/polis-interactive/2023-CosmicMurmur/internal/types"
 	"log"
@@ -73,10 +74,15 @@ func (s *service) doCreateLights() {
 		// keeps track of strings in the current section
 		seenStrings := 0
 		for universeNumber, universe := range s.segmentDefinition {
-			lights := make([]*types.Light, 0, 171)
+			pixelCount := 0
+			for _, ledString := range universe {
+				pixelCount += ledString.LedCount * ledString.StringCount
+			}
+			lights := make([]*types.Light, 0, pixelCount)
 			// first string in universe always starts on the bottom
 			evenString := true
 			nextPixel := 0
+			nextChannel := 0
 			for _, ledString := range universe {
 				// strings are always odd numbered
 				aboveBelow := (ledString.LedCount - 1) / 2
@@ -96,9 +102,12 @@ func (s *service) doCreateLights() {
 						newLight := &types.Light{
 							Position: types.CreatePoint(stringXPosition, ledYPosition),
 							Pixel:    nextPixel,
+							Channel:  nextChannel,
+							Format:   ledString.PixelFormat,
 							Color:    types.Color{},
 						}
 						nextPixel += 1
+						nextChannel += ledString.PixelFormat.ChannelCount()
 						lights = append(lights, newLight)
 					}
 					evenString = !evenString
@@ -132,8 +141,59 @@ func (s *service) GetSettings() *domain.LightingSettings {
 	}
 }
 
+// a dmx universe is 512 channels; 170 rgb pixels, 128 rgbw, 85 16 bit rgb
+const channelsPerUniverse = 512
+
+func validateSettings(settings *domain.LightingSettings) error {
+	if settings.SegmentCount < 1 {
+		return fmt.Errorf("%w: segment count must be positive, got %d", domain.ErrInvalidSettings, settings.SegmentCount)
+	}
+	if len(settings.SegmentDefinition) == 0 {
+		return fmt.Errorf("%w: segment definition has no universes", domain.ErrInvalidSettings)
+	}
+	for universeNumber, universe := range settings.SegmentDefinition {
+		if len(universe) == 0 {
+			return fmt.Errorf("%w: universe %d has no strings", domain.ErrInvalidSettings, universeNumber)
+		}
+		channelCount := 0
+		for _, ledString := range universe {
+			// strings are centered on y = 0, so they need a middle led
+			if ledString.LedCount < 1 || ledString.LedCount%2 == 0 {
+				return fmt.Errorf(
+					"%w: universe %d has led count %d; led counts must be odd",
+					domain.ErrInvalidSettings, universeNumber, ledString.LedCount,
+				)
+			}
+			if ledString.StringCount < 1 {
+				return fmt.Errorf(
+					"%w: universe %d has string count %d; string counts must be positive",
+					domain.ErrInvalidSettings, universeNumber, ledString.StringCount,
+				)
+			}
+			if !ledString.PixelFormat.IsValid() {
+				return fmt.Errorf(
+					"%w: universe %d has unknown pixel format %q",
+					domain.ErrInvalidSettings, universeNumber, ledString.PixelFormat,
+				)
+			}
+			channelCount += ledString.LedCount * ledString.StringCount * ledString.PixelFormat.ChannelCount()
+		}
+		if channelCount > channelsPerUniverse {
+			return fmt.Errorf(
+				"%w: universe %d needs %d channels; at most %d fit in a universe",
+				domain.ErrInvalidSettings, universeNumber, channelCount, channelsPerUniverse,
+			)
+		}
+	}
+	return nil
+}
+
 func (s *service) SetSettings(settings *domain.LightingSettings) error {
-	err := s.repo.SetLightingSegmentDefinition(settings.SegmentDefinition)
+	err := validateSettings(settings)
+	if err != nil {
+		return err
+	}
+	err = s.repo.SetLightingSegmentDefinition(settings.SegmentDefinition)
 	if err != nil {
 		return err
 	}
@@ -1,8 +1,10 @@
 package lighting
 
 import (
+	"errors"
 	"fmt"
 	"github.com/polis-interactive/2023-CosmicMurmur/data"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/domain"
 	"github.com/polis-interactive/2023-CosmicMurmur/internal/types"
 	"log"
 	"testing"
@@ -853,3 +855,80 @@ func TestService_doCreateLights(t *testing.T) {
 		t.Fatal("Lighting grid 5 does not match template")
 	}
 }
+
+func TestValidateSettings(t *testing.T) {
+	valid := &domain.LightingSettings{
+		SegmentDefinition: data.DefaultLightingSegmentDefinition,
+		SegmentCount:      1,
+	}
+	if err := validateSettings(valid); err != nil {
+		t.Fatalf("default settings should be valid; %s", err.Error())
+	}
+	invalid := map[string]*domain.LightingSettings{
+		"no segments": {
+			SegmentDefinition: data.DefaultLightingSegmentDefinition,
+			SegmentCount:      0,
+		},
+		"even led count": {
+			SegmentDefinition: types.LedSegment{
+				types.LedUniverse{types.LedString{LedCount: 4, StringCount: 1}},
+			},
+			SegmentCount: 1,
+		},
+		"no strings": {
+			SegmentDefinition: types.LedSegment{
+				types.LedUniverse{types.LedString{LedCount: 3, StringCount: 0}},
+			},
+			SegmentCount: 1,
+		},
+		"overfull universe": {
+			SegmentDefinition: types.LedSegment{
+				types.LedUniverse{types.LedString{LedCount: 11, StringCount: 16}},
+			},
+			SegmentCount: 1,
+		},
+		// 129 pixels fit as rgb but not rgbw
+		"overfull rgbw universe": {
+			SegmentDefinition: types.LedSegment{
+				types.LedUniverse{types.LedString{LedCount: 43, StringCount: 3, PixelFormat: types.PixelFormatRGBW}},
+			},
+			SegmentCount: 1,
+		},
+		"unknown pixel format": {
+			SegmentDefinition: types.LedSegment{
+				types.LedUniverse{types.LedString{LedCount: 3, StringCount: 1, PixelFormat: "RGBA"}},
+			},
+			SegmentCount: 1,
+		},
+	}
+	for name, settings := range invalid {
+		if err := validateSettings(settings); !errors.Is(err, domain.ErrInvalidSettings) {
+			t.Errorf("%s: expected invalid settings, got %v", name, err)
+		}
+	}
+}
+
+func TestService_pixelFormatChannels(t *testing.T) {
+	segment := types.LedSegment{
+		types.LedUniverse{
+			types.LedString{LedCount: 1, StringCount: 2, PixelFormat: types.PixelFormatRGBW},
+			types.LedString{LedCount: 1, StringCount: 1, PixelFormat: types.PixelFormatRGB16},
+			types.LedString{LedCount: 1, StringCount: 1},
+		},
+	}
+	s := &service{segmentDefinition: segment, segmentCount: 1}
+	s.doCreateLights()
+	lights := s.GetLightUniverses()[0]
+	expected := []int{0, 4, 8, 14}
+	if len(lights) != len(expected) {
+		t.Fatalf("got %d lights", len(lights))
+	}
+	for i, l := range lights {
+		if l.Channel != expected[i] {
+			t.Errorf("light %d starts at channel %d; expected %d", i, l.Channel, expected[i])
+		}
+	}
+	if lights[3].Format.ChannelCount() != 3 {
+		t.Errorf("unset pixel format should be rgb")
+	}
+}
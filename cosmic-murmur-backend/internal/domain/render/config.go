@@ -0,0 +1,11 @@
+package render
+
+import "github.com/polis-interactive/2023-CosmicMurmur/internal/types"
+
+type Config interface {
+	GetRenderGamma() float64
+	GetRenderWhiteBalance() types.WhiteBalance
+	GetRenderDithering() bool
+	GetRenderBrightness() float64
+	GetRenderPowerBudget() types.PowerBudget
+}
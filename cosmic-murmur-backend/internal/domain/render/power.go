@@ -0,0 +1,103 @@
+package render
+
+import (
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/domain"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/types"
+	"sort"
+	"sync"
+)
+
+/*
+	the limiter estimates each power segment's draw from the calibrated frame, and when a segment would pull more
+	than its supply allows, scales that segment's pixels down until it doesn't. It's an estimate; the leds' real
+	draw depends on the strip, but MilliampsPerChannel errs high if it's taken from the datasheet maximum
+*/
+
+type segmentKey struct {
+	universe   int
+	firstPixel int
+}
+
+type powerLimiter struct {
+	budget types.PowerBudget
+	// segments indexed by universe, so each universe worker only looks at its own
+	universeSegments map[int][]types.PowerSegment
+
+	mu     *sync.Mutex
+	status map[segmentKey]domain.PowerSegmentStatus
+}
+
+func newPowerLimiter(budget types.PowerBudget) *powerLimiter {
+	l := &powerLimiter{
+		budget:           budget,
+		universeSegments: make(map[int][]types.PowerSegment),
+		mu:               &sync.Mutex{},
+		status:           make(map[segmentKey]domain.PowerSegmentStatus),
+	}
+	if budget.MilliampsPerChannel <= 0 {
+		return l
+	}
+	for _, segment := range budget.Segments {
+		l.universeSegments[segment.Universe] = append(l.universeSegments[segment.Universe], segment)
+	}
+	return l
+}
+
+func (l *powerLimiter) limit(universe int, lights []*types.Light, colors []types.Color16) {
+	segments, ok := l.universeSegments[universe]
+	if !ok {
+		return
+	}
+	for _, segment := range segments {
+		lastPixel := segment.FirstPixel + segment.PixelCount
+		full := 0.0
+		for i, light := range lights {
+			if light.Pixel < segment.FirstPixel || light.Pixel >= lastPixel {
+				continue
+			}
+			c := colors[i]
+			full += float64(c.R) + float64(c.G) + float64(c.B)
+		}
+		milliamps := full / lutFullScale * l.budget.MilliampsPerChannel
+		scale := 1.0
+		if milliamps > segment.MaxMilliamps {
+			scale = segment.MaxMilliamps / milliamps
+			for i, light := range lights {
+				if light.Pixel < segment.FirstPixel || light.Pixel >= lastPixel {
+					continue
+				}
+				colors[i] = types.Color16{
+					R: uint16(float64(colors[i].R) * scale),
+					G: uint16(float64(colors[i].G) * scale),
+					B: uint16(float64(colors[i].B) * scale),
+				}
+			}
+		}
+		l.mu.Lock()
+		l.status[segmentKey{universe: universe, firstPixel: segment.FirstPixel}] = domain.PowerSegmentStatus{
+			Universe:           universe,
+			FirstPixel:         segment.FirstPixel,
+			EstimatedMilliamps: milliamps,
+			MaxMilliamps:       segment.MaxMilliamps,
+			Scale:              scale,
+		}
+		l.mu.Unlock()
+	}
+}
+
+// getStatus is the last frame's estimate for each segment that's seen a frame, by universe then first pixel
+func (l *powerLimiter) getStatus() []domain.PowerSegmentStatus {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	status := make([]domain.PowerSegmentStatus, 0, len(l.status))
+	for _, s := range l.status {
+		status = append(status, s)
+	}
+	sort.Slice(status, func(i, j int) bool {
+		if status[i].Universe != status[j].Universe {
+			return status[i].Universe < status[j].Universe
+		}
+		return status[i].FirstPixel < status[j].FirstPixel
+	})
+	return status
+}
@@ -0,0 +1,16 @@
+package render
+
+import "github.com/polis-interactive/2023-CosmicMurmur/internal/types"
+
+type Repository interface {
+	GetRenderGamma() (gamma float64, ok bool)
+	SetRenderGamma(gamma float64) error
+	GetRenderWhiteBalance() (whiteBalance types.WhiteBalance, ok bool)
+	SetRenderWhiteBalance(whiteBalance types.WhiteBalance) error
+	GetRenderDithering() (dithering bool, ok bool)
+	SetRenderDithering(dithering bool) error
+	GetRenderBrightness() (brightness float64, ok bool)
+	SetRenderBrightness(brightness float64) error
+	GetRenderPowerBudget() (budget types.PowerBudget, ok bool)
+	SetRenderPowerBudget(budget types.PowerBudget) error
+}
@@ -0,0 +1,250 @@
+package render
+
+import (
+	"fmt"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/domain"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/types"
+	"log"
+	"math"
+)
+
+type service struct {
+	repo Repository
+	cfg  Config
+
+	gamma        float64
+	whiteBalance types.WhiteBalance
+	dithering    bool
+	brightness   float64
+	powerBudget  types.PowerBudget
+
+	// lut maps an 8 bit channel to its gamma corrected, white balanced, dimmed 16 bit output, per channel
+	lut     [3][256]uint16
+	limiter *powerLimiter
+}
+
+var _ domain.RenderService = (*service)(nil)
+
+func NewService(cfg Config, repo Repository) *service {
+	log.Println("Render, NewService: creating")
+	s := &service{
+		repo: repo,
+		cfg:  cfg,
+	}
+	s.SetupRenderService()
+	return s
+}
+
+func (s *service) SetupRenderService() {
+	s.initializeVariables()
+	s.doCreateLut()
+	s.limiter = newPowerLimiter(s.powerBudget)
+}
+
+func (s *service) initializeVariables() {
+	var ok bool
+	s.gamma, ok = s.repo.GetRenderGamma()
+	if !ok {
+		log.Println("Render, initializeVariables: no gamma found, using default")
+		s.gamma = s.cfg.GetRenderGamma()
+	}
+	s.whiteBalance, ok = s.repo.GetRenderWhiteBalance()
+	if !ok {
+		log.Println("Render, initializeVariables: no white balance found, using default")
+		s.whiteBalance = s.cfg.GetRenderWhiteBalance()
+	}
+	s.dithering, ok = s.repo.GetRenderDithering()
+	if !ok {
+		log.Println("Render, initializeVariables: no dithering found, using default")
+		s.dithering = s.cfg.GetRenderDithering()
+	}
+	s.brightness, ok = s.repo.GetRenderBrightness()
+	if !ok {
+		log.Println("Render, initializeVariables: no brightness found, using default")
+		s.brightness = s.cfg.GetRenderBrightness()
+	}
+	s.powerBudget, ok = s.repo.GetRenderPowerBudget()
+	if !ok {
+		log.Println("Render, initializeVariables: no power budget found, using default")
+		s.powerBudget = s.cfg.GetRenderPowerBudget()
+	}
+}
+
+/*
+	the lut tops out at 0xFF00 rather than 0xFFFF so identity settings map v to v<<8, low byte clear; 8 bit
+	outputs then come through unchanged, and dithering can't push a value a step it shouldn't reach. Wide
+	formats are stretched back out to 0xFFFF by widen, so they still reach full scale
+*/
+
+const lutFullScale = 0xFF00
+
+// widen maps 0..0xFF00 onto 0..0xFFFF; v<<8 becomes v<<8|v, the usual 8 to 16 bit expansion
+func widen(v uint16) uint16 {
+	return v + v>>8
+}
+
+func (s *service) doCreateLut() {
+	for channel, scale := range []float64{s.whiteBalance.R, s.whiteBalance.G, s.whiteBalance.B} {
+		for v := 0; v < 256; v++ {
+			out := math.Pow(float64(v)/255, s.gamma) * scale * s.brightness * lutFullScale
+			s.lut[channel][v] = uint16(math.Round(out))
+		}
+	}
+}
+
+/*
+	temporal dithering; an 8 bit output drops the low byte of the lut value, so a per frame threshold is added
+	first and the low byte comes through as how often the output rounds up. The threshold walks a 4 frame
+	ordered pattern, offset by seed so neighbouring lights don't flicker in step
+*/
+
+var ditherThresholds = [4]uint16{0x00, 0x80, 0x40, 0xC0}
+
+func dither(v uint16, threshold uint16) uint16 {
+	if v > 0xFFFF-threshold {
+		return 0xFFFF
+	}
+	return v + threshold
+}
+
+// Calibrate is called from the universe workers of a frame; settings only change on the event loop between
+// frames, so the lut isn't locked
+func (s *service) Calibrate(c types.Color, format types.PixelFormat, seed int, frame uint64) types.Color16 {
+	out := types.Color16{
+		R: s.lut[0][c.R],
+		G: s.lut[1][c.G],
+		B: s.lut[2][c.B],
+	}
+	if format.IsWide() {
+		out.R = widen(out.R)
+		out.G = widen(out.G)
+		out.B = widen(out.B)
+		return out
+	}
+	if !s.dithering {
+		return out
+	}
+	threshold := ditherThresholds[(frame+uint64(seed))%uint64(len(ditherThresholds))]
+	out.R = dither(out.R, threshold)
+	out.G = dither(out.G, threshold)
+	out.B = dither(out.B, threshold)
+	return out
+}
+
+func (s *service) GetSettings() *domain.RenderSettings {
+	return &domain.RenderSettings{
+		Gamma:        s.gamma,
+		WhiteBalance: s.whiteBalance,
+		Dithering:    s.dithering,
+		Brightness:   s.brightness,
+		PowerBudget:  s.powerBudget,
+	}
+}
+
+func (s *service) LimitPower(universe int, lights []*types.Light, colors []types.Color16) {
+	s.limiter.limit(universe, lights, colors)
+}
+
+func (s *service) GetPowerStatus() []domain.PowerSegmentStatus {
+	return s.limiter.getStatus()
+}
+
+const (
+	minGamma = 0.1
+	maxGamma = 5.0
+)
+
+func validateSettings(settings *domain.RenderSettings) error {
+	if math.IsNaN(settings.Gamma) || settings.Gamma < minGamma || settings.Gamma > maxGamma {
+		return fmt.Errorf(
+			"%w: gamma must be between %g and %g, got %g", domain.ErrInvalidSettings, minGamma, maxGamma,
+			settings.Gamma,
+		)
+	}
+	wb := settings.WhiteBalance
+	for _, scale := range []float64{wb.R, wb.G, wb.B} {
+		if math.IsNaN(scale) || scale < 0 || scale > 1 {
+			return fmt.Errorf(
+				"%w: white balance channels must be between 0 and 1, got %g / %g / %g",
+				domain.ErrInvalidSettings, wb.R, wb.G, wb.B,
+			)
+		}
+	}
+	if math.IsNaN(settings.Brightness) || settings.Brightness < 0 || settings.Brightness > 1 {
+		return fmt.Errorf(
+			"%w: brightness must be between 0 and 1, got %g", domain.ErrInvalidSettings, settings.Brightness,
+		)
+	}
+	return validatePowerBudget(&settings.PowerBudget)
+}
+
+func validatePowerBudget(budget *types.PowerBudget) error {
+	if math.IsNaN(budget.MilliampsPerChannel) || budget.MilliampsPerChannel < 0 {
+		return fmt.Errorf(
+			"%w: milliamps per channel can't be negative, got %g", domain.ErrInvalidSettings,
+			budget.MilliampsPerChannel,
+		)
+	}
+	// segments on a universe can't overlap, or a pixel would be scaled twice
+	universeSegments := make(map[int][]types.PowerSegment)
+	for _, segment := range budget.Segments {
+		if segment.Universe < 0 || segment.FirstPixel < 0 || segment.PixelCount < 1 {
+			return fmt.Errorf(
+				"%w: power segment at universe %d pixel %d covers no pixels", domain.ErrInvalidSettings,
+				segment.Universe, segment.FirstPixel,
+			)
+		}
+		if math.IsNaN(segment.MaxMilliamps) || segment.MaxMilliamps <= 0 {
+			return fmt.Errorf(
+				"%w: power segment at universe %d pixel %d needs a positive budget", domain.ErrInvalidSettings,
+				segment.Universe, segment.FirstPixel,
+			)
+		}
+		for _, other := range universeSegments[segment.Universe] {
+			if segment.FirstPixel < other.FirstPixel+other.PixelCount &&
+				other.FirstPixel < segment.FirstPixel+segment.PixelCount {
+				return fmt.Errorf(
+					"%w: power segments at universe %d pixels %d and %d overlap", domain.ErrInvalidSettings,
+					segment.Universe, other.FirstPixel, segment.FirstPixel,
+				)
+			}
+		}
+		universeSegments[segment.Universe] = append(universeSegments[segment.Universe], segment)
+	}
+	return nil
+}
+
+func (s *service) SetSettings(settings *domain.RenderSettings) error {
+	err := validateSettings(settings)
+	if err != nil {
+		return err
+	}
+	err = s.repo.SetRenderGamma(settings.Gamma)
+	if err != nil {
+		return err
+	}
+	err = s.repo.SetRenderWhiteBalance(settings.WhiteBalance)
+	if err != nil {
+		return err
+	}
+	err = s.repo.SetRenderDithering(settings.Dithering)
+	if err != nil {
+		return err
+	}
+	err = s.repo.SetRenderBrightness(settings.Brightness)
+	if err != nil {
+		return err
+	}
+	err = s.repo.SetRenderPowerBudget(settings.PowerBudget)
+	if err != nil {
+		return err
+	}
+	s.gamma = settings.Gamma
+	s.whiteBalance = settings.WhiteBalance
+	s.dithering = settings.Dithering
+	s.brightness = settings.Brightness
+	s.powerBudget = settings.PowerBudget
+	s.doCreateLut()
+	s.limiter = newPowerLimiter(s.powerBudget)
+	return nil
+}
@@ -0,0 +1,152 @@
+package render
+
+import (
+	"errors"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/domain"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/types"
+	"testing"
+)
+
+func newTestService(settings domain.RenderSettings) *service {
+	s := &service{
+		gamma:        settings.Gamma,
+		whiteBalance: settings.WhiteBalance,
+		dithering:    settings.Dithering,
+		brightness:   settings.Brightness,
+	}
+	s.doCreateLut()
+	s.limiter = newPowerLimiter(settings.PowerBudget)
+	return s
+}
+
+var neutral = types.WhiteBalance{R: 1, G: 1, B: 1}
+
+func TestService_Calibrate(t *testing.T) {
+	// unity gamma and white balance leave colors alone
+	s := newTestService(domain.RenderSettings{Gamma: 1, WhiteBalance: neutral, Brightness: 1})
+	c := types.Color{R: 0, G: 128, B: 255}
+	if out := s.Calibrate(c, types.PixelFormatRGB, 0, 0); out != (types.Color16{R: 0, G: 128 << 8, B: 255 << 8}) {
+		t.Errorf("identity calibration = %v", out)
+	}
+
+	s = newTestService(domain.RenderSettings{
+		Gamma: 2, WhiteBalance: types.WhiteBalance{R: 1, G: 1, B: 0.5}, Brightness: 1,
+	})
+	out := s.Calibrate(types.Color{R: 255, G: 128, B: 255}, types.PixelFormatRGB, 0, 0)
+	// (128 / 255)^2 of full scale, and half of full scale for blue
+	if out.R != 0xFF00 || out.G != 16448 || out.B != 0x7F80 {
+		t.Errorf("calibration = %v", out)
+	}
+}
+
+func TestService_CalibrateDithers(t *testing.T) {
+	s := newTestService(domain.RenderSettings{Gamma: 2, WhiteBalance: neutral, Dithering: true, Brightness: 1})
+	c := types.Color{G: 128}
+	exact := float64(s.lut[1][128]) / 256
+	// over the pattern, the 8 bit output averages out to the 16 bit value
+	sum := 0.0
+	for frame := uint64(0); frame < uint64(len(ditherThresholds)); frame++ {
+		sum += float64(s.Calibrate(c, types.PixelFormatRGB, 3, frame).G >> 8)
+	}
+	if average := sum / float64(len(ditherThresholds)); average < exact-0.25 || average > exact+0.25 {
+		t.Errorf("dithered average = %f; expected about %f", average, exact)
+	}
+	// wide formats get every bit already
+	if out := s.Calibrate(c, types.PixelFormatRGB16, 3, 1); out.G != widen(s.lut[1][128]) {
+		t.Errorf("wide format was dithered")
+	}
+	if out := s.Calibrate(types.Color{R: 255}, types.PixelFormatRGB, 0, 3); out.R>>8 != 0xFF {
+		t.Errorf("dithering overflowed full scale to %d", out.R)
+	}
+}
+
+func TestService_CalibrateDithersIdentity(t *testing.T) {
+	// with nothing to calibrate there's no remainder for dithering to spread, so no frame differs
+	s := newTestService(domain.RenderSettings{Gamma: 1, WhiteBalance: neutral, Dithering: true, Brightness: 1})
+	channels := make([]byte, 3)
+	for v := 0; v < 256; v++ {
+		for frame := uint64(0); frame < uint64(len(ditherThresholds)); frame++ {
+			types.PixelFormatRGB.Pack(channels, s.Calibrate(types.Color{R: uint8(v)}, types.PixelFormatRGB, 1, frame))
+			if int(channels[0]) != v {
+				t.Fatalf("%d came out as %d on frame %d", v, channels[0], frame)
+			}
+		}
+	}
+}
+
+func TestService_CalibrateWide(t *testing.T) {
+	// wide formats reach full scale, and identity settings expand 8 bits the usual way
+	s := newTestService(domain.RenderSettings{Gamma: 1, WhiteBalance: neutral, Dithering: true, Brightness: 1})
+	for _, v := range []uint8{0, 1, 128, 255} {
+		out := s.Calibrate(types.Color{R: v, G: v, B: v}, types.PixelFormatRGB16, 0, 1)
+		if expected := uint16(v) * 257; out != (types.Color16{R: expected, G: expected, B: expected}) {
+			t.Errorf("%d came out as %v; expected %d", v, out, expected)
+		}
+	}
+}
+
+func TestValidateSettings(t *testing.T) {
+	if err := validateSettings(&domain.RenderSettings{Gamma: 2.2, WhiteBalance: neutral, Brightness: 1}); err != nil {
+		t.Fatalf("expected valid settings; %s", err.Error())
+	}
+	invalid := map[string]*domain.RenderSettings{
+		"zero gamma":          {Gamma: 0, WhiteBalance: neutral},
+		"huge gamma":          {Gamma: 10, WhiteBalance: neutral},
+		"white balance over":  {Gamma: 1, WhiteBalance: types.WhiteBalance{R: 1.2, G: 1, B: 1}},
+		"white balance under": {Gamma: 1, WhiteBalance: types.WhiteBalance{R: 1, G: -0.1, B: 1}},
+		"brightness over":     {Gamma: 1, WhiteBalance: neutral, Brightness: 1.5},
+		"empty power segment": {Gamma: 1, WhiteBalance: neutral, Brightness: 1, PowerBudget: types.PowerBudget{
+			MilliampsPerChannel: 20, Segments: []types.PowerSegment{{PixelCount: 0, MaxMilliamps: 1000}},
+		}},
+		"overlapping power segments": {Gamma: 1, WhiteBalance: neutral, Brightness: 1, PowerBudget: types.PowerBudget{
+			MilliampsPerChannel: 20, Segments: []types.PowerSegment{
+				{Universe: 1, FirstPixel: 0, PixelCount: 84, MaxMilliamps: 1000},
+				{Universe: 1, FirstPixel: 80, PixelCount: 80, MaxMilliamps: 1000},
+			},
+		}},
+	}
+	for name, settings := range invalid {
+		if err := validateSettings(settings); !errors.Is(err, domain.ErrInvalidSettings) {
+			t.Errorf("%s: expected invalid settings, got %v", name, err)
+		}
+	}
+}
+
+func TestService_LimitPower(t *testing.T) {
+	s := newTestService(domain.RenderSettings{
+		Gamma: 1, WhiteBalance: neutral, Brightness: 0.5,
+		PowerBudget: types.PowerBudget{
+			MilliampsPerChannel: 20,
+			Segments: []types.PowerSegment{
+				{Universe: 0, FirstPixel: 0, PixelCount: 2, MaxMilliamps: 30},
+				{Universe: 0, FirstPixel: 2, PixelCount: 2, MaxMilliamps: 1000},
+			},
+		},
+	})
+	lights := make([]*types.Light, 4)
+	colors := make([]types.Color16, 4)
+	for i := range lights {
+		lights[i] = &types.Light{Pixel: i}
+		// white at half brightness; 3 channels * 20 mA * 0.5 per pixel
+		colors[i] = s.Calibrate(types.Color{R: 255, G: 255, B: 255}, types.PixelFormatRGB, i, 0)
+	}
+	if colors[0].R != 0x7F80 {
+		t.Fatalf("brightness wasn't applied; got %d", colors[0].R)
+	}
+	s.LimitPower(0, lights, colors)
+
+	// the first segment wants 60 mA against 30, so it's halved; the second is well inside its budget
+	if colors[0].R < 0x3FBF || colors[0].R > 0x3FC0 || colors[1].B < 0x3FBF || colors[1].B > 0x3FC0 {
+		t.Errorf("limited segment = %v %v", colors[0], colors[1])
+	}
+	if colors[2].R != 0x7F80 || colors[3].G != 0x7F80 {
+		t.Errorf("unlimited segment = %v %v", colors[2], colors[3])
+	}
+	status := s.GetPowerStatus()
+	if len(status) != 2 || status[0].Scale < 0.49 || status[0].Scale > 0.51 || status[1].Scale != 1 {
+		t.Errorf("power status = %v", status)
+	}
+	if status[0].EstimatedMilliamps < 59 || status[0].EstimatedMilliamps > 61 {
+		t.Errorf("estimated draw = %f; expected about 60", status[0].EstimatedMilliamps)
+	}
+}
@@ -0,0 +1,7 @@
+package scheduler
+
+import "github.com/polis-interactive/2023-CosmicMurmur/internal/domain"
+
+type Bus interface {
+	EmitScheduleState(state *domain.ScheduleState)
+}
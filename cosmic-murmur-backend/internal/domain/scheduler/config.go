@@ -0,0 +1,14 @@
+package scheduler
+
+import (
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/types"
+	"time"
+)
+
+type Config interface {
+	GetScheduleEnabled() bool
+	GetScheduleLocation() types.GeoLocation
+	GetScheduleWindows() []types.ScheduleWindow
+	GetSchedulePlaylist() []types.PlaylistEntry
+	GetScheduleCheckPeriod() time.Duration
+}
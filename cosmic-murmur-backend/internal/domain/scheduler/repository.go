@@ -0,0 +1,14 @@
+package scheduler
+
+import "github.com/polis-interactive/2023-CosmicMurmur/internal/types"
+
+type Repository interface {
+	GetScheduleEnabled() (enabled bool, ok bool)
+	SetScheduleEnabled(enabled bool) error
+	GetScheduleLocation() (location types.GeoLocation, ok bool)
+	SetScheduleLocation(location types.GeoLocation) error
+	GetScheduleWindows() (windows []types.ScheduleWindow, ok bool)
+	SetScheduleWindows(windows []types.ScheduleWindow) error
+	GetSchedulePlaylist() (playlist []types.PlaylistEntry, ok bool)
+	SetSchedulePlaylist(playlist []types.PlaylistEntry) error
+}
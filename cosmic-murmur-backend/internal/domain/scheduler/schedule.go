@@ -0,0 +1,101 @@
+package scheduler
+
+import (
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/domain"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/types"
+	"time"
+)
+
+/*
+	schedule times are resolved against a calendar day in now's location, so clock times follow daylight
+	savings and sun times follow the seasons. Windows and playlist entries can run past midnight, so yesterday
+	is resolved alongside today
+*/
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func addDays(day time.Time, days int) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day()+days, 0, 0, 0, 0, day.Location())
+}
+
+// resolveTime places t on day; ok is false for a sun anchor on a day the sun doesn't rise or set
+func resolveTime(t types.ScheduleTime, day time.Time, location types.GeoLocation) (time.Time, bool) {
+	switch t.Anchor {
+	case types.AnchorSunrise, types.AnchorSunset:
+		sunrise, sunset, ok := sunTimes(day, location)
+		if !ok {
+			return time.Time{}, false
+		}
+		if t.Anchor == types.AnchorSunrise {
+			return sunrise.Add(time.Duration(t.Minutes) * time.Minute), true
+		}
+		return sunset.Add(time.Duration(t.Minutes) * time.Minute), true
+	default:
+		return time.Date(day.Year(), day.Month(), day.Day(), 0, t.Minutes, 0, 0, day.Location()), true
+	}
+}
+
+func windowContains(w types.ScheduleWindow, day time.Time, location types.GeoLocation, now time.Time) bool {
+	on, ok := resolveTime(w.On, day, location)
+	if !ok {
+		return false
+	}
+	off, ok := resolveTime(w.Off, day, location)
+	if !ok {
+		return false
+	}
+	if !off.After(on) {
+		off, ok = resolveTime(w.Off, addDays(day, 1), location)
+		if !ok {
+			return false
+		}
+	}
+	return !now.Before(on) && now.Before(off)
+}
+
+func isShowOn(settings *domain.ScheduleSettings, now time.Time) bool {
+	if len(settings.Windows) == 0 {
+		return true
+	}
+	today := startOfDay(now)
+	for _, day := range []time.Time{addDays(today, -1), today} {
+		for _, w := range settings.Windows {
+			if windowContains(w, day, settings.Location, now) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// scheduledShader is the playlist entry that started most recently, looking back as far as yesterday
+func scheduledShader(settings *domain.ScheduleSettings, now time.Time) string {
+	var latest time.Time
+	shaderName := ""
+	today := startOfDay(now)
+	for _, day := range []time.Time{addDays(today, -1), today} {
+		for _, entry := range settings.Playlist {
+			start, ok := resolveTime(entry.Start, day, settings.Location)
+			if !ok || start.After(now) {
+				continue
+			}
+			if shaderName == "" || !start.Before(latest) {
+				latest = start
+				shaderName = entry.ShaderName
+			}
+		}
+	}
+	return shaderName
+}
+
+func evaluateSchedule(settings *domain.ScheduleSettings, now time.Time) *domain.ScheduleState {
+	if !settings.Enabled {
+		return &domain.ScheduleState{ShowOn: true}
+	}
+	return &domain.ScheduleState{
+		ShowOn:     isShowOn(settings, now),
+		ShaderName: scheduledShader(settings, now),
+	}
+}
@@ -0,0 +1,103 @@
+package scheduler
+
+import (
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/domain"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/types"
+	"testing"
+	"time"
+)
+
+var london = types.GeoLocation{Latitude: 51.5074, Longitude: -0.1278}
+
+func TestSunTimes(t *testing.T) {
+	// published times for london on the 2023 summer solstice; 04:43 and 21:21 bst
+	day := time.Date(2023, 6, 21, 0, 0, 0, 0, time.UTC)
+	sunrise, sunset, ok := sunTimes(day, london)
+	if !ok {
+		t.Fatal("expected the sun to rise over london")
+	}
+	for _, c := range []struct {
+		name     string
+		got      time.Time
+		expected time.Time
+	}{
+		{"sunrise", sunrise, time.Date(2023, 6, 21, 3, 43, 0, 0, time.UTC)},
+		{"sunset", sunset, time.Date(2023, 6, 21, 20, 21, 0, 0, time.UTC)},
+	} {
+		if d := c.got.Sub(c.expected); d < -2*time.Minute || d > 2*time.Minute {
+			t.Errorf("%s = %v, expected about %v", c.name, c.got, c.expected)
+		}
+	}
+
+	tromso := types.GeoLocation{Latitude: 69.6492, Longitude: 18.9553}
+	if _, _, ok = sunTimes(day, tromso); ok {
+		t.Error("expected midnight sun over tromso")
+	}
+}
+
+func TestEvaluateSchedule(t *testing.T) {
+	settings := &domain.ScheduleSettings{
+		Enabled:  true,
+		Location: london,
+		Windows: []types.ScheduleWindow{
+			// sunset until 1am
+			{
+				On:  types.ScheduleTime{Anchor: types.AnchorSunset, Minutes: 0},
+				Off: types.ScheduleTime{Anchor: types.AnchorClock, Minutes: 60},
+			},
+		},
+		Playlist: []types.PlaylistEntry{
+			{Start: types.ScheduleTime{Anchor: types.AnchorSunset, Minutes: 0}, ShaderName: "dusk"},
+			{Start: types.ScheduleTime{Anchor: types.AnchorClock, Minutes: 23 * 60}, ShaderName: "night"},
+		},
+	}
+	for _, c := range []struct {
+		now      time.Time
+		expected domain.ScheduleState
+	}{
+		{time.Date(2023, 6, 21, 12, 0, 0, 0, time.UTC), domain.ScheduleState{ShowOn: false, ShaderName: "night"}},
+		{time.Date(2023, 6, 21, 20, 30, 0, 0, time.UTC), domain.ScheduleState{ShowOn: true, ShaderName: "dusk"}},
+		{time.Date(2023, 6, 21, 23, 30, 0, 0, time.UTC), domain.ScheduleState{ShowOn: true, ShaderName: "night"}},
+		// past midnight the window and playlist still run off of yesterday
+		{time.Date(2023, 6, 22, 0, 30, 0, 0, time.UTC), domain.ScheduleState{ShowOn: true, ShaderName: "night"}},
+		{time.Date(2023, 6, 22, 1, 0, 0, 0, time.UTC), domain.ScheduleState{ShowOn: false, ShaderName: "night"}},
+	} {
+		state := evaluateSchedule(settings, c.now)
+		if *state != c.expected {
+			t.Errorf("at %v, state = %+v, expected %+v", c.now, *state, c.expected)
+		}
+	}
+
+	settings.Enabled = false
+	state := evaluateSchedule(settings, time.Date(2023, 6, 21, 12, 0, 0, 0, time.UTC))
+	if !state.ShowOn || state.ShaderName != "" {
+		t.Errorf("disabled schedule state = %+v", *state)
+	}
+}
+
+func TestValidateSettings(t *testing.T) {
+	for _, c := range []struct {
+		name     string
+		settings domain.ScheduleSettings
+	}{
+		{"latitude", domain.ScheduleSettings{Location: types.GeoLocation{Latitude: 91}}},
+		{"anchor", domain.ScheduleSettings{Playlist: []types.PlaylistEntry{
+			{Start: types.ScheduleTime{Anchor: "noon"}, ShaderName: "basic"},
+		}}},
+		{"clock", domain.ScheduleSettings{Windows: []types.ScheduleWindow{{
+			On:  types.ScheduleTime{Anchor: types.AnchorClock, Minutes: 24 * 60},
+			Off: types.ScheduleTime{Anchor: types.AnchorClock, Minutes: 60},
+		}}}},
+		{"empty window", domain.ScheduleSettings{Windows: []types.ScheduleWindow{{
+			On:  types.ScheduleTime{Anchor: types.AnchorSunset, Minutes: 30},
+			Off: types.ScheduleTime{Anchor: types.AnchorSunset, Minutes: 30},
+		}}}},
+		{"shader", domain.ScheduleSettings{Playlist: []types.PlaylistEntry{
+			{Start: types.ScheduleTime{Anchor: types.AnchorClock}},
+		}}},
+	} {
+		if err := validateSettings(&c.settings); err == nil {
+			t.Errorf("%s: expected settings to be invalid", c.name)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package scheduler
+
+import (
+	"fmt"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/domain"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/types"
+	"log"
+	"math"
+	"sync"
+	"time"
+)
+
+type service struct {
+	repo Repository
+	bus  Bus
+	cfg  Config
+
+	// settings are set from the event loop and read by the schedule loop
+	settings    *domain.ScheduleSettings
+	mu          *sync.Mutex
+	checkPeriod time.Duration
+	now         func() time.Time
+
+	shutdowns chan struct{}
+	wake      chan struct{}
+	wg        *sync.WaitGroup
+}
+
+var _ domain.SchedulerService = (*service)(nil)
+
+func NewService(cfg Config, repo Repository, bus Bus) *service {
+	log.Println("Scheduler, NewService: creating")
+	s := &service{
+		repo:        repo,
+		bus:         bus,
+		cfg:         cfg,
+		mu:          &sync.Mutex{},
+		checkPeriod: cfg.GetScheduleCheckPeriod(),
+		now:         time.Now,
+		wake:        make(chan struct{}, 1),
+		wg:          &sync.WaitGroup{},
+	}
+	s.SetupSchedulerService()
+	return s
+}
+
+func (s *service) SetupSchedulerService() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.initializeVariables()
+}
+
+func (s *service) initializeVariables() {
+	settings := &domain.ScheduleSettings{}
+	var ok bool
+	settings.Enabled, ok = s.repo.GetScheduleEnabled()
+	if !ok {
+		log.Println("Scheduler, initializeVariables: no enabled found, using default")
+		settings.Enabled = s.cfg.GetScheduleEnabled()
+	}
+	settings.Location, ok = s.repo.GetScheduleLocation()
+	if !ok {
+		log.Println("Scheduler, initializeVariables: no location found, using default")
+		settings.Location = s.cfg.GetScheduleLocation()
+	}
+	settings.Windows, ok = s.repo.GetScheduleWindows()
+	if !ok {
+		log.Println("Scheduler, initializeVariables: no windows found, using default")
+		settings.Windows = s.cfg.GetScheduleWindows()
+	}
+	settings.Playlist, ok = s.repo.GetSchedulePlaylist()
+	if !ok {
+		log.Println("Scheduler, initializeVariables: no playlist found, using default")
+		settings.Playlist = s.cfg.GetSchedulePlaylist()
+	}
+	s.settings = settings
+}
+
+func (s *service) Startup() {
+	if s.shutdowns == nil {
+		s.shutdowns = make(chan struct{})
+		s.wg.Add(1)
+		go s.runScheduleLoop()
+	}
+}
+
+func (s *service) Shutdown() {
+	if s.shutdowns != nil {
+		close(s.shutdowns)
+		s.wg.Wait()
+		s.shutdowns = nil
+	}
+}
+
+/*
+	the loop reports the schedule's state every check rather than only on changes; the bus applies it on
+	change, and if applying fails (say graphics was down) the next report tries again
+*/
+
+func (s *service) runScheduleLoop() {
+	defer func() {
+		log.Println("Scheduler, runScheduleLoop: closed")
+		s.wg.Done()
+	}()
+	for {
+		s.mu.Lock()
+		state := evaluateSchedule(s.settings, s.now())
+		s.mu.Unlock()
+		s.bus.EmitScheduleState(state)
+		select {
+		case _, ok := <-s.shutdowns:
+			if !ok {
+				return
+			}
+		case <-s.wake:
+		case <-time.After(s.checkPeriod):
+		}
+	}
+}
+
+func (s *service) GetSettings() *domain.ScheduleSettings {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return &domain.ScheduleSettings{
+		Enabled:  s.settings.Enabled,
+		Location: s.settings.Location,
+		Windows:  append([]types.ScheduleWindow(nil), s.settings.Windows...),
+		Playlist: append([]types.PlaylistEntry(nil), s.settings.Playlist...),
+	}
+}
+
+const (
+	minutesPerDay = 24 * 60
+	// sun offsets go out to half a day either way; any further and an offset sunset is really a clock time
+	maxSunOffset = minutesPerDay / 2
+)
+
+func validateTime(t types.ScheduleTime) error {
+	if !t.Anchor.IsValid() {
+		return fmt.Errorf("%w: schedule anchor %q is unknown", domain.ErrInvalidSettings, t.Anchor)
+	}
+	if t.Anchor == types.AnchorClock && (t.Minutes < 0 || t.Minutes >= minutesPerDay) {
+		return fmt.Errorf("%w: clock time %d is outside of the day", domain.ErrInvalidSettings, t.Minutes)
+	}
+	if t.Anchor != types.AnchorClock && (t.Minutes < -maxSunOffset || t.Minutes > maxSunOffset) {
+		return fmt.Errorf("%w: %s offset %d is out of range", domain.ErrInvalidSettings, t.Anchor, t.Minutes)
+	}
+	return nil
+}
+
+func validateSettings(settings *domain.ScheduleSettings) error {
+	location := settings.Location
+	if math.IsNaN(location.Latitude) || location.Latitude < -90 || location.Latitude > 90 {
+		return fmt.Errorf("%w: latitude %g is out of range", domain.ErrInvalidSettings, location.Latitude)
+	}
+	if math.IsNaN(location.Longitude) || location.Longitude < -180 || location.Longitude > 180 {
+		return fmt.Errorf("%w: longitude %g is out of range", domain.ErrInvalidSettings, location.Longitude)
+	}
+	for _, w := range settings.Windows {
+		for _, t := range []types.ScheduleTime{w.On, w.Off} {
+			err := validateTime(t)
+			if err != nil {
+				return err
+			}
+		}
+		// identical on and off would read as a full day, which is what leaving out windows is for
+		if w.On == w.Off {
+			return fmt.Errorf("%w: schedule window turns on and off at the same time", domain.ErrInvalidSettings)
+		}
+	}
+	for _, entry := range settings.Playlist {
+		err := validateTime(entry.Start)
+		if err != nil {
+			return err
+		}
+		if entry.ShaderName == "" {
+			return fmt.Errorf("%w: playlist entry has no shader", domain.ErrInvalidSettings)
+		}
+	}
+	return nil
+}
+
+func (s *service) SetSettings(settings *domain.ScheduleSettings) error {
+	err := validateSettings(settings)
+	if err != nil {
+		return err
+	}
+	err = s.repo.SetScheduleEnabled(settings.Enabled)
+	if err != nil {
+		return err
+	}
+	err = s.repo.SetScheduleLocation(settings.Location)
+	if err != nil {
+		return err
+	}
+	err = s.repo.SetScheduleWindows(settings.Windows)
+	if err != nil {
+		return err
+	}
+	err = s.repo.SetSchedulePlaylist(settings.Playlist)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.settings = &domain.ScheduleSettings{
+		Enabled:  settings.Enabled,
+		Location: settings.Location,
+		Windows:  append([]types.ScheduleWindow(nil), settings.Windows...),
+		Playlist: append([]types.PlaylistEntry(nil), settings.Playlist...),
+	}
+	s.mu.Unlock()
+	// report the new schedule right away rather than on the next check
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package scheduler
+
+import (
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/types"
+	"math"
+	"time"
+)
+
+/*
+	sunrise and sunset come from the sunrise equation as noaa approximates it; good to a minute or two, which
+	is plenty for turning a show on. Everything is worked in julian days, east longitudes positive
+*/
+
+const (
+	julianUnixEpoch = 2440587.5
+	julian2000      = 2451545.0
+	// the sun's disc is fully below the horizon once its center drops this far, refraction included
+	sunHorizonDegrees = -0.833
+	earthTiltDegrees  = 23.4397
+)
+
+func toRadians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
+
+func toDegrees(radians float64) float64 {
+	return radians * 180 / math.Pi
+}
+
+func toJulian(t time.Time) float64 {
+	return float64(t.Unix())/86400 + julianUnixEpoch
+}
+
+func fromJulian(j float64, loc *time.Location) time.Time {
+	return time.Unix(0, int64(math.Round((j-julianUnixEpoch)*86400*1e9))).In(loc)
+}
+
+// sunTimes finds sunrise and sunset on day's date in its location; ok is false when the sun doesn't cross the
+// horizon that day, i.e. polar day or night
+func sunTimes(day time.Time, location types.GeoLocation) (sunrise, sunset time.Time, ok bool) {
+	noon := time.Date(day.Year(), day.Month(), day.Day(), 12, 0, 0, 0, time.UTC)
+	meanSolarNoon := math.Round(toJulian(noon)-julian2000) - location.Longitude/360
+	anomaly := math.Mod(357.5291+0.98560028*meanSolarNoon, 360)
+	m := toRadians(anomaly)
+	center := 1.9148*math.Sin(m) + 0.0200*math.Sin(2*m) + 0.0003*math.Sin(3*m)
+	eclipticLongitude := toRadians(math.Mod(anomaly+center+180+102.9372, 360))
+	transit := julian2000 + meanSolarNoon + 0.0053*math.Sin(m) - 0.0069*math.Sin(2*eclipticLongitude)
+	declination := math.Asin(math.Sin(eclipticLongitude) * math.Sin(toRadians(earthTiltDegrees)))
+	latitude := toRadians(location.Latitude)
+	cosHourAngle := (math.Sin(toRadians(sunHorizonDegrees)) - math.Sin(latitude)*math.Sin(declination)) /
+		(math.Cos(latitude) * math.Cos(declination))
+	if cosHourAngle < -1 || cosHourAngle > 1 {
+		return time.Time{}, time.Time{}, false
+	}
+	hourAngle := toDegrees(math.Acos(cosHourAngle))
+	return fromJulian(transit-hourAngle/360, day.Location()), fromJulian(transit+hourAngle/360, day.Location()), true
+}
@@ -6,17 +6,34 @@ import (
 	"time"
 )
 
+// GraphicsSettableSettings leave the transition as is when Transition is nil; otherwise the new transition
+// already applies to this shader change. Setting a shader stops any playlist that's running
 type GraphicsSettableSettings struct {
 	ShaderName     string
 	Frequency      time.Duration
 	ReloadOnUpdate bool
+	Transition     *types.Transition
 }
 
+// UniformSetting is a uniform a shader declared settable, with the value it runs with outside of playlists
+type UniformSetting struct {
+	types.ShaderUniform
+	Value float32
+}
+
+// GraphicsSettings report the playlist that's running, if any, in ActivePlaylist, with PlaylistItem indexing
+// the item on now. UserShaders are the uploaded subset of Shaders. Uniforms are keyed by shader
 type GraphicsSettings struct {
 	Shaders        []string
+	UserShaders    []string
 	RunningShader  string
 	Frequency      time.Duration
 	ReloadOnUpdate bool
+	Transition     types.Transition
+	Playlists      []types.Playlist
+	ActivePlaylist string
+	PlaylistItem   int
+	Uniforms       map[string][]UniformSetting
 }
 
 type GraphicsService interface {
@@ -25,6 +42,14 @@ type GraphicsService interface {
 	Shutdown()
 	GetSettings() (*GraphicsSettings, error)
 	SetSettings(settings *GraphicsSettableSettings) error
+	SetUniforms(shaderName string, values map[string]float32) error
+	UploadShader(name string, frag string, vert string) error
+	DeleteShader(name string) error
+	RenameShader(name string, newName string) error
+	SetPlaylists(playlists []types.Playlist) error
+	StartPlaylist(name string) error
+	StopPlaylist() error
+	SkipPlaylist() error
 	GetPb() (pb *types.PixelBuffer, preLockedMutex *sync.RWMutex)
 }
 
@@ -41,6 +66,37 @@ type LightingService interface {
 	GetLightUniverses() [][]*types.Light
 }
 
+// RenderSettings calibrate what's sampled from the shader before it goes out to the leds
+type RenderSettings struct {
+	Gamma        float64
+	WhiteBalance types.WhiteBalance
+	Dithering    bool
+	Brightness   float64
+	PowerBudget  types.PowerBudget
+}
+
+// PowerSegmentStatus is the limiter's estimate for a power segment on the last frame; Scale is 1 unless the
+// segment was dimmed to stay inside its budget
+type PowerSegmentStatus struct {
+	Universe           int
+	FirstPixel         int
+	EstimatedMilliamps float64
+	MaxMilliamps       float64
+	Scale              float64
+}
+
+type RenderService interface {
+	SetupRenderService()
+	GetSettings() *RenderSettings
+	SetSettings(settings *RenderSettings) error
+	// Calibrate maps a sampled color to what gets packed for a light; seed should differ between lights, and
+	// frame between frames, so dithering spreads its error across both
+	Calibrate(c types.Color, format types.PixelFormat, seed int, frame uint64) types.Color16
+	// LimitPower scales a universe's calibrated colors, lined up with its lights, down to its power budget
+	LimitPower(universe int, lights []*types.Light, colors []types.Color16)
+	GetPowerStatus() []PowerSegmentStatus
+}
+
 type ControllerSettings struct {
 	NodeDefinitions types.NodeDefinitions
 	LocalAddress    string
@@ -53,6 +109,108 @@ type ControllerService interface {
 	BlackoutNodes()
 	GetUniverseBuffer(universe int) (universeBuffer *[512]byte, ok bool)
 	SendUniverseUpdate(universe int)
+	SendFrameSync()
 	GetSettings() *ControllerSettings
 	SetSettings(settings *ControllerSettings) error
+	GetNodeHealth() []*NodeHealth
+	ProposeNodeDefinitions(requiredUniverses []int) *NodeDefinitionsProposal
+	AcceptNodeDefinitionsProposal() error
+}
+
+// NodeDefinitionsProposal is built from the nodes answering polls; MissingUniverses are the ones lighting
+// drives that no discovered node outputs
+type NodeDefinitionsProposal struct {
+	NodeDefinitions  types.NodeDefinitions
+	MissingUniverses []int
+}
+
+// NodeHealth is what the controller last heard from a node over ArtPoll; the names, firmware and port
+// addresses are empty until the node first replies. sACN has no poll, so those nodes never show online
+type NodeHealth struct {
+	Address       string
+	Port          int
+	Protocol      types.NodeProtocol
+	Online        bool
+	LastSeen      time.Time
+	ShortName     string
+	LongName      string
+	Firmware      uint16
+	PortAddresses []int
+
+	// UniversesCoalesced were replaced by a newer frame before going out, UniversesDropped had no running
+	// node loop; either climbing means the node (or its link) can't keep up
+	UniversesSent      uint64
+	UniversesCoalesced uint64
+	UniversesDropped   uint64
+}
+
+// ScheduleSettings drive when the show is on and what it plays; a disabled schedule, or one without windows,
+// leaves the show on all day, and an empty playlist leaves the shader alone
+type ScheduleSettings struct {
+	Enabled  bool
+	Location types.GeoLocation
+	Windows  []types.ScheduleWindow
+	Playlist []types.PlaylistEntry
+}
+
+// ScheduleState is what the schedule calls for right now; ShaderName is empty when the playlist has no say
+type ScheduleState struct {
+	ShowOn     bool
+	ShaderName string
+}
+
+type SchedulerService interface {
+	SetupSchedulerService()
+	Startup()
+	Shutdown()
+	GetSettings() *ScheduleSettings
+	SetSettings(settings *ScheduleSettings) error
+}
+
+type ApplicationStatus struct {
+	GraphicsRunning bool
+	RunningShader   string
+	FramesRendered  uint64
+	GraphicsCrashes uint64
+	LastFrame       time.Time
+	PowerSegments   []PowerSegmentStatus
+	// ShowOn is false outside the scheduled windows, while the nodes are held blacked out
+	ShowOn bool
+}
+
+type StreamEventName string
+
+const (
+	StreamEventStatus             StreamEventName = "status"
+	StreamEventGraphicsCrashed    StreamEventName = "graphicsCrashed"
+	StreamEventGraphicsSettings   StreamEventName = "graphicsSettings"
+	StreamEventLightingSettings   StreamEventName = "lightingSettings"
+	StreamEventControllerSettings StreamEventName = "controllerSettings"
+	StreamEventRenderSettings     StreamEventName = "renderSettings"
+	StreamEventScheduleSettings   StreamEventName = "scheduleSettings"
+	StreamEventScheduleState      StreamEventName = "scheduleState"
+	StreamEventApplicationReset   StreamEventName = "applicationReset"
+	StreamEventNodeError          StreamEventName = "nodeError"
+	StreamEventNodeHealth         StreamEventName = "nodeHealth"
+	StreamEventShaderError        StreamEventName = "shaderError"
+)
+
+// StreamEvent is published to live subscribers; Payload is one of the domain settings / status types, a
+// *NodeError, a *NodeHealth, a *ShaderError, or nil
+type StreamEvent struct {
+	Name    StreamEventName
+	TraceId uint64
+	Time    time.Time
+	Payload interface{}
+}
+
+type NodeError struct {
+	Address string
+	Error   string
+}
+
+// ShaderError is a shader on disk that changed and no longer compiles; the graphics loop keeps running
+type ShaderError struct {
+	ShaderName string
+	Error      string
 }
@@ -1 +1,35 @@
 package api
+
+import (
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/domain"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/types"
+	"image"
+)
+
+type Bus interface {
+	FetchGraphicsSettings() (*domain.GraphicsSettings, error)
+	SetGraphicsSettings(shaderName string, refreshInMs int64, reloadOnUpdate bool, transition *types.Transition) error
+	SetGraphicsUniforms(shaderName string, values map[string]float32) error
+	UploadGraphicsShader(name string, frag string, vert string) error
+	DeleteGraphicsShader(name string) error
+	RenameGraphicsShader(name string, newName string) error
+	SetGraphicsPlaylists(playlists []types.Playlist) error
+	StartGraphicsPlaylist(name string) error
+	StopGraphicsPlaylist() error
+	SkipGraphicsPlaylist() error
+	FetchLightingSettings() (*domain.LightingSettings, error)
+	SetLightingSettings(segmentDefinition types.LedSegment, segmentCount int) error
+	FetchControllerSettings() (*domain.ControllerSettings, error)
+	SetControllerSettings(nodeDefinitions types.NodeDefinitions, localAddress string) error
+	FetchRenderSettings() (*domain.RenderSettings, error)
+	SetRenderSettings(settings *domain.RenderSettings) error
+	FetchScheduleSettings() (*domain.ScheduleSettings, error)
+	SetScheduleSettings(settings *domain.ScheduleSettings) error
+	FetchNodeHealth() ([]*domain.NodeHealth, error)
+	ProposeNodeDefinitions() (*domain.NodeDefinitionsProposal, error)
+	AcceptNodeDefinitions() error
+	ResetApplication() error
+	FetchStatus() (*domain.ApplicationStatus, error)
+	FetchPreview(lightsOnly bool) (*image.RGBA, error)
+	Subscribe() (events <-chan *domain.StreamEvent, unsubscribe func())
+}
@@ -0,0 +1,171 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/domain"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/types"
+	"net/http"
+)
+
+type nodeDefinition struct {
+	Address      string `json:"address" binding:"required"`
+	Universes    []int  `json:"universes" binding:"required"`
+	Port         int    `json:"port,omitempty" binding:"min=0,max=65535"`
+	Sync         bool   `json:"sync"`
+	Protocol     string `json:"protocol,omitempty" binding:"omitempty,oneof=artnet sacn"`
+	Multicast    bool   `json:"multicast,omitempty"`
+	Priority     int    `json:"priority,omitempty" binding:"min=0,max=200"`
+	SyncUniverse int    `json:"syncUniverse,omitempty"`
+	SourceName   string `json:"sourceName,omitempty"`
+}
+
+func (d nodeDefinition) toNodeDefinition() types.NodeDefinition {
+	return types.NodeDefinition{
+		Address:      d.Address,
+		Universes:    d.Universes,
+		Port:         d.Port,
+		Sync:         d.Sync,
+		Protocol:     types.NodeProtocol(d.Protocol),
+		Multicast:    d.Multicast,
+		Priority:     d.Priority,
+		SyncUniverse: d.SyncUniverse,
+		SourceName:   d.SourceName,
+	}
+}
+
+type controllerSettingsBody struct {
+	LocalAddress    string           `json:"localAddress" binding:"required"`
+	NodeDefinitions []nodeDefinition `json:"nodeDefinitions" binding:"required,dive"`
+}
+
+type nodeHealthResponse struct {
+	Address        string `json:"address"`
+	Port           int    `json:"port,omitempty"`
+	Protocol       string `json:"protocol"`
+	Online         bool   `json:"online"`
+	LastSeenUnixMs int64  `json:"lastSeenUnixMs"`
+	ShortName      string `json:"shortName"`
+	LongName       string `json:"longName"`
+	Firmware       uint16 `json:"firmware"`
+	PortAddresses  []int  `json:"portAddresses"`
+
+	UniversesSent      uint64 `json:"universesSent"`
+	UniversesCoalesced uint64 `json:"universesCoalesced"`
+	UniversesDropped   uint64 `json:"universesDropped"`
+}
+
+func newNodeHealthResponse(health *domain.NodeHealth) *nodeHealthResponse {
+	var lastSeenUnixMs int64
+	if !health.LastSeen.IsZero() {
+		lastSeenUnixMs = health.LastSeen.UnixMilli()
+	}
+	return &nodeHealthResponse{
+		Address:        health.Address,
+		Port:           health.Port,
+		Protocol:       string(health.Protocol),
+		Online:         health.Online,
+		LastSeenUnixMs: lastSeenUnixMs,
+		ShortName:      health.ShortName,
+		LongName:       health.LongName,
+		Firmware:       health.Firmware,
+		PortAddresses:  health.PortAddresses,
+
+		UniversesSent:      health.UniversesSent,
+		UniversesCoalesced: health.UniversesCoalesced,
+		UniversesDropped:   health.UniversesDropped,
+	}
+}
+
+type nodeDefinitionsProposalResponse struct {
+	NodeDefinitions  []nodeDefinition `json:"nodeDefinitions"`
+	MissingUniverses []int            `json:"missingUniverses"`
+}
+
+func newNodeDefinitions(definitions types.NodeDefinitions) []nodeDefinition {
+	nodeDefinitions := make([]nodeDefinition, 0, len(definitions))
+	for _, d := range definitions {
+		nodeDefinitions = append(nodeDefinitions, nodeDefinition{
+			Address:      d.Address,
+			Universes:    d.Universes,
+			Port:         d.Port,
+			Sync:         d.Sync,
+			Protocol:     string(d.Protocol),
+			Multicast:    d.Multicast,
+			Priority:     d.Priority,
+			SyncUniverse: d.SyncUniverse,
+			SourceName:   d.SourceName,
+		})
+	}
+	return nodeDefinitions
+}
+
+func newControllerSettingsBody(settings *domain.ControllerSettings) *controllerSettingsBody {
+	return &controllerSettingsBody{
+		LocalAddress:    settings.LocalAddress,
+		NodeDefinitions: newNodeDefinitions(settings.NodeDefinitions),
+	}
+}
+
+func (s *Server) getControllerSettings(c *gin.Context) {
+	settings, err := s.bus.FetchControllerSettings()
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, newControllerSettingsBody(settings))
+}
+
+func (s *Server) putControllerSettings(c *gin.Context) {
+	var req controllerSettingsBody
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondWithValidationError(c, err)
+		return
+	}
+	nodeDefinitions := make(types.NodeDefinitions, 0, len(req.NodeDefinitions))
+	for _, d := range req.NodeDefinitions {
+		nodeDefinitions = append(nodeDefinitions, d.toNodeDefinition())
+	}
+	// the controller service owns address / universe validation; it answers with domain.ErrInvalidSettings
+	err := s.bus.SetControllerSettings(nodeDefinitions, req.LocalAddress)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (s *Server) getNodeHealth(c *gin.Context) {
+	health, err := s.bus.FetchNodeHealth()
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+	resp := make([]*nodeHealthResponse, 0, len(health))
+	for _, h := range health {
+		resp = append(resp, newNodeHealthResponse(h))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// getNodeDefinitionsProposal builds node definitions from the nodes answering on the network; nothing changes
+// until the proposal is accepted
+func (s *Server) getNodeDefinitionsProposal(c *gin.Context) {
+	proposal, err := s.bus.ProposeNodeDefinitions()
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, &nodeDefinitionsProposalResponse{
+		NodeDefinitions:  newNodeDefinitions(proposal.NodeDefinitions),
+		MissingUniverses: proposal.MissingUniverses,
+	})
+}
+
+func (s *Server) postAcceptNodeDefinitionsProposal(c *gin.Context) {
+	err := s.bus.AcceptNodeDefinitions()
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,105 @@
+package api
+
+import (
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/domain"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// keeps proxies from timing out an idle stream
+const eventsKeepAlivePeriod = 15 * time.Second
+
+type nodeErrorEvent struct {
+	Address string `json:"address"`
+	Error   string `json:"error"`
+}
+
+type shaderErrorEvent struct {
+	ShaderName string `json:"shaderName"`
+	Error      string `json:"error"`
+}
+
+type streamEventBody struct {
+	TimeUnixMs int64       `json:"timeUnixMs"`
+	Data       interface{} `json:"data,omitempty"`
+}
+
+func newStreamEventData(payload interface{}) interface{} {
+	switch p := payload.(type) {
+	case *domain.ApplicationStatus:
+		return newStatusResponse(p)
+	case *domain.GraphicsSettings:
+		return newGraphicsSettingsResponse(p)
+	case *domain.LightingSettings:
+		return newLightingSettingsBody(p)
+	case *domain.ControllerSettings:
+		return newControllerSettingsBody(p)
+	case *domain.RenderSettings:
+		return newRenderSettingsBody(p)
+	case *domain.ScheduleSettings:
+		return newScheduleSettingsBody(p)
+	case *domain.ScheduleState:
+		return &scheduleStateEvent{ShowOn: p.ShowOn, ShaderName: p.ShaderName}
+	case *domain.NodeHealth:
+		return newNodeHealthResponse(p)
+	case *domain.NodeError:
+		return &nodeErrorEvent{Address: p.Address, Error: p.Error}
+	case *domain.ShaderError:
+		return &shaderErrorEvent{ShaderName: p.ShaderName, Error: p.Error}
+	default:
+		return nil
+	}
+}
+
+/*
+	/api/v1/events is a server-sent event stream; each event's name is a domain.StreamEventName and its id is
+	the bus trace id, so it can be matched against the service logs
+*/
+
+func (s *Server) getEvents(c *gin.Context) {
+	s.shutdownLock.Lock()
+	streamsDone := s.streamsDone
+	s.shutdownLock.Unlock()
+
+	events, unsubscribe := s.bus.Subscribe()
+	defer unsubscribe()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	keepAlive := time.NewTicker(eventsKeepAlivePeriod)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-streamsDone:
+			return
+		case <-keepAlive.C:
+			_, err := c.Writer.WriteString(": keep-alive\n\n")
+			if err != nil {
+				return
+			}
+		case streamEvent, ok := <-events:
+			if !ok {
+				return
+			}
+			c.Render(-1, sse.Event{
+				Id:    strconv.FormatUint(streamEvent.TraceId, 10),
+				Event: string(streamEvent.Name),
+				Data: &streamEventBody{
+					TimeUnixMs: streamEvent.Time.UnixMilli(),
+					Data:       newStreamEventData(streamEvent.Payload),
+				},
+			})
+		}
+		c.Writer.Flush()
+	}
+}
@@ -0,0 +1,324 @@
+package api
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/domain"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/types"
+	"io"
+	"net/http"
+	"time"
+)
+
+type transition struct {
+	DurationInMs int64  `json:"durationInMs" binding:"min=0"`
+	Easing       string `json:"easing" binding:"required,oneof=linear easeIn easeOut easeInOut"`
+	MaskShader   string `json:"maskShader,omitempty"`
+}
+
+func (t *transition) toTransition() *types.Transition {
+	if t == nil {
+		return nil
+	}
+	return &types.Transition{
+		Duration:   time.Duration(t.DurationInMs) * time.Millisecond,
+		Easing:     types.Easing(t.Easing),
+		MaskShader: t.MaskShader,
+	}
+}
+
+func newTransition(t types.Transition) *transition {
+	return &transition{
+		DurationInMs: t.Duration.Milliseconds(),
+		Easing:       string(t.Easing),
+		MaskShader:   t.MaskShader,
+	}
+}
+
+// graphicsPlaylistItem leaves refreshInMs out (or 0) to keep the graphics refresh
+type graphicsPlaylistItem struct {
+	ShaderName   string             `json:"shaderName" binding:"required"`
+	DurationInMs int64              `json:"durationInMs" binding:"required,min=1000"`
+	RefreshInMs  int64              `json:"refreshInMs,omitempty" binding:"min=0,max=10000"`
+	Uniforms     map[string]float32 `json:"uniforms,omitempty"`
+	Transition   *transition        `json:"transition,omitempty"`
+}
+
+type graphicsPlaylist struct {
+	Name  string                 `json:"name" binding:"required"`
+	Mode  string                 `json:"mode" binding:"required,oneof=sequential shuffle"`
+	Items []graphicsPlaylistItem `json:"items" binding:"required,min=1,dive"`
+}
+
+func (p *graphicsPlaylist) toPlaylist() types.Playlist {
+	playlist := types.Playlist{
+		Name:  p.Name,
+		Mode:  types.PlaylistMode(p.Mode),
+		Items: make([]types.PlaylistItem, 0, len(p.Items)),
+	}
+	for _, item := range p.Items {
+		playlist.Items = append(playlist.Items, types.PlaylistItem{
+			ShaderName: item.ShaderName,
+			Duration:   time.Duration(item.DurationInMs) * time.Millisecond,
+			Frequency:  time.Duration(item.RefreshInMs) * time.Millisecond,
+			Uniforms:   item.Uniforms,
+			Transition: item.Transition.toTransition(),
+		})
+	}
+	return playlist
+}
+
+func newGraphicsPlaylist(p types.Playlist) graphicsPlaylist {
+	playlist := graphicsPlaylist{
+		Name:  p.Name,
+		Mode:  string(p.Mode),
+		Items: make([]graphicsPlaylistItem, 0, len(p.Items)),
+	}
+	for _, item := range p.Items {
+		resp := graphicsPlaylistItem{
+			ShaderName:   item.ShaderName,
+			DurationInMs: item.Duration.Milliseconds(),
+			RefreshInMs:  item.Frequency.Milliseconds(),
+			Uniforms:     item.Uniforms,
+		}
+		if item.Transition != nil {
+			resp.Transition = newTransition(*item.Transition)
+		}
+		playlist.Items = append(playlist.Items, resp)
+	}
+	return playlist
+}
+
+type uniformSetting struct {
+	Name    string  `json:"name"`
+	Type    string  `json:"type"`
+	Min     float32 `json:"min"`
+	Max     float32 `json:"max"`
+	Default float32 `json:"default"`
+	Value   float32 `json:"value"`
+}
+
+// graphicsSettingsResponse leaves activePlaylist empty when no playlist is running; uniforms are keyed by
+// shader, and only list the ones a shader declared settable
+type graphicsSettingsResponse struct {
+	Shaders        []string                    `json:"shaders"`
+	UserShaders    []string                    `json:"userShaders"`
+	RunningShader  string                      `json:"runningShader"`
+	RefreshInMs    int64                       `json:"refreshInMs"`
+	ReloadOnUpdate bool                        `json:"reloadOnUpdate"`
+	Transition     *transition                 `json:"transition"`
+	Playlists      []graphicsPlaylist          `json:"playlists"`
+	ActivePlaylist string                      `json:"activePlaylist"`
+	PlaylistItem   int                         `json:"playlistItem"`
+	Uniforms       map[string][]uniformSetting `json:"uniforms"`
+}
+
+type setGraphicsSettingsRequest struct {
+	ShaderName     string `json:"shaderName" binding:"required"`
+	RefreshInMs    int64  `json:"refreshInMs" binding:"required,min=1,max=10000"`
+	ReloadOnUpdate bool   `json:"reloadOnUpdate"`
+	// leaving the transition out keeps the current one
+	Transition *transition `json:"transition,omitempty"`
+}
+
+type setGraphicsUniformsRequest struct {
+	Values map[string]float32 `json:"values" binding:"required"`
+}
+
+type renameGraphicsShaderRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+type setGraphicsPlaylistsRequest struct {
+	Playlists []graphicsPlaylist `json:"playlists" binding:"dive"`
+}
+
+type startGraphicsPlaylistRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+func newGraphicsSettingsResponse(settings *domain.GraphicsSettings) *graphicsSettingsResponse {
+	playlists := make([]graphicsPlaylist, 0, len(settings.Playlists))
+	for _, p := range settings.Playlists {
+		playlists = append(playlists, newGraphicsPlaylist(p))
+	}
+	uniforms := make(map[string][]uniformSetting, len(settings.Uniforms))
+	for shader, declared := range settings.Uniforms {
+		uniforms[shader] = make([]uniformSetting, 0, len(declared))
+		for _, u := range declared {
+			uniforms[shader] = append(uniforms[shader], uniformSetting{
+				Name: u.Name, Type: u.Type, Min: u.Min, Max: u.Max, Default: u.Default, Value: u.Value,
+			})
+		}
+	}
+	return &graphicsSettingsResponse{
+		Shaders:        settings.Shaders,
+		UserShaders:    settings.UserShaders,
+		RunningShader:  settings.RunningShader,
+		RefreshInMs:    settings.Frequency.Milliseconds(),
+		ReloadOnUpdate: settings.ReloadOnUpdate,
+		Transition:     newTransition(settings.Transition),
+		Playlists:      playlists,
+		ActivePlaylist: settings.ActivePlaylist,
+		PlaylistItem:   settings.PlaylistItem,
+		Uniforms:       uniforms,
+	}
+}
+
+func (s *Server) getGraphicsSettings(c *gin.Context) {
+	settings, err := s.bus.FetchGraphicsSettings()
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, newGraphicsSettingsResponse(settings))
+}
+
+func (s *Server) putGraphicsSettings(c *gin.Context) {
+	var req setGraphicsSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondWithValidationError(c, err)
+		return
+	}
+	err := s.bus.SetGraphicsSettings(req.ShaderName, req.RefreshInMs, req.ReloadOnUpdate, req.Transition.toTransition())
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (s *Server) putGraphicsUniforms(c *gin.Context) {
+	var req setGraphicsUniformsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondWithValidationError(c, err)
+		return
+	}
+	// the graphics service checks the shader declared each uniform, and its range
+	err := s.bus.SetGraphicsUniforms(c.Param("shader"), req.Values)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// shader sources bigger than this are far more likely a wrong file than a shader
+const maxShaderSourceSize = 64 * 1024
+
+// readShaderSource reads one file of a multipart upload
+func readShaderSource(c *gin.Context, field string) (string, error) {
+	header, err := c.FormFile(field)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s shader is missing; %s", domain.ErrInvalidSettings, field, err.Error())
+	} else if header.Size > maxShaderSourceSize {
+		return "", fmt.Errorf(
+			"%w: %s shader is over %d bytes", domain.ErrInvalidSettings, field, maxShaderSourceSize,
+		)
+	}
+	f, err := header.Open()
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	source, err := io.ReadAll(f)
+	if err != nil {
+		return "", err
+	}
+	return string(source), nil
+}
+
+// putGraphicsShader takes a multipart form with the frag and vert files
+func (s *Server) putGraphicsShader(c *gin.Context) {
+	frag, err := readShaderSource(c, "frag")
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+	vert, err := readShaderSource(c, "vert")
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+	// the graphics service checks the name, and that the pair compiles
+	err = s.bus.UploadGraphicsShader(c.Param("shader"), frag, vert)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (s *Server) deleteGraphicsShader(c *gin.Context) {
+	err := s.bus.DeleteGraphicsShader(c.Param("shader"))
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (s *Server) postRenameGraphicsShader(c *gin.Context) {
+	var req renameGraphicsShaderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondWithValidationError(c, err)
+		return
+	}
+	err := s.bus.RenameGraphicsShader(c.Param("shader"), req.Name)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (s *Server) putGraphicsPlaylists(c *gin.Context) {
+	var req setGraphicsPlaylistsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondWithValidationError(c, err)
+		return
+	}
+	playlists := make([]types.Playlist, 0, len(req.Playlists))
+	for _, p := range req.Playlists {
+		playlists = append(playlists, p.toPlaylist())
+	}
+	// the graphics service checks names are unique and the shaders exist
+	err := s.bus.SetGraphicsPlaylists(playlists)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (s *Server) postStartGraphicsPlaylist(c *gin.Context) {
+	var req startGraphicsPlaylistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondWithValidationError(c, err)
+		return
+	}
+	err := s.bus.StartGraphicsPlaylist(req.Name)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (s *Server) postStopGraphicsPlaylist(c *gin.Context) {
+	err := s.bus.StopGraphicsPlaylist()
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (s *Server) postSkipGraphicsPlaylist(c *gin.Context) {
+	err := s.bus.SkipGraphicsPlaylist()
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/domain"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/types"
+	"net/http"
+)
+
+type ledString struct {
+	LedCount    int    `json:"ledCount"`
+	StringCount int    `json:"stringCount"`
+	PixelFormat string `json:"pixelFormat,omitempty"`
+}
+
+type lightingSettingsBody struct {
+	SegmentDefinition [][]ledString `json:"segmentDefinition" binding:"required"`
+	SegmentCount      int           `json:"segmentCount" binding:"required"`
+}
+
+func newLightingSettingsBody(settings *domain.LightingSettings) *lightingSettingsBody {
+	segmentDefinition := make([][]ledString, 0, len(settings.SegmentDefinition))
+	for _, universe := range settings.SegmentDefinition {
+		ledStrings := make([]ledString, 0, len(universe))
+		for _, l := range universe {
+			ledStrings = append(ledStrings, ledString{
+				LedCount:    l.LedCount,
+				StringCount: l.StringCount,
+				PixelFormat: string(l.PixelFormat),
+			})
+		}
+		segmentDefinition = append(segmentDefinition, ledStrings)
+	}
+	return &lightingSettingsBody{
+		SegmentDefinition: segmentDefinition,
+		SegmentCount:      settings.SegmentCount,
+	}
+}
+
+func (s *Server) getLightingSettings(c *gin.Context) {
+	settings, err := s.bus.FetchLightingSettings()
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, newLightingSettingsBody(settings))
+}
+
+func (s *Server) putLightingSettings(c *gin.Context) {
+	var req lightingSettingsBody
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondWithValidationError(c, err)
+		return
+	}
+	segmentDefinition := make(types.LedSegment, 0, len(req.SegmentDefinition))
+	for _, universe := range req.SegmentDefinition {
+		ledUniverse := make(types.LedUniverse, 0, len(universe))
+		for _, l := range universe {
+			ledUniverse = append(ledUniverse, types.LedString{
+				LedCount:    l.LedCount,
+				StringCount: l.StringCount,
+				PixelFormat: types.PixelFormat(l.PixelFormat),
+			})
+		}
+		segmentDefinition = append(segmentDefinition, ledUniverse)
+	}
+	// the lighting service owns layout validation; it answers with domain.ErrInvalidSettings
+	err := s.bus.SetLightingSettings(segmentDefinition, req.SegmentCount)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,40 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"image/png"
+	"net/http"
+)
+
+const (
+	previewModeFrame = "frame"
+	previewModeLeds  = "leds"
+)
+
+/*
+	?mode=frame (the default) is the whole rendered frame; ?mode=leds keeps only the pixels sampled for lights,
+	which is what the strings are actually showing
+*/
+
+func (s *Server) getPreview(c *gin.Context) {
+	mode := c.DefaultQuery("mode", previewModeFrame)
+	if mode != previewModeFrame && mode != previewModeLeds {
+		respondWithValidationError(c, fmt.Errorf("unknown preview mode %q", mode))
+		return
+	}
+	img, err := s.bus.FetchPreview(mode == previewModeLeds)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+	var buf bytes.Buffer
+	err = png.Encode(&buf, img)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+	c.Header("Cache-Control", "no-store")
+	c.Data(http.StatusOK, "image/png", buf.Bytes())
+}
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/domain"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/types"
+	"net/http"
+)
+
+type whiteBalance struct {
+	R float64 `json:"r" binding:"min=0,max=1"`
+	G float64 `json:"g" binding:"min=0,max=1"`
+	B float64 `json:"b" binding:"min=0,max=1"`
+}
+
+type powerSegment struct {
+	Universe     int     `json:"universe" binding:"min=0"`
+	FirstPixel   int     `json:"firstPixel" binding:"min=0"`
+	PixelCount   int     `json:"pixelCount" binding:"min=1"`
+	MaxMilliamps float64 `json:"maxMilliamps" binding:"gt=0"`
+}
+
+type powerBudget struct {
+	MilliampsPerChannel float64        `json:"milliampsPerChannel" binding:"min=0"`
+	Segments            []powerSegment `json:"segments" binding:"dive"`
+}
+
+type renderSettingsBody struct {
+	Gamma        float64       `json:"gamma" binding:"required"`
+	WhiteBalance *whiteBalance `json:"whiteBalance" binding:"required"`
+	Dithering    bool          `json:"dithering"`
+	// zero is a valid brightness, so it has to be told apart from missing
+	Brightness  *float64     `json:"brightness" binding:"required,min=0,max=1"`
+	PowerBudget *powerBudget `json:"powerBudget,omitempty"`
+}
+
+func newRenderSettingsBody(settings *domain.RenderSettings) *renderSettingsBody {
+	brightness := settings.Brightness
+	return &renderSettingsBody{
+		Gamma: settings.Gamma,
+		WhiteBalance: &whiteBalance{
+			R: settings.WhiteBalance.R,
+			G: settings.WhiteBalance.G,
+			B: settings.WhiteBalance.B,
+		},
+		Dithering:   settings.Dithering,
+		Brightness:  &brightness,
+		PowerBudget: newPowerBudget(settings.PowerBudget),
+	}
+}
+
+func newPowerBudget(budget types.PowerBudget) *powerBudget {
+	segments := make([]powerSegment, 0, len(budget.Segments))
+	for _, segment := range budget.Segments {
+		segments = append(segments, powerSegment{
+			Universe:     segment.Universe,
+			FirstPixel:   segment.FirstPixel,
+			PixelCount:   segment.PixelCount,
+			MaxMilliamps: segment.MaxMilliamps,
+		})
+	}
+	return &powerBudget{
+		MilliampsPerChannel: budget.MilliampsPerChannel,
+		Segments:            segments,
+	}
+}
+
+func (b *powerBudget) toPowerBudget() types.PowerBudget {
+	if b == nil {
+		return types.PowerBudget{}
+	}
+	budget := types.PowerBudget{
+		MilliampsPerChannel: b.MilliampsPerChannel,
+		Segments:            make([]types.PowerSegment, 0, len(b.Segments)),
+	}
+	for _, segment := range b.Segments {
+		budget.Segments = append(budget.Segments, types.PowerSegment{
+			Universe:     segment.Universe,
+			FirstPixel:   segment.FirstPixel,
+			PixelCount:   segment.PixelCount,
+			MaxMilliamps: segment.MaxMilliamps,
+		})
+	}
+	return budget
+}
+
+func (s *Server) getRenderSettings(c *gin.Context) {
+	settings, err := s.bus.FetchRenderSettings()
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, newRenderSettingsBody(settings))
+}
+
+func (s *Server) putRenderSettings(c *gin.Context) {
+	var req renderSettingsBody
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondWithValidationError(c, err)
+		return
+	}
+	// the render service owns range validation; it answers with domain.ErrInvalidSettings
+	err := s.bus.SetRenderSettings(&domain.RenderSettings{
+		Gamma: req.Gamma,
+		WhiteBalance: types.WhiteBalance{
+			R: req.WhiteBalance.R,
+			G: req.WhiteBalance.G,
+			B: req.WhiteBalance.B,
+		},
+		Dithering:   req.Dithering,
+		Brightness:  *req.Brightness,
+		PowerBudget: req.PowerBudget.toPowerBudget(),
+	})
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
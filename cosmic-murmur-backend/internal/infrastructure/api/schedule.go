@@ -0,0 +1,103 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/domain"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/types"
+	"net/http"
+)
+
+// scheduleTime is minutes after midnight for a clock anchor, or minutes either side of sunrise / sunset
+type scheduleTime struct {
+	Anchor  string `json:"anchor" binding:"required,oneof=clock sunrise sunset"`
+	Minutes int    `json:"minutes"`
+}
+
+func (t scheduleTime) toScheduleTime() types.ScheduleTime {
+	return types.ScheduleTime{Anchor: types.ScheduleAnchor(t.Anchor), Minutes: t.Minutes}
+}
+
+func newScheduleTime(t types.ScheduleTime) scheduleTime {
+	return scheduleTime{Anchor: string(t.Anchor), Minutes: t.Minutes}
+}
+
+type scheduleWindow struct {
+	On  scheduleTime `json:"on"`
+	Off scheduleTime `json:"off"`
+}
+
+type playlistEntry struct {
+	Start      scheduleTime `json:"start"`
+	ShaderName string       `json:"shaderName" binding:"required"`
+}
+
+type scheduleSettingsBody struct {
+	Enabled   bool             `json:"enabled"`
+	Latitude  float64          `json:"latitude" binding:"min=-90,max=90"`
+	Longitude float64          `json:"longitude" binding:"min=-180,max=180"`
+	Windows   []scheduleWindow `json:"windows" binding:"dive"`
+	Playlist  []playlistEntry  `json:"playlist" binding:"dive"`
+}
+
+func newScheduleSettingsBody(settings *domain.ScheduleSettings) *scheduleSettingsBody {
+	windows := make([]scheduleWindow, 0, len(settings.Windows))
+	for _, w := range settings.Windows {
+		windows = append(windows, scheduleWindow{On: newScheduleTime(w.On), Off: newScheduleTime(w.Off)})
+	}
+	playlist := make([]playlistEntry, 0, len(settings.Playlist))
+	for _, entry := range settings.Playlist {
+		playlist = append(playlist, playlistEntry{Start: newScheduleTime(entry.Start), ShaderName: entry.ShaderName})
+	}
+	return &scheduleSettingsBody{
+		Enabled:   settings.Enabled,
+		Latitude:  settings.Location.Latitude,
+		Longitude: settings.Location.Longitude,
+		Windows:   windows,
+		Playlist:  playlist,
+	}
+}
+
+type scheduleStateEvent struct {
+	ShowOn     bool   `json:"showOn"`
+	ShaderName string `json:"shaderName"`
+}
+
+func (s *Server) getScheduleSettings(c *gin.Context) {
+	settings, err := s.bus.FetchScheduleSettings()
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, newScheduleSettingsBody(settings))
+}
+
+func (s *Server) putScheduleSettings(c *gin.Context) {
+	var req scheduleSettingsBody
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondWithValidationError(c, err)
+		return
+	}
+	settings := &domain.ScheduleSettings{
+		Enabled:  req.Enabled,
+		Location: types.GeoLocation{Latitude: req.Latitude, Longitude: req.Longitude},
+		Windows:  make([]types.ScheduleWindow, 0, len(req.Windows)),
+		Playlist: make([]types.PlaylistEntry, 0, len(req.Playlist)),
+	}
+	for _, w := range req.Windows {
+		settings.Windows = append(settings.Windows, types.ScheduleWindow{
+			On: w.On.toScheduleTime(), Off: w.Off.toScheduleTime(),
+		})
+	}
+	for _, entry := range req.Playlist {
+		settings.Playlist = append(settings.Playlist, types.PlaylistEntry{
+			Start: entry.Start.toScheduleTime(), ShaderName: entry.ShaderName,
+		})
+	}
+	// the scheduler owns time range validation, and the bus checks the playlist's shaders exist
+	err := s.bus.SetScheduleSettings(settings)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/gin-gonic/contrib/static"
@@ -9,35 +10,81 @@ import (
 	"net"
 	"net/http"
 	"sync"
+	"time"
 )
 
+const shutdownTimeout = 5 * time.Second
+
 type Server struct {
+	bus          Bus
 	router       *gin.Engine
 	srv          *http.Server
+	streamsDone  chan struct{}
 	shutdown     bool
 	shutdownLock sync.Mutex
 	port         int
+	wg           *sync.WaitGroup
 }
 
-func NewServer(cfg Config) (*Server, error) {
-
-	htmlPath, err := checkIfIsHtmlRoot(cfg.GetWebServerRootDirectory(), cfg.GetProgramName())
-	if err != nil {
-		return nil, err
-	}
+func NewServer(cfg Config, bus Bus) (*Server, error) {
 
 	if cfg.GetWebServerIsProduction() {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
 	router := gin.Default()
-	router.Use(static.Serve("/", static.LocalFile(htmlPath, true)))
 
-	return &Server{
+	htmlPath, err := checkIfIsHtmlRoot(cfg.GetWebServerRootDirectory(), cfg.GetProgramName())
+	if err != nil {
+		log.Println(fmt.Sprintf("FrontendServer, NewServer: not serving frontend; %s", err.Error()))
+	} else {
+		router.Use(static.Serve("/", static.LocalFile(htmlPath, true)))
+	}
+
+	s := &Server{
+		bus:      bus,
 		router:   router,
 		port:     cfg.GetWebServerPort(),
 		shutdown: true,
-	}, nil
+		wg:       &sync.WaitGroup{},
+	}
+	s.registerRoutes()
+
+	return s, nil
+}
+
+func (s *Server) registerRoutes() {
+	v1 := s.router.Group("/api/v1")
+	v1.GET("/health", s.getHealth)
+	v1.GET("/graphics", s.getGraphicsSettings)
+	v1.PUT("/graphics", s.putGraphicsSettings)
+	v1.PUT("/graphics/uniforms/:shader", s.putGraphicsUniforms)
+	v1.PUT("/graphics/shaders/:shader", s.putGraphicsShader)
+	v1.DELETE("/graphics/shaders/:shader", s.deleteGraphicsShader)
+	v1.POST("/graphics/shaders/:shader/rename", s.postRenameGraphicsShader)
+	v1.PUT("/graphics/playlists", s.putGraphicsPlaylists)
+	v1.POST("/graphics/playlist/start", s.postStartGraphicsPlaylist)
+	v1.POST("/graphics/playlist/stop", s.postStopGraphicsPlaylist)
+	v1.POST("/graphics/playlist/skip", s.postSkipGraphicsPlaylist)
+	v1.GET("/lighting", s.getLightingSettings)
+	v1.PUT("/lighting", s.putLightingSettings)
+	v1.GET("/controller", s.getControllerSettings)
+	v1.PUT("/controller", s.putControllerSettings)
+	v1.GET("/render", s.getRenderSettings)
+	v1.PUT("/render", s.putRenderSettings)
+	v1.GET("/schedule", s.getScheduleSettings)
+	v1.PUT("/schedule", s.putScheduleSettings)
+	v1.GET("/controller/nodes", s.getNodeHealth)
+	v1.GET("/controller/proposal", s.getNodeDefinitionsProposal)
+	v1.POST("/controller/proposal/accept", s.postAcceptNodeDefinitionsProposal)
+	v1.GET("/status", s.getStatus)
+	v1.POST("/reset", s.postReset)
+	v1.GET("/events", s.getEvents)
+	v1.GET("/preview.png", s.getPreview)
+}
+
+func (s *Server) getHealth(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
 }
 
 func (s *Server) Startup() error {
@@ -57,6 +104,47 @@ func (s *Server) Startup() error {
 		log.Printf("FrontendServer, Startup: Failed to listen: %v", err)
 		return err
 	}
-	log.Println(listener)
+
+	s.srv = &http.Server{
+		Handler: s.router,
+	}
+	s.streamsDone = make(chan struct{})
+	s.shutdown = false
+
+	s.wg.Add(1)
+	go func(srv *http.Server) {
+		defer s.wg.Done()
+		err := srv.Serve(listener)
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("FrontendServer, Serve: stopped unexpectedly: %v", err)
+		}
+	}(s.srv)
+
 	return nil
 }
+
+func (s *Server) Shutdown() error {
+
+	s.shutdownLock.Lock()
+	defer s.shutdownLock.Unlock()
+
+	if s.shutdown {
+		return nil
+	}
+	s.shutdown = true
+
+	log.Println("FrontendServer, Shutdown: shutting down")
+
+	// event streams never finish on their own; end them so Shutdown isn't stuck waiting on them
+	close(s.streamsDone)
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	err := s.srv.Shutdown(ctx)
+	s.wg.Wait()
+	s.srv = nil
+
+	log.Println("FrontendServer, Shutdown: finished")
+
+	return err
+}
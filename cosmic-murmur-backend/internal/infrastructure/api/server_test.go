@@ -0,0 +1,764 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/domain"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/types"
+	"image"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testConfig struct{}
+
+func (c *testConfig) GetWebServerPort() int {
+	return 0
+}
+
+func (c *testConfig) GetWebServerRootDirectory() string {
+	return "no-frontend-in-tests"
+}
+
+func (c *testConfig) GetProgramName() string {
+	return "cosmic-murmur-backend"
+}
+
+func (c *testConfig) GetWebServerIsProduction() bool {
+	return true
+}
+
+type testBus struct {
+	graphicsSettings   *domain.GraphicsSettings
+	lightingSettings   *domain.LightingSettings
+	controllerSettings *domain.ControllerSettings
+	renderSettings     *domain.RenderSettings
+	scheduleSettings   *domain.ScheduleSettings
+	status             *domain.ApplicationStatus
+	events             chan *domain.StreamEvent
+	proposal           *domain.NodeDefinitionsProposal
+	err                error
+}
+
+func (b *testBus) FetchGraphicsSettings() (*domain.GraphicsSettings, error) {
+	return b.graphicsSettings, b.err
+}
+
+func (b *testBus) SetGraphicsSettings(shaderName string, refreshInMs int64, reloadOnUpdate bool, transition *types.Transition) error {
+	if b.err != nil {
+		return b.err
+	}
+	for _, shader := range b.graphicsSettings.Shaders {
+		if shader == shaderName {
+			b.graphicsSettings.RunningShader = shaderName
+			b.graphicsSettings.Frequency = time.Duration(refreshInMs) * time.Millisecond
+			b.graphicsSettings.ReloadOnUpdate = reloadOnUpdate
+			if transition != nil {
+				b.graphicsSettings.Transition = *transition
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: Shader %s", domain.ErrNotFound, shaderName)
+}
+
+func (b *testBus) SetGraphicsUniforms(shaderName string, values map[string]float32) error {
+	if b.err != nil {
+		return b.err
+	}
+	uniforms, ok := b.graphicsSettings.Uniforms[shaderName]
+	if !ok {
+		return fmt.Errorf("%w: Shader %s", domain.ErrNotFound, shaderName)
+	}
+	for name, v := range values {
+		found := false
+		for i := range uniforms {
+			if uniforms[i].Name == name {
+				uniforms[i].Value = v
+				found = true
+			}
+		}
+		if !found {
+			return fmt.Errorf("%w: Shader %s uniform %s", domain.ErrNotFound, shaderName, name)
+		}
+	}
+	return nil
+}
+
+func (b *testBus) UploadGraphicsShader(name string, frag string, vert string) error {
+	if b.err != nil {
+		return b.err
+	}
+	if !strings.Contains(frag, "void main") || !strings.Contains(vert, "void main") {
+		return fmt.Errorf("%w: shader %s doesn't compile", domain.ErrInvalidSettings, name)
+	}
+	for _, shader := range b.graphicsSettings.UserShaders {
+		if shader == name {
+			return nil
+		}
+	}
+	b.graphicsSettings.Shaders = append(b.graphicsSettings.Shaders, name)
+	b.graphicsSettings.UserShaders = append(b.graphicsSettings.UserShaders, name)
+	return nil
+}
+
+func (b *testBus) DeleteGraphicsShader(name string) error {
+	return b.RenameGraphicsShader(name, "")
+}
+
+func (b *testBus) RenameGraphicsShader(name string, newName string) error {
+	if b.err != nil {
+		return b.err
+	}
+	for i, shader := range b.graphicsSettings.UserShaders {
+		if shader == name {
+			b.graphicsSettings.UserShaders = append(b.graphicsSettings.UserShaders[:i], b.graphicsSettings.UserShaders[i+1:]...)
+			if newName != "" {
+				b.graphicsSettings.UserShaders = append(b.graphicsSettings.UserShaders, newName)
+			}
+			return nil
+		}
+	}
+	for _, shader := range b.graphicsSettings.Shaders {
+		if shader == name {
+			return fmt.Errorf("%w: shader %s is built in", domain.ErrInvalidSettings, name)
+		}
+	}
+	return fmt.Errorf("%w: Shader %s", domain.ErrNotFound, name)
+}
+
+func (b *testBus) SetGraphicsPlaylists(playlists []types.Playlist) error {
+	if b.err != nil {
+		return b.err
+	}
+	b.graphicsSettings.Playlists = playlists
+	return nil
+}
+
+func (b *testBus) StartGraphicsPlaylist(name string) error {
+	if b.err != nil {
+		return b.err
+	}
+	for _, p := range b.graphicsSettings.Playlists {
+		if p.Name == name {
+			b.graphicsSettings.ActivePlaylist = name
+			b.graphicsSettings.RunningShader = p.Items[0].ShaderName
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: Playlist %s", domain.ErrNotFound, name)
+}
+
+func (b *testBus) StopGraphicsPlaylist() error {
+	b.graphicsSettings.ActivePlaylist = ""
+	return b.err
+}
+
+func (b *testBus) SkipGraphicsPlaylist() error {
+	if b.graphicsSettings.ActivePlaylist == "" {
+		return fmt.Errorf("%w: no playlist is running", domain.ErrNotFound)
+	}
+	b.graphicsSettings.PlaylistItem += 1
+	return b.err
+}
+
+func (b *testBus) FetchLightingSettings() (*domain.LightingSettings, error) {
+	return b.lightingSettings, b.err
+}
+
+func (b *testBus) SetLightingSettings(segmentDefinition types.LedSegment, segmentCount int) error {
+	if b.err != nil {
+		return b.err
+	}
+	if segmentCount < 1 {
+		return fmt.Errorf("%w: segment count", domain.ErrInvalidSettings)
+	}
+	b.lightingSettings.SegmentDefinition = segmentDefinition
+	b.lightingSettings.SegmentCount = segmentCount
+	return nil
+}
+
+func (b *testBus) FetchControllerSettings() (*domain.ControllerSettings, error) {
+	return b.controllerSettings, b.err
+}
+
+func (b *testBus) SetControllerSettings(nodeDefinitions types.NodeDefinitions, localAddress string) error {
+	if b.err != nil {
+		return b.err
+	}
+	b.controllerSettings.NodeDefinitions = nodeDefinitions
+	b.controllerSettings.LocalAddress = localAddress
+	return nil
+}
+
+func (b *testBus) FetchRenderSettings() (*domain.RenderSettings, error) {
+	return b.renderSettings, b.err
+}
+
+func (b *testBus) SetRenderSettings(settings *domain.RenderSettings) error {
+	if b.err != nil {
+		return b.err
+	}
+	b.renderSettings = settings
+	return nil
+}
+
+func (b *testBus) FetchScheduleSettings() (*domain.ScheduleSettings, error) {
+	return b.scheduleSettings, b.err
+}
+
+func (b *testBus) SetScheduleSettings(settings *domain.ScheduleSettings) error {
+	if b.err != nil {
+		return b.err
+	}
+	b.scheduleSettings = settings
+	return nil
+}
+
+func (b *testBus) FetchNodeHealth() ([]*domain.NodeHealth, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	health := make([]*domain.NodeHealth, 0, len(b.controllerSettings.NodeDefinitions))
+	for _, d := range b.controllerSettings.NodeDefinitions {
+		health = append(health, &domain.NodeHealth{Address: d.Address, Port: d.Port})
+	}
+	return health, nil
+}
+
+func (b *testBus) ProposeNodeDefinitions() (*domain.NodeDefinitionsProposal, error) {
+	return b.proposal, b.err
+}
+
+func (b *testBus) AcceptNodeDefinitions() error {
+	if b.err != nil {
+		return b.err
+	}
+	if b.proposal == nil {
+		return fmt.Errorf("%w: no node definitions have been proposed", domain.ErrNotFound)
+	}
+	b.controllerSettings.NodeDefinitions = b.proposal.NodeDefinitions
+	b.proposal = nil
+	return nil
+}
+
+func (b *testBus) ResetApplication() error {
+	return b.err
+}
+
+func (b *testBus) FetchStatus() (*domain.ApplicationStatus, error) {
+	return b.status, b.err
+}
+
+func (b *testBus) FetchPreview(lightsOnly bool) (*image.RGBA, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	pb := types.NewPixelBuffer(2, 2, 0, 0, 1)
+	// bottom left of the frame
+	p := types.CreatePoint(0, 0)
+	*pb.GetPixelPointer(&p) = types.Color{R: 255}
+	if lightsOnly {
+		return pb.ToLightsImage([][]*types.Light{{{Position: types.CreatePoint(1, 1)}}}), nil
+	}
+	return pb.ToImage(), nil
+}
+
+func (b *testBus) Subscribe() (<-chan *domain.StreamEvent, func()) {
+	return b.events, func() {}
+}
+
+func newTestServer(t *testing.T) (*Server, *testBus) {
+	b := &testBus{
+		graphicsSettings: &domain.GraphicsSettings{
+			Shaders:        []string{"basic", "snake_wipe"},
+			RunningShader:  "basic",
+			Frequency:      33 * time.Millisecond,
+			ReloadOnUpdate: false,
+		},
+		lightingSettings: &domain.LightingSettings{
+			SegmentDefinition: types.LedSegment{
+				types.LedUniverse{
+					types.LedString{LedCount: 3, StringCount: 5},
+				},
+			},
+			SegmentCount: 1,
+		},
+		controllerSettings: &domain.ControllerSettings{
+			NodeDefinitions: types.NodeDefinitions{
+				types.NodeDefinition{Address: "2.0.0.2", Universes: []int{0, 1}},
+			},
+			LocalAddress: "2.0.0.1",
+		},
+		renderSettings: &domain.RenderSettings{
+			Gamma:        1,
+			WhiteBalance: types.WhiteBalance{R: 1, G: 1, B: 1},
+		},
+		scheduleSettings: &domain.ScheduleSettings{},
+		events:           make(chan *domain.StreamEvent, 10),
+	}
+	s, err := NewServer(&testConfig{}, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, b
+}
+
+func doRequest(s *Server, method string, path string, body interface{}) *httptest.ResponseRecorder {
+	var buf bytes.Buffer
+	if body != nil {
+		_ = json.NewEncoder(&buf).Encode(body)
+	}
+	req := httptest.NewRequest(method, path, &buf)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestServer_graphicsSettings(t *testing.T) {
+	s, b := newTestServer(t)
+
+	w := doRequest(s, http.MethodGet, "/api/v1/graphics", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET status = %d", w.Code)
+	}
+	var resp graphicsSettingsResponse
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	if resp.RunningShader != "basic" || resp.RefreshInMs != 33 || len(resp.Shaders) != 2 {
+		t.Errorf("GET body = %s", w.Body.String())
+	}
+
+	w = doRequest(s, http.MethodPut, "/api/v1/graphics", &setGraphicsSettingsRequest{
+		ShaderName: "snake_wipe", RefreshInMs: 20, ReloadOnUpdate: true,
+	})
+	if w.Code != http.StatusNoContent || b.graphicsSettings.RunningShader != "snake_wipe" {
+		t.Errorf("PUT status = %d, running = %s", w.Code, b.graphicsSettings.RunningShader)
+	}
+
+	w = doRequest(s, http.MethodPut, "/api/v1/graphics", &setGraphicsSettingsRequest{
+		ShaderName: "basic", RefreshInMs: 20,
+		Transition: &transition{DurationInMs: 1500, Easing: "easeInOut", MaskShader: "snake_wipe"},
+	})
+	if w.Code != http.StatusNoContent || b.graphicsSettings.Transition.Duration != 1500*time.Millisecond ||
+		b.graphicsSettings.Transition.MaskShader != "snake_wipe" {
+		t.Errorf("PUT with transition status = %d, transition = %v", w.Code, b.graphicsSettings.Transition)
+	}
+
+	w = doRequest(s, http.MethodPut, "/api/v1/graphics", &setGraphicsSettingsRequest{
+		ShaderName: "basic", RefreshInMs: 20, Transition: &transition{DurationInMs: 1500, Easing: "bounce"},
+	})
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("PUT with unknown easing status = %d; expected 400", w.Code)
+	}
+
+	w = doRequest(s, http.MethodPut, "/api/v1/graphics", &setGraphicsSettingsRequest{
+		ShaderName: "snake_wipe", RefreshInMs: 0,
+	})
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("PUT without refresh status = %d; expected 400", w.Code)
+	}
+
+	w = doRequest(s, http.MethodPut, "/api/v1/graphics", &setGraphicsSettingsRequest{
+		ShaderName: "not_a_shader", RefreshInMs: 20,
+	})
+	if w.Code != http.StatusNotFound {
+		t.Errorf("PUT unknown shader status = %d; expected 404", w.Code)
+	}
+
+	b.err = fmt.Errorf("%w: eventloop not responding", domain.ErrUnavailable)
+	w = doRequest(s, http.MethodGet, "/api/v1/graphics", nil)
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("GET with busy bus status = %d; expected 503", w.Code)
+	}
+}
+
+func TestServer_graphicsUniforms(t *testing.T) {
+	s, b := newTestServer(t)
+	b.graphicsSettings.Uniforms = map[string][]domain.UniformSetting{
+		"basic": {{
+			ShaderUniform: types.ShaderUniform{Name: "speed", Type: types.UniformTypeFloat, Max: 10, Default: 2},
+			Value:         2,
+		}},
+	}
+
+	w := doRequest(s, http.MethodPut, "/api/v1/graphics/uniforms/basic", &setGraphicsUniformsRequest{
+		Values: map[string]float32{"speed": 4.5},
+	})
+	if w.Code != http.StatusNoContent {
+		t.Errorf("PUT status = %d", w.Code)
+	}
+	w = doRequest(s, http.MethodGet, "/api/v1/graphics", nil)
+	var resp graphicsSettingsResponse
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	if u := resp.Uniforms["basic"]; len(u) != 1 || u[0].Value != 4.5 || u[0].Default != 2 || u[0].Max != 10 {
+		t.Errorf("GET body = %s", w.Body.String())
+	}
+
+	w = doRequest(s, http.MethodPut, "/api/v1/graphics/uniforms/basic", &setGraphicsUniformsRequest{
+		Values: map[string]float32{"scale": 1},
+	})
+	if w.Code != http.StatusNotFound {
+		t.Errorf("PUT undeclared uniform status = %d; expected 404", w.Code)
+	}
+	w = doRequest(s, http.MethodPut, "/api/v1/graphics/uniforms/basic", nil)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("PUT without values status = %d; expected 400", w.Code)
+	}
+}
+
+func doShaderUpload(s *Server, path string, files map[string]string) *httptest.ResponseRecorder {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	for field, source := range files {
+		fw, _ := mw.CreateFormFile(field, field+".glsl")
+		_, _ = fw.Write([]byte(source))
+	}
+	_ = mw.Close()
+	req := httptest.NewRequest(http.MethodPut, path, &buf)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestServer_graphicsShaders(t *testing.T) {
+	s, b := newTestServer(t)
+	source := "void main() {}"
+
+	w := doShaderUpload(s, "/api/v1/graphics/shaders/ripple", map[string]string{"frag": source, "vert": source})
+	if w.Code != http.StatusNoContent {
+		t.Errorf("PUT status = %d", w.Code)
+	}
+	w = doShaderUpload(s, "/api/v1/graphics/shaders/ripple", map[string]string{"frag": "void", "vert": source})
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("PUT broken shader status = %d; expected 400", w.Code)
+	}
+	w = doShaderUpload(s, "/api/v1/graphics/shaders/ripple", map[string]string{"frag": source})
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("PUT without a vertex shader status = %d; expected 400", w.Code)
+	}
+	w = doShaderUpload(s, "/api/v1/graphics/shaders/ripple", map[string]string{
+		"frag": strings.Repeat(" ", maxShaderSourceSize+1) + source, "vert": source,
+	})
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("PUT oversized shader status = %d; expected 400", w.Code)
+	}
+
+	w = doRequest(s, http.MethodPost, "/api/v1/graphics/shaders/ripple/rename", &renameGraphicsShaderRequest{
+		Name: "waves",
+	})
+	if w.Code != http.StatusNoContent {
+		t.Errorf("rename status = %d", w.Code)
+	}
+	w = doRequest(s, http.MethodGet, "/api/v1/graphics", nil)
+	var resp graphicsSettingsResponse
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	if len(resp.UserShaders) != 1 || resp.UserShaders[0] != "waves" {
+		t.Errorf("GET body = %s", w.Body.String())
+	}
+
+	w = doRequest(s, http.MethodDelete, "/api/v1/graphics/shaders/basic", nil)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("DELETE built in status = %d; expected 400", w.Code)
+	}
+	w = doRequest(s, http.MethodDelete, "/api/v1/graphics/shaders/waves", nil)
+	if w.Code != http.StatusNoContent || len(b.graphicsSettings.UserShaders) != 0 {
+		t.Errorf("DELETE status = %d, user shaders = %v", w.Code, b.graphicsSettings.UserShaders)
+	}
+	w = doRequest(s, http.MethodDelete, "/api/v1/graphics/shaders/waves", nil)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("DELETE missing status = %d; expected 404", w.Code)
+	}
+}
+
+func TestServer_graphicsPlaylists(t *testing.T) {
+	s, b := newTestServer(t)
+
+	w := doRequest(s, http.MethodPut, "/api/v1/graphics/playlists", &setGraphicsPlaylistsRequest{
+		Playlists: []graphicsPlaylist{{
+			Name: "evening", Mode: "shuffle",
+			Items: []graphicsPlaylistItem{
+				{ShaderName: "basic", DurationInMs: 60000, Uniforms: map[string]float32{"speed": 2}},
+				{
+					ShaderName: "snake_wipe", DurationInMs: 30000, RefreshInMs: 20,
+					Transition: &transition{DurationInMs: 2000, Easing: "linear"},
+				},
+			},
+		}},
+	})
+	if w.Code != http.StatusNoContent || len(b.graphicsSettings.Playlists) != 1 {
+		t.Fatalf("PUT status = %d, playlists = %v", w.Code, b.graphicsSettings.Playlists)
+	}
+	item := b.graphicsSettings.Playlists[0].Items[1]
+	if item.Duration != 30*time.Second || item.Frequency != 20*time.Millisecond || item.Transition == nil {
+		t.Errorf("PUT item = %v", item)
+	}
+
+	w = doRequest(s, http.MethodPost, "/api/v1/graphics/playlist/skip", nil)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("skip without a playlist status = %d; expected 404", w.Code)
+	}
+	w = doRequest(s, http.MethodPost, "/api/v1/graphics/playlist/start", &startGraphicsPlaylistRequest{Name: "evening"})
+	if w.Code != http.StatusNoContent {
+		t.Errorf("start status = %d", w.Code)
+	}
+	w = doRequest(s, http.MethodPost, "/api/v1/graphics/playlist/skip", nil)
+	if w.Code != http.StatusNoContent {
+		t.Errorf("skip status = %d", w.Code)
+	}
+
+	w = doRequest(s, http.MethodGet, "/api/v1/graphics", nil)
+	var resp graphicsSettingsResponse
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	if resp.ActivePlaylist != "evening" || resp.PlaylistItem != 1 || len(resp.Playlists) != 1 ||
+		resp.Playlists[0].Items[0].Uniforms["speed"] != 2 {
+		t.Errorf("GET body = %s", w.Body.String())
+	}
+
+	w = doRequest(s, http.MethodPost, "/api/v1/graphics/playlist/stop", nil)
+	if w.Code != http.StatusNoContent || b.graphicsSettings.ActivePlaylist != "" {
+		t.Errorf("stop status = %d, active = %s", w.Code, b.graphicsSettings.ActivePlaylist)
+	}
+
+	w = doRequest(s, http.MethodPut, "/api/v1/graphics/playlists", &setGraphicsPlaylistsRequest{
+		Playlists: []graphicsPlaylist{{
+			Name: "quick", Mode: "sequential", Items: []graphicsPlaylistItem{{ShaderName: "basic", DurationInMs: 10}},
+		}},
+	})
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("PUT with too short an item status = %d; expected 400", w.Code)
+	}
+}
+
+func TestServer_lightingSettings(t *testing.T) {
+	s, b := newTestServer(t)
+
+	w := doRequest(s, http.MethodGet, "/api/v1/lighting", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET status = %d", w.Code)
+	}
+	var resp lightingSettingsBody
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	if resp.SegmentCount != 1 || resp.SegmentDefinition[0][0].StringCount != 5 {
+		t.Errorf("GET body = %s", w.Body.String())
+	}
+
+	w = doRequest(s, http.MethodPut, "/api/v1/lighting", &lightingSettingsBody{
+		SegmentDefinition: [][]ledString{{{LedCount: 5, StringCount: 2}}, {{LedCount: 3, StringCount: 1}}},
+		SegmentCount:      2,
+	})
+	if w.Code != http.StatusNoContent || len(b.lightingSettings.SegmentDefinition) != 2 {
+		t.Errorf("PUT status = %d, settings = %v", w.Code, b.lightingSettings)
+	}
+
+	w = doRequest(s, http.MethodPut, "/api/v1/lighting", map[string]int{"segmentCount": 1})
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("PUT without definition status = %d; expected 400", w.Code)
+	}
+}
+
+func TestServer_controllerSettings(t *testing.T) {
+	s, b := newTestServer(t)
+
+	w := doRequest(s, http.MethodGet, "/api/v1/controller", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET status = %d", w.Code)
+	}
+	var resp controllerSettingsBody
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	if resp.LocalAddress != "2.0.0.1" || resp.NodeDefinitions[0].Address != "2.0.0.2" {
+		t.Errorf("GET body = %s", w.Body.String())
+	}
+
+	w = doRequest(s, http.MethodPut, "/api/v1/controller", &controllerSettingsBody{
+		LocalAddress: "2.0.0.1",
+		NodeDefinitions: []nodeDefinition{
+			{Address: "2.0.0.3", Universes: []int{0, 1, 2}},
+		},
+	})
+	if w.Code != http.StatusNoContent || b.controllerSettings.NodeDefinitions[0].Address != "2.0.0.3" {
+		t.Errorf("PUT status = %d, settings = %v", w.Code, b.controllerSettings)
+	}
+
+	w = doRequest(s, http.MethodPut, "/api/v1/controller", &controllerSettingsBody{
+		LocalAddress:    "2.0.0.1",
+		NodeDefinitions: []nodeDefinition{{Address: "2.0.0.3"}},
+	})
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("PUT without universes status = %d; expected 400", w.Code)
+	}
+}
+
+func TestServer_renderSettings(t *testing.T) {
+	s, b := newTestServer(t)
+
+	w := doRequest(s, http.MethodGet, "/api/v1/render", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET status = %d", w.Code)
+	}
+	var resp renderSettingsBody
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	if resp.Gamma != 1 || resp.WhiteBalance == nil || resp.WhiteBalance.G != 1 || resp.Brightness == nil {
+		t.Errorf("GET body = %s", w.Body.String())
+	}
+
+	w = doRequest(s, http.MethodPut, "/api/v1/render", &renderSettingsBody{
+		Gamma: 2.2, WhiteBalance: &whiteBalance{R: 1, G: 1, B: 1},
+	})
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("PUT without brightness status = %d; expected 400", w.Code)
+	}
+
+	brightness := 0.5
+	w = doRequest(s, http.MethodPut, "/api/v1/render", &renderSettingsBody{
+		Gamma: 2.2, WhiteBalance: &whiteBalance{R: 1, G: 0.9, B: 0.8}, Dithering: true, Brightness: &brightness,
+		PowerBudget: &powerBudget{
+			MilliampsPerChannel: 20,
+			Segments:            []powerSegment{{Universe: 0, FirstPixel: 0, PixelCount: 84, MaxMilliamps: 2000}},
+		},
+	})
+	if w.Code != http.StatusNoContent || b.renderSettings.Gamma != 2.2 || b.renderSettings.WhiteBalance.B != 0.8 ||
+		b.renderSettings.Brightness != 0.5 || len(b.renderSettings.PowerBudget.Segments) != 1 {
+		t.Errorf("PUT status = %d, settings = %v", w.Code, b.renderSettings)
+	}
+
+	w = doRequest(s, http.MethodPut, "/api/v1/render", &renderSettingsBody{
+		Gamma: 2.2, WhiteBalance: &whiteBalance{R: 1.5, G: 1, B: 1}, Brightness: &brightness,
+	})
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("PUT with white balance over 1 status = %d; expected 400", w.Code)
+	}
+}
+
+func TestServer_scheduleSettings(t *testing.T) {
+	s, b := newTestServer(t)
+
+	w := doRequest(s, http.MethodPut, "/api/v1/schedule", &scheduleSettingsBody{
+		Enabled: true, Latitude: 41.88, Longitude: -87.63,
+		Windows: []scheduleWindow{{
+			On:  scheduleTime{Anchor: "sunset", Minutes: -15},
+			Off: scheduleTime{Anchor: "clock", Minutes: 60},
+		}},
+		Playlist: []playlistEntry{{Start: scheduleTime{Anchor: "clock", Minutes: 22 * 60}, ShaderName: "basic"}},
+	})
+	if w.Code != http.StatusNoContent || !b.scheduleSettings.Enabled || len(b.scheduleSettings.Windows) != 1 ||
+		b.scheduleSettings.Windows[0].On.Anchor != types.AnchorSunset || b.scheduleSettings.Playlist[0].ShaderName != "basic" {
+		t.Errorf("PUT status = %d, settings = %v", w.Code, b.scheduleSettings)
+	}
+
+	w = doRequest(s, http.MethodGet, "/api/v1/schedule", nil)
+	var resp scheduleSettingsBody
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	if w.Code != http.StatusOK || resp.Longitude != -87.63 || resp.Windows[0].Off.Minutes != 60 {
+		t.Errorf("GET body = %s", w.Body.String())
+	}
+
+	w = doRequest(s, http.MethodPut, "/api/v1/schedule", &scheduleSettingsBody{
+		Windows: []scheduleWindow{{On: scheduleTime{Anchor: "noon"}, Off: scheduleTime{Anchor: "clock"}}},
+	})
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("PUT with unknown anchor status = %d; expected 400", w.Code)
+	}
+}
+
+func TestServer_events(t *testing.T) {
+	s, b := newTestServer(t)
+
+	b.events <- &domain.StreamEvent{
+		Name:    domain.StreamEventNodeError,
+		TraceId: 42,
+		Time:    time.Now(),
+		Payload: &domain.NodeError{Address: "2.0.0.2", Error: "connection refused"},
+	}
+	close(b.events)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/events", nil).WithContext(ctx)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	if w.Header().Get("Content-Type") != "text/event-stream" {
+		t.Errorf("Content-Type = %s", w.Header().Get("Content-Type"))
+	}
+	body := w.Body.String()
+	for _, expected := range []string{"id:42\n", "event:nodeError\n", `"address":"2.0.0.2"`} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("body %q missing %q", body, expected)
+		}
+	}
+}
+
+func TestServer_preview(t *testing.T) {
+	s, b := newTestServer(t)
+
+	w := doRequest(s, http.MethodGet, "/api/v1/preview.png", nil)
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "image/png" {
+		t.Fatalf("GET status = %d, type = %s", w.Code, w.Header().Get("Content-Type"))
+	}
+	img, err := png.Decode(w.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r, _, _, _ := img.At(0, 1).RGBA(); r == 0 {
+		t.Error("expected the first buffer row at the bottom of the image")
+	}
+
+	w = doRequest(s, http.MethodGet, "/api/v1/preview.png?mode=leds", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET leds status = %d", w.Code)
+	}
+	img, err = png.Decode(w.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r, _, _, a := img.At(0, 1).RGBA(); r != 0 || a == 0 {
+		t.Error("expected pixels without a light to be opaque black")
+	}
+
+	w = doRequest(s, http.MethodGet, "/api/v1/preview.png?mode=sideways", nil)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("GET unknown mode status = %d; expected 400", w.Code)
+	}
+
+	b.err = fmt.Errorf("%w: eventloop closed connection", domain.ErrUnavailable)
+	w = doRequest(s, http.MethodGet, "/api/v1/preview.png", nil)
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("GET without graphics status = %d; expected 503", w.Code)
+	}
+}
+
+func TestServer_nodeDefinitionsProposal(t *testing.T) {
+	s, b := newTestServer(t)
+
+	w := doRequest(s, http.MethodPost, "/api/v1/controller/proposal/accept", nil)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("accept without a proposal status = %d; expected 404", w.Code)
+	}
+
+	b.proposal = &domain.NodeDefinitionsProposal{
+		NodeDefinitions: types.NodeDefinitions{
+			types.NodeDefinition{Address: "2.0.0.9", Universes: []int{0, 1}},
+		},
+		MissingUniverses: []int{2},
+	}
+	w = doRequest(s, http.MethodGet, "/api/v1/controller/proposal", nil)
+	var resp nodeDefinitionsProposalResponse
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	if w.Code != http.StatusOK || resp.NodeDefinitions[0].Address != "2.0.0.9" || resp.MissingUniverses[0] != 2 {
+		t.Errorf("GET status = %d, body = %s", w.Code, w.Body.String())
+	}
+
+	w = doRequest(s, http.MethodPost, "/api/v1/controller/proposal/accept", nil)
+	if w.Code != http.StatusNoContent || b.controllerSettings.NodeDefinitions[0].Address != "2.0.0.9" {
+		t.Errorf("accept status = %d, settings = %v", w.Code, b.controllerSettings)
+	}
+}
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/domain"
+	"net/http"
+)
+
+type powerSegmentStatus struct {
+	Universe           int     `json:"universe"`
+	FirstPixel         int     `json:"firstPixel"`
+	EstimatedMilliamps float64 `json:"estimatedMilliamps"`
+	MaxMilliamps       float64 `json:"maxMilliamps"`
+	Scale              float64 `json:"scale"`
+}
+
+type statusResponse struct {
+	GraphicsRunning bool                 `json:"graphicsRunning"`
+	RunningShader   string               `json:"runningShader"`
+	FramesRendered  uint64               `json:"framesRendered"`
+	GraphicsCrashes uint64               `json:"graphicsCrashes"`
+	LastFrameUnixMs int64                `json:"lastFrameUnixMs"`
+	PowerLimited    bool                 `json:"powerLimited"`
+	PowerSegments   []powerSegmentStatus `json:"powerSegments"`
+	ShowOn          bool                 `json:"showOn"`
+}
+
+func newStatusResponse(status *domain.ApplicationStatus) *statusResponse {
+	var lastFrameUnixMs int64
+	if !status.LastFrame.IsZero() {
+		lastFrameUnixMs = status.LastFrame.UnixMilli()
+	}
+	// powerLimited saves clients digging through the segments to find out if anything is being dimmed
+	powerLimited := false
+	powerSegments := make([]powerSegmentStatus, 0, len(status.PowerSegments))
+	for _, segment := range status.PowerSegments {
+		powerLimited = powerLimited || segment.Scale < 1
+		powerSegments = append(powerSegments, powerSegmentStatus{
+			Universe:           segment.Universe,
+			FirstPixel:         segment.FirstPixel,
+			EstimatedMilliamps: segment.EstimatedMilliamps,
+			MaxMilliamps:       segment.MaxMilliamps,
+			Scale:              segment.Scale,
+		})
+	}
+	return &statusResponse{
+		GraphicsRunning: status.GraphicsRunning,
+		RunningShader:   status.RunningShader,
+		FramesRendered:  status.FramesRendered,
+		GraphicsCrashes: status.GraphicsCrashes,
+		LastFrameUnixMs: lastFrameUnixMs,
+		PowerLimited:    powerLimited,
+		PowerSegments:   powerSegments,
+		ShowOn:          status.ShowOn,
+	}
+}
+
+func (s *Server) getStatus(c *gin.Context) {
+	status, err := s.bus.FetchStatus()
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, newStatusResponse(status))
+}
+
+func (s *Server) postReset(c *gin.Context) {
+	err := s.bus.ResetApplication()
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
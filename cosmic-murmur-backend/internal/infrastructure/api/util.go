@@ -3,6 +3,10 @@ package api
 import (
 	"errors"
 	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/domain"
+	"log"
+	"net/http"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -34,3 +38,25 @@ func checkIfIsHtmlRoot(filePath string, rootDir string) (string, error) {
 	}
 	return htmlPath, nil
 }
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func respondWithError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	if errors.Is(err, domain.ErrInvalidSettings) {
+		status = http.StatusBadRequest
+	} else if errors.Is(err, domain.ErrNotFound) {
+		status = http.StatusNotFound
+	} else if errors.Is(err, domain.ErrUnavailable) {
+		status = http.StatusServiceUnavailable
+	} else {
+		log.Println(fmt.Sprintf("FrontendServer, %s: unexpected error; %s", c.FullPath(), err.Error()))
+	}
+	c.JSON(status, &errorResponse{Error: err.Error()})
+}
+
+func respondWithValidationError(c *gin.Context, err error) {
+	c.JSON(http.StatusBadRequest, &errorResponse{Error: err.Error()})
+}
@@ -0,0 +1,19 @@
+package grpcApi
+
+import (
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/domain"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/types"
+)
+
+type Bus interface {
+	FetchGraphicsSettings() (*domain.GraphicsSettings, error)
+	SetGraphicsSettings(shaderName string, refreshInMs int64, reloadOnUpdate bool, transition *types.Transition) error
+	FetchLightingSettings() (*domain.LightingSettings, error)
+	SetLightingSettings(segmentDefinition types.LedSegment, segmentCount int) error
+	FetchControllerSettings() (*domain.ControllerSettings, error)
+	SetControllerSettings(nodeDefinitions types.NodeDefinitions, localAddress string) error
+	FetchRenderSettings() (*domain.RenderSettings, error)
+	SetRenderSettings(settings *domain.RenderSettings) error
+	ResetApplication() error
+	FetchStatus() (*domain.ApplicationStatus, error)
+}
@@ -0,0 +1,5 @@
+package grpcApi
+
+type Config interface {
+	GetGrpcPort() int
+}
@@ -0,0 +1,85 @@
+package grpcApi
+
+import (
+	"errors"
+	"fmt"
+	grpcSetting "github.com/polis-interactive/2023-CosmicMurmur/api/v1/go/setting"
+	"google.golang.org/grpc"
+	"log"
+	"net"
+	"sync"
+)
+
+type Server struct {
+	grpcSetting.UnimplementedSettingServiceServer
+	bus          Bus
+	srv          *grpc.Server
+	shutdown     bool
+	shutdownLock sync.Mutex
+	port         int
+	wg           *sync.WaitGroup
+}
+
+var _ grpcSetting.SettingServiceServer = (*Server)(nil)
+
+func NewServer(cfg Config, bus Bus) (*Server, error) {
+	return &Server{
+		bus:      bus,
+		port:     cfg.GetGrpcPort(),
+		shutdown: true,
+		wg:       &sync.WaitGroup{},
+	}, nil
+}
+
+func (s *Server) Startup() error {
+
+	s.shutdownLock.Lock()
+	defer s.shutdownLock.Unlock()
+
+	if s.shutdown == false {
+		return errors.New("GrpcServer, Startup: Tried to startup server twice")
+	}
+
+	addr := fmt.Sprintf("0.0.0.0:%d", s.port)
+	log.Println(fmt.Sprintf("GrpcServer, Startup: listening at %s", addr))
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		log.Printf("GrpcServer, Startup: Failed to listen: %v", err)
+		return err
+	}
+
+	s.srv = grpc.NewServer()
+	grpcSetting.RegisterSettingServiceServer(s.srv, s)
+	s.shutdown = false
+
+	s.wg.Add(1)
+	go func(srv *grpc.Server) {
+		defer s.wg.Done()
+		err := srv.Serve(listener)
+		if err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			log.Printf("GrpcServer, Serve: stopped unexpectedly: %v", err)
+		}
+	}(s.srv)
+
+	return nil
+}
+
+func (s *Server) Shutdown() {
+
+	s.shutdownLock.Lock()
+	defer s.shutdownLock.Unlock()
+
+	if s.shutdown {
+		return
+	}
+	s.shutdown = true
+
+	log.Println("GrpcServer, Shutdown: shutting down")
+
+	s.srv.GracefulStop()
+	s.wg.Wait()
+	s.srv = nil
+
+	log.Println("GrpcServer, Shutdown: finished")
+}
@@ -0,0 +1,280 @@
+package grpcApi
+
+import (
+	"context"
+	"fmt"
+	grpcCommon "github.com/polis-interactive/2023-CosmicMurmur/api/v1/go/common"
+	grpcSetting "github.com/polis-interactive/2023-CosmicMurmur/api/v1/go/setting"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/domain"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/types"
+	"time"
+)
+
+/*
+	bus failures are reported in the response status rather than as grpc errors, so clients only see a
+	transport error when the server itself is unreachable
+*/
+
+func statusFromError(err error) *grpcCommon.SuccessFailure {
+	if err != nil {
+		return &grpcCommon.SuccessFailure{Success: false, Error: err.Error()}
+	}
+	return &grpcCommon.SuccessFailure{Success: true}
+}
+
+func defaultResponse(err error) *grpcCommon.DefaultResponse {
+	return &grpcCommon.DefaultResponse{Status: statusFromError(err)}
+}
+
+func (s *Server) GetGraphicsSettings(_ context.Context, _ *grpcCommon.EmptyRequest) (*grpcSetting.GraphicsSettingsResponse, error) {
+	settings, err := s.bus.FetchGraphicsSettings()
+	if err != nil {
+		return &grpcSetting.GraphicsSettingsResponse{Status: statusFromError(err)}, nil
+	}
+	return &grpcSetting.GraphicsSettingsResponse{
+		Status: statusFromError(nil),
+		Settings: &grpcSetting.GraphicsSettings{
+			Shaders:        settings.Shaders,
+			RunningShader:  settings.RunningShader,
+			RefreshInMs:    settings.Frequency.Milliseconds(),
+			ReloadOnUpdate: settings.ReloadOnUpdate,
+			Transition: &grpcSetting.Transition{
+				DurationInMs: settings.Transition.Duration.Milliseconds(),
+				Easing:       string(settings.Transition.Easing),
+				MaskShader:   settings.Transition.MaskShader,
+			},
+			ActivePlaylist: settings.ActivePlaylist,
+		},
+	}, nil
+}
+
+func (s *Server) SetGraphicsSettings(_ context.Context, req *grpcSetting.SetGraphicsSettingsRequest) (*grpcCommon.DefaultResponse, error) {
+	if req.ShaderName == "" {
+		return defaultResponse(fmt.Errorf("%w: ShaderName is required", domain.ErrInvalidSettings)), nil
+	}
+	var transition *types.Transition
+	if req.Transition != nil {
+		transition = &types.Transition{
+			Duration:   time.Duration(req.Transition.DurationInMs) * time.Millisecond,
+			Easing:     types.Easing(req.Transition.Easing),
+			MaskShader: req.Transition.MaskShader,
+		}
+	}
+	err := s.bus.SetGraphicsSettings(req.ShaderName, req.RefreshInMs, req.ReloadOnUpdate, transition)
+	return defaultResponse(err), nil
+}
+
+func (s *Server) GetLightingSettings(_ context.Context, _ *grpcCommon.EmptyRequest) (*grpcSetting.LightingSettingsResponse, error) {
+	settings, err := s.bus.FetchLightingSettings()
+	if err != nil {
+		return &grpcSetting.LightingSettingsResponse{Status: statusFromError(err)}, nil
+	}
+	segmentDefinition := make([]*grpcSetting.LedUniverse, 0, len(settings.SegmentDefinition))
+	for _, universe := range settings.SegmentDefinition {
+		strings := make([]*grpcSetting.LedString, 0, len(universe))
+		for _, ledString := range universe {
+			strings = append(strings, &grpcSetting.LedString{
+				LedCount:    int32(ledString.LedCount),
+				StringCount: int32(ledString.StringCount),
+				PixelFormat: string(ledString.PixelFormat),
+			})
+		}
+		segmentDefinition = append(segmentDefinition, &grpcSetting.LedUniverse{Strings: strings})
+	}
+	return &grpcSetting.LightingSettingsResponse{
+		Status: statusFromError(nil),
+		Settings: &grpcSetting.LightingSettings{
+			SegmentDefinition: segmentDefinition,
+			SegmentCount:      int32(settings.SegmentCount),
+		},
+	}, nil
+}
+
+func (s *Server) SetLightingSettings(_ context.Context, req *grpcSetting.SetLightingSettingsRequest) (*grpcCommon.DefaultResponse, error) {
+	if req.Settings == nil {
+		return defaultResponse(fmt.Errorf("%w: Settings are required", domain.ErrInvalidSettings)), nil
+	}
+	segmentDefinition := make(types.LedSegment, 0, len(req.Settings.SegmentDefinition))
+	for _, universe := range req.Settings.SegmentDefinition {
+		ledUniverse := make(types.LedUniverse, 0, len(universe.Strings))
+		for _, ledString := range universe.Strings {
+			ledUniverse = append(ledUniverse, types.LedString{
+				LedCount:    int(ledString.LedCount),
+				StringCount: int(ledString.StringCount),
+				PixelFormat: types.PixelFormat(ledString.PixelFormat),
+			})
+		}
+		segmentDefinition = append(segmentDefinition, ledUniverse)
+	}
+	err := s.bus.SetLightingSettings(segmentDefinition, int(req.Settings.SegmentCount))
+	return defaultResponse(err), nil
+}
+
+func (s *Server) GetControllerSettings(_ context.Context, _ *grpcCommon.EmptyRequest) (*grpcSetting.ControllerSettingsResponse, error) {
+	settings, err := s.bus.FetchControllerSettings()
+	if err != nil {
+		return &grpcSetting.ControllerSettingsResponse{Status: statusFromError(err)}, nil
+	}
+	nodeDefinitions := make([]*grpcSetting.NodeDefinition, 0, len(settings.NodeDefinitions))
+	for _, nodeDefinition := range settings.NodeDefinitions {
+		universes := make([]int32, 0, len(nodeDefinition.Universes))
+		for _, universe := range nodeDefinition.Universes {
+			universes = append(universes, int32(universe))
+		}
+		nodeDefinitions = append(nodeDefinitions, &grpcSetting.NodeDefinition{
+			Address:      nodeDefinition.Address,
+			Universes:    universes,
+			Port:         int32(nodeDefinition.Port),
+			Sync:         nodeDefinition.Sync,
+			Protocol:     string(nodeDefinition.Protocol),
+			Multicast:    nodeDefinition.Multicast,
+			Priority:     int32(nodeDefinition.Priority),
+			SyncUniverse: int32(nodeDefinition.SyncUniverse),
+			SourceName:   nodeDefinition.SourceName,
+		})
+	}
+	return &grpcSetting.ControllerSettingsResponse{
+		Status: statusFromError(nil),
+		Settings: &grpcSetting.ControllerSettings{
+			LocalAddress:    settings.LocalAddress,
+			NodeDefinitions: nodeDefinitions,
+		},
+	}, nil
+}
+
+func (s *Server) SetControllerSettings(_ context.Context, req *grpcSetting.SetControllerSettingsRequest) (*grpcCommon.DefaultResponse, error) {
+	if req.Settings == nil {
+		return defaultResponse(fmt.Errorf("%w: Settings are required", domain.ErrInvalidSettings)), nil
+	}
+	nodeDefinitions := make(types.NodeDefinitions, 0, len(req.Settings.NodeDefinitions))
+	for _, nodeDefinition := range req.Settings.NodeDefinitions {
+		universes := make([]int, 0, len(nodeDefinition.Universes))
+		for _, universe := range nodeDefinition.Universes {
+			universes = append(universes, int(universe))
+		}
+		nodeDefinitions = append(nodeDefinitions, types.NodeDefinition{
+			Address:      nodeDefinition.Address,
+			Universes:    universes,
+			Port:         int(nodeDefinition.Port),
+			Sync:         nodeDefinition.Sync,
+			Protocol:     types.NodeProtocol(nodeDefinition.Protocol),
+			Multicast:    nodeDefinition.Multicast,
+			Priority:     int(nodeDefinition.Priority),
+			SyncUniverse: int(nodeDefinition.SyncUniverse),
+			SourceName:   nodeDefinition.SourceName,
+		})
+	}
+	err := s.bus.SetControllerSettings(nodeDefinitions, req.Settings.LocalAddress)
+	return defaultResponse(err), nil
+}
+
+func (s *Server) GetRenderSettings(_ context.Context, _ *grpcCommon.EmptyRequest) (*grpcSetting.RenderSettingsResponse, error) {
+	settings, err := s.bus.FetchRenderSettings()
+	if err != nil {
+		return &grpcSetting.RenderSettingsResponse{Status: statusFromError(err)}, nil
+	}
+	return &grpcSetting.RenderSettingsResponse{
+		Status: statusFromError(nil),
+		Settings: &grpcSetting.RenderSettings{
+			Gamma: settings.Gamma,
+			WhiteBalance: &grpcSetting.WhiteBalance{
+				R: settings.WhiteBalance.R,
+				G: settings.WhiteBalance.G,
+				B: settings.WhiteBalance.B,
+			},
+			Dithering:   settings.Dithering,
+			Brightness:  settings.Brightness,
+			PowerBudget: newPowerBudget(settings.PowerBudget),
+		},
+	}, nil
+}
+
+func (s *Server) SetRenderSettings(_ context.Context, req *grpcSetting.SetRenderSettingsRequest) (*grpcCommon.DefaultResponse, error) {
+	if req.Settings == nil || req.Settings.WhiteBalance == nil {
+		return defaultResponse(fmt.Errorf("%w: Settings and WhiteBalance are required", domain.ErrInvalidSettings)), nil
+	}
+	err := s.bus.SetRenderSettings(&domain.RenderSettings{
+		Gamma: req.Settings.Gamma,
+		WhiteBalance: types.WhiteBalance{
+			R: req.Settings.WhiteBalance.R,
+			G: req.Settings.WhiteBalance.G,
+			B: req.Settings.WhiteBalance.B,
+		},
+		Dithering:   req.Settings.Dithering,
+		Brightness:  req.Settings.Brightness,
+		PowerBudget: toPowerBudget(req.Settings.PowerBudget),
+	})
+	return defaultResponse(err), nil
+}
+
+func newPowerBudget(budget types.PowerBudget) *grpcSetting.PowerBudget {
+	segments := make([]*grpcSetting.PowerSegment, 0, len(budget.Segments))
+	for _, segment := range budget.Segments {
+		segments = append(segments, &grpcSetting.PowerSegment{
+			Universe:     int32(segment.Universe),
+			FirstPixel:   int32(segment.FirstPixel),
+			PixelCount:   int32(segment.PixelCount),
+			MaxMilliamps: segment.MaxMilliamps,
+		})
+	}
+	return &grpcSetting.PowerBudget{
+		MilliampsPerChannel: budget.MilliampsPerChannel,
+		Segments:            segments,
+	}
+}
+
+// toPowerBudget treats a missing budget as no limiting, same as the rest api
+func toPowerBudget(budget *grpcSetting.PowerBudget) types.PowerBudget {
+	if budget == nil {
+		return types.PowerBudget{}
+	}
+	segments := make([]types.PowerSegment, 0, len(budget.Segments))
+	for _, segment := range budget.Segments {
+		segments = append(segments, types.PowerSegment{
+			Universe:     int(segment.Universe),
+			FirstPixel:   int(segment.FirstPixel),
+			PixelCount:   int(segment.PixelCount),
+			MaxMilliamps: segment.MaxMilliamps,
+		})
+	}
+	return types.PowerBudget{
+		MilliampsPerChannel: budget.MilliampsPerChannel,
+		Segments:            segments,
+	}
+}
+
+func (s *Server) ResetApplication(_ context.Context, _ *grpcCommon.EmptyRequest) (*grpcCommon.DefaultResponse, error) {
+	err := s.bus.ResetApplication()
+	return defaultResponse(err), nil
+}
+
+func (s *Server) GetStatus(_ context.Context, _ *grpcCommon.EmptyRequest) (*grpcSetting.StatusResponse, error) {
+	status, err := s.bus.FetchStatus()
+	if err != nil {
+		return &grpcSetting.StatusResponse{Status: statusFromError(err)}, nil
+	}
+	var lastFrameUnixMs int64
+	if !status.LastFrame.IsZero() {
+		lastFrameUnixMs = status.LastFrame.UnixMilli()
+	}
+	powerSegments := make([]*grpcSetting.PowerSegmentStatus, 0, len(status.PowerSegments))
+	for _, segment := range status.PowerSegments {
+		powerSegments = append(powerSegments, &grpcSetting.PowerSegmentStatus{
+			Universe:           int32(segment.Universe),
+			FirstPixel:         int32(segment.FirstPixel),
+			EstimatedMilliamps: segment.EstimatedMilliamps,
+			MaxMilliamps:       segment.MaxMilliamps,
+			Scale:              segment.Scale,
+		})
+	}
+	return &grpcSetting.StatusResponse{
+		Status:          statusFromError(nil),
+		GraphicsRunning: status.GraphicsRunning,
+		RunningShader:   status.RunningShader,
+		FramesRendered:  status.FramesRendered,
+		GraphicsCrashes: status.GraphicsCrashes,
+		LastFrameUnixMs: lastFrameUnixMs,
+		PowerSegments:   powerSegments,
+		ShowOn:          status.ShowOn,
+	}, nil
+}
@@ -0,0 +1,336 @@
+package grpcApi
+
+import (
+	"context"
+	"fmt"
+	grpcCommon "github.com/polis-interactive/2023-CosmicMurmur/api/v1/go/common"
+	grpcSetting "github.com/polis-interactive/2023-CosmicMurmur/api/v1/go/setting"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/domain"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/types"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type testBus struct {
+	graphicsSettings   *domain.GraphicsSettings
+	lightingSettings   *domain.LightingSettings
+	controllerSettings *domain.ControllerSettings
+	renderSettings     *domain.RenderSettings
+	status             *domain.ApplicationStatus
+	resets             int
+	err                error
+}
+
+func (b *testBus) FetchGraphicsSettings() (*domain.GraphicsSettings, error) {
+	return b.graphicsSettings, b.err
+}
+
+func (b *testBus) SetGraphicsSettings(shaderName string, refreshInMs int64, reloadOnUpdate bool, transition *types.Transition) error {
+	if b.err != nil {
+		return b.err
+	}
+	for _, shader := range b.graphicsSettings.Shaders {
+		if shader == shaderName {
+			b.graphicsSettings.RunningShader = shaderName
+			b.graphicsSettings.Frequency = time.Duration(refreshInMs) * time.Millisecond
+			b.graphicsSettings.ReloadOnUpdate = reloadOnUpdate
+			if transition != nil {
+				b.graphicsSettings.Transition = *transition
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: Shader %s", domain.ErrNotFound, shaderName)
+}
+
+func (b *testBus) FetchLightingSettings() (*domain.LightingSettings, error) {
+	return b.lightingSettings, b.err
+}
+
+func (b *testBus) SetLightingSettings(segmentDefinition types.LedSegment, segmentCount int) error {
+	if b.err != nil {
+		return b.err
+	}
+	b.lightingSettings = &domain.LightingSettings{SegmentDefinition: segmentDefinition, SegmentCount: segmentCount}
+	return nil
+}
+
+func (b *testBus) FetchControllerSettings() (*domain.ControllerSettings, error) {
+	return b.controllerSettings, b.err
+}
+
+func (b *testBus) SetControllerSettings(nodeDefinitions types.NodeDefinitions, localAddress string) error {
+	if b.err != nil {
+		return b.err
+	}
+	b.controllerSettings = &domain.ControllerSettings{NodeDefinitions: nodeDefinitions, LocalAddress: localAddress}
+	return nil
+}
+
+func (b *testBus) FetchRenderSettings() (*domain.RenderSettings, error) {
+	return b.renderSettings, b.err
+}
+
+func (b *testBus) SetRenderSettings(settings *domain.RenderSettings) error {
+	if b.err != nil {
+		return b.err
+	}
+	b.renderSettings = settings
+	return nil
+}
+
+func (b *testBus) ResetApplication() error {
+	if b.err == nil {
+		b.resets += 1
+	}
+	return b.err
+}
+
+func (b *testBus) FetchStatus() (*domain.ApplicationStatus, error) {
+	return b.status, b.err
+}
+
+func newTestServer() (*Server, *testBus) {
+	b := &testBus{
+		graphicsSettings: &domain.GraphicsSettings{
+			Shaders:       []string{"basic", "snake_wipe"},
+			RunningShader: "basic",
+			Frequency:     33 * time.Millisecond,
+		},
+		lightingSettings:   &domain.LightingSettings{},
+		controllerSettings: &domain.ControllerSettings{},
+		renderSettings:     &domain.RenderSettings{},
+		status:             &domain.ApplicationStatus{},
+	}
+	return &Server{bus: b}, b
+}
+
+var empty = &grpcCommon.EmptyRequest{}
+
+func TestServer_graphicsSettings(t *testing.T) {
+	s, _ := newTestServer()
+	ctx := context.Background()
+
+	resp, _ := s.SetGraphicsSettings(ctx, &grpcSetting.SetGraphicsSettingsRequest{
+		ShaderName: "snake_wipe", RefreshInMs: 50, ReloadOnUpdate: true,
+		Transition: &grpcSetting.Transition{DurationInMs: 1500, Easing: "ease_in_out", MaskShader: "basic"},
+	})
+	if !resp.Status.Success {
+		t.Fatalf("set failed; %s", resp.Status.Error)
+	}
+	got, _ := s.GetGraphicsSettings(ctx, empty)
+	settings := got.Settings
+	if !got.Status.Success || settings.RunningShader != "snake_wipe" || settings.RefreshInMs != 50 ||
+		!settings.ReloadOnUpdate || settings.Transition.DurationInMs != 1500 ||
+		settings.Transition.Easing != "ease_in_out" || settings.Transition.MaskShader != "basic" {
+		t.Errorf("round trip = %v", settings)
+	}
+
+	// without a transition, the one already set is kept
+	resp, _ = s.SetGraphicsSettings(ctx, &grpcSetting.SetGraphicsSettingsRequest{ShaderName: "basic", RefreshInMs: 33})
+	got, _ = s.GetGraphicsSettings(ctx, empty)
+	if !resp.Status.Success || got.Settings.RunningShader != "basic" || got.Settings.Transition.DurationInMs != 1500 {
+		t.Errorf("set without transition = %v", got.Settings)
+	}
+
+	resp, _ = s.SetGraphicsSettings(ctx, &grpcSetting.SetGraphicsSettingsRequest{})
+	if resp.Status.Success || resp.Status.Error == "" {
+		t.Errorf("set without a shader name succeeded")
+	}
+	resp, _ = s.SetGraphicsSettings(ctx, &grpcSetting.SetGraphicsSettingsRequest{ShaderName: "missing"})
+	if resp.Status.Success {
+		t.Errorf("set with an unknown shader succeeded")
+	}
+}
+
+func TestServer_lightingSettings(t *testing.T) {
+	s, b := newTestServer()
+	ctx := context.Background()
+
+	resp, _ := s.SetLightingSettings(ctx, &grpcSetting.SetLightingSettingsRequest{
+		Settings: &grpcSetting.LightingSettings{
+			SegmentDefinition: []*grpcSetting.LedUniverse{
+				{Strings: []*grpcSetting.LedString{{LedCount: 84, StringCount: 2, PixelFormat: "GRB"}}},
+				{Strings: []*grpcSetting.LedString{{LedCount: 42, StringCount: 1, PixelFormat: "RGB16"}}},
+			},
+			SegmentCount: 3,
+		},
+	})
+	if !resp.Status.Success {
+		t.Fatalf("set failed; %s", resp.Status.Error)
+	}
+	expected := types.LedSegment{
+		{{LedCount: 84, StringCount: 2, PixelFormat: types.PixelFormatGRB}},
+		{{LedCount: 42, StringCount: 1, PixelFormat: types.PixelFormatRGB16}},
+	}
+	if !reflect.DeepEqual(b.lightingSettings.SegmentDefinition, expected) || b.lightingSettings.SegmentCount != 3 {
+		t.Errorf("bus got %v", b.lightingSettings)
+	}
+	got, _ := s.GetLightingSettings(ctx, empty)
+	definition := got.Settings.SegmentDefinition
+	if len(definition) != 2 || definition[1].Strings[0].PixelFormat != "RGB16" ||
+		definition[0].Strings[0].LedCount != 84 || got.Settings.SegmentCount != 3 {
+		t.Errorf("round trip = %v", got.Settings)
+	}
+
+	resp, _ = s.SetLightingSettings(ctx, &grpcSetting.SetLightingSettingsRequest{})
+	if resp.Status.Success {
+		t.Errorf("set without settings succeeded")
+	}
+}
+
+func TestServer_controllerSettings(t *testing.T) {
+	s, b := newTestServer()
+	ctx := context.Background()
+
+	resp, _ := s.SetControllerSettings(ctx, &grpcSetting.SetControllerSettingsRequest{
+		Settings: &grpcSetting.ControllerSettings{
+			LocalAddress: "10.0.0.1",
+			NodeDefinitions: []*grpcSetting.NodeDefinition{
+				{Address: "10.0.0.10", Universes: []int32{0, 1}, Port: 6455, Sync: true},
+				{
+					Address: "10.0.0.11", Universes: []int32{2}, Protocol: "sacn", Multicast: true, Priority: 150,
+					SyncUniverse: 7, SourceName: "murmur",
+				},
+			},
+		},
+	})
+	if !resp.Status.Success {
+		t.Fatalf("set failed; %s", resp.Status.Error)
+	}
+	expected := types.NodeDefinitions{
+		{Address: "10.0.0.10", Universes: []int{0, 1}, Port: 6455, Sync: true},
+		{
+			Address: "10.0.0.11", Universes: []int{2}, Protocol: types.ProtocolSacn, Multicast: true, Priority: 150,
+			SyncUniverse: 7, SourceName: "murmur",
+		},
+	}
+	if !reflect.DeepEqual(b.controllerSettings.NodeDefinitions, expected) ||
+		b.controllerSettings.LocalAddress != "10.0.0.1" {
+		t.Errorf("bus got %v", b.controllerSettings)
+	}
+	got, _ := s.GetControllerSettings(ctx, empty)
+	nodes := got.Settings.NodeDefinitions
+	if len(nodes) != 2 || !nodes[0].Sync || nodes[0].Port != 6455 || len(nodes[0].Universes) != 2 ||
+		nodes[1].Protocol != "sacn" || nodes[1].Priority != 150 || nodes[1].SourceName != "murmur" ||
+		got.Settings.LocalAddress != "10.0.0.1" {
+		t.Errorf("round trip = %v", got.Settings)
+	}
+
+	resp, _ = s.SetControllerSettings(ctx, &grpcSetting.SetControllerSettingsRequest{})
+	if resp.Status.Success {
+		t.Errorf("set without settings succeeded")
+	}
+}
+
+func TestServer_renderSettings(t *testing.T) {
+	s, b := newTestServer()
+	ctx := context.Background()
+
+	resp, _ := s.SetRenderSettings(ctx, &grpcSetting.SetRenderSettingsRequest{
+		Settings: &grpcSetting.RenderSettings{
+			Gamma: 2.2, WhiteBalance: &grpcSetting.WhiteBalance{R: 1, G: 0.9, B: 0.8}, Dithering: true, Brightness: 0.5,
+			PowerBudget: &grpcSetting.PowerBudget{
+				MilliampsPerChannel: 20,
+				Segments:            []*grpcSetting.PowerSegment{{Universe: 1, FirstPixel: 4, PixelCount: 80, MaxMilliamps: 2000}},
+			},
+		},
+	})
+	if !resp.Status.Success {
+		t.Fatalf("set failed; %s", resp.Status.Error)
+	}
+	expected := &domain.RenderSettings{
+		Gamma: 2.2, WhiteBalance: types.WhiteBalance{R: 1, G: 0.9, B: 0.8}, Dithering: true, Brightness: 0.5,
+		PowerBudget: types.PowerBudget{
+			MilliampsPerChannel: 20,
+			Segments:            []types.PowerSegment{{Universe: 1, FirstPixel: 4, PixelCount: 80, MaxMilliamps: 2000}},
+		},
+	}
+	if !reflect.DeepEqual(b.renderSettings, expected) {
+		t.Errorf("bus got %v", b.renderSettings)
+	}
+	got, _ := s.GetRenderSettings(ctx, empty)
+	settings := got.Settings
+	if settings.Gamma != 2.2 || settings.WhiteBalance.B != 0.8 || !settings.Dithering || settings.Brightness != 0.5 ||
+		settings.PowerBudget.MilliampsPerChannel != 20 || len(settings.PowerBudget.Segments) != 1 ||
+		settings.PowerBudget.Segments[0].FirstPixel != 4 {
+		t.Errorf("round trip = %v", settings)
+	}
+
+	// a missing budget turns limiting off
+	resp, _ = s.SetRenderSettings(ctx, &grpcSetting.SetRenderSettingsRequest{
+		Settings: &grpcSetting.RenderSettings{
+			Gamma: 1, WhiteBalance: &grpcSetting.WhiteBalance{R: 1, G: 1, B: 1}, Brightness: 1,
+		},
+	})
+	if !resp.Status.Success || b.renderSettings.PowerBudget.MilliampsPerChannel != 0 ||
+		len(b.renderSettings.PowerBudget.Segments) != 0 {
+		t.Errorf("set without a budget = %v", b.renderSettings)
+	}
+
+	for name, req := range map[string]*grpcSetting.SetRenderSettingsRequest{
+		"no settings":      {},
+		"no white balance": {Settings: &grpcSetting.RenderSettings{Gamma: 1, Brightness: 1}},
+	} {
+		resp, _ = s.SetRenderSettings(ctx, req)
+		if resp.Status.Success {
+			t.Errorf("%s: set succeeded", name)
+		}
+	}
+}
+
+func TestServer_busErrors(t *testing.T) {
+	s, b := newTestServer()
+	ctx := context.Background()
+	b.err = fmt.Errorf("%w: eventloop not responding", domain.ErrUnavailable)
+
+	graphics, _ := s.GetGraphicsSettings(ctx, empty)
+	lighting, _ := s.GetLightingSettings(ctx, empty)
+	controller, _ := s.GetControllerSettings(ctx, empty)
+	render, _ := s.GetRenderSettings(ctx, empty)
+	status, _ := s.GetStatus(ctx, empty)
+	reset, _ := s.ResetApplication(ctx, empty)
+	for name, st := range map[string]*grpcCommon.SuccessFailure{
+		"graphics": graphics.Status, "lighting": lighting.Status, "controller": controller.Status,
+		"render": render.Status, "status": status.Status, "reset": reset.Status,
+	} {
+		if st.Success || st.Error != b.err.Error() {
+			t.Errorf("%s: status = %v", name, st)
+		}
+	}
+	if graphics.Settings != nil || render.Settings != nil || b.resets != 0 {
+		t.Errorf("failed calls returned settings")
+	}
+
+	b.err = nil
+	reset, _ = s.ResetApplication(ctx, empty)
+	if !reset.Status.Success || b.resets != 1 {
+		t.Errorf("reset status = %v", reset.Status)
+	}
+}
+
+func TestServer_status(t *testing.T) {
+	s, b := newTestServer()
+	ctx := context.Background()
+
+	got, _ := s.GetStatus(ctx, empty)
+	if !got.Status.Success || got.LastFrameUnixMs != 0 || len(got.PowerSegments) != 0 {
+		t.Errorf("status without a frame = %v", got)
+	}
+
+	lastFrame := time.UnixMilli(1700000000000)
+	b.status = &domain.ApplicationStatus{
+		GraphicsRunning: true, RunningShader: "basic", FramesRendered: 12, GraphicsCrashes: 1, LastFrame: lastFrame,
+		PowerSegments: []domain.PowerSegmentStatus{
+			{Universe: 1, FirstPixel: 4, EstimatedMilliamps: 1500, MaxMilliamps: 2000, Scale: 1},
+		},
+		ShowOn: true,
+	}
+	got, _ = s.GetStatus(ctx, empty)
+	if !got.GraphicsRunning || got.RunningShader != "basic" || got.FramesRendered != 12 || got.GraphicsCrashes != 1 ||
+		got.LastFrameUnixMs != lastFrame.UnixMilli() || len(got.PowerSegments) != 1 ||
+		got.PowerSegments[0].EstimatedMilliamps != 1500 || !got.ShowOn {
+		t.Errorf("status = %v", got)
+	}
+}
@@ -0,0 +1,275 @@
+package receiver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/jsimonetti/go-artnet"
+	"github.com/jsimonetti/go-artnet/packet"
+	"io"
+	"log"
+	"net"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	pollReplyShortName = "artnetReceiver"
+	pollReplyLongName  = "cosmic murmur art-net receiver"
+	maxPacketSize      = 1024
+	rateWindow         = 1 * time.Second
+	// a read error that keeps coming back mustn't spin; the same backoff as the controller's reply loops
+	minReadBackoff = 10 * time.Millisecond
+	maxReadBackoff = 1 * time.Second
+)
+
+type UniverseStats struct {
+	Universe        int
+	Packets         uint64
+	SequenceErrors  uint64
+	LastSequence    uint8
+	FramesPerSecond float64
+	LastPacket      time.Time
+	Length          int
+	Data            [512]byte
+}
+
+type universeState struct {
+	stats         UniverseStats
+	windowStart   time.Time
+	windowPackets uint64
+}
+
+type captureRecord struct {
+	TimeUnixNano int64  `json:"timeUnixNano"`
+	Universe     int    `json:"universe"`
+	Sequence     uint8  `json:"sequence"`
+	Data         []byte `json:"data"`
+}
+
+// Receiver listens for art-net the way a node would, so controller output can be checked without hardware
+type Receiver struct {
+	conn *net.UDPConn
+
+	mu             *sync.RWMutex
+	universes      map[int]*universeState
+	syncs          uint64
+	polls          uint64
+	invalidPackets uint64
+
+	capture    *json.Encoder
+	captureErr error
+
+	shutdowns chan struct{}
+	wg        *sync.WaitGroup
+}
+
+// NewReceiver binds address immediately so a ":0" port can be read back with Addr before Startup; capture,
+// when not nil, gets one json line per dmx packet
+func NewReceiver(address string, capture io.Writer) (*Receiver, error) {
+	udpAddr, err := net.ResolveUDPAddr("udp", address)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := net.ListenUDP("udp", udpAddr)
+	if err != nil {
+		return nil, err
+	}
+	r := &Receiver{
+		conn:      conn,
+		mu:        &sync.RWMutex{},
+		universes: make(map[int]*universeState),
+		shutdowns: nil,
+		wg:        &sync.WaitGroup{},
+	}
+	if capture != nil {
+		r.capture = json.NewEncoder(capture)
+	}
+	return r, nil
+}
+
+func (r *Receiver) Addr() *net.UDPAddr {
+	return r.conn.LocalAddr().(*net.UDPAddr)
+}
+
+func (r *Receiver) Startup() {
+	if r.shutdowns == nil {
+		r.shutdowns = make(chan struct{})
+		r.wg.Add(1)
+		go r.runMainLoop()
+	}
+}
+
+func (r *Receiver) Shutdown() {
+	if r.shutdowns != nil {
+		close(r.shutdowns)
+		// unblock the pending read
+		_ = r.conn.Close()
+		r.wg.Wait()
+		r.shutdowns = nil
+	}
+}
+
+func (r *Receiver) runMainLoop() {
+	defer func() {
+		log.Println("Receiver, runMainLoop, Main Loop: closed")
+		r.wg.Done()
+	}()
+	buf := make([]byte, maxPacketSize)
+	var backoff time.Duration
+	for {
+		n, from, err := r.conn.ReadFromUDP(buf)
+		if err != nil {
+			select {
+			case <-r.shutdowns:
+				return
+			default:
+			}
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			// only the first error of a run is logged; the delay doubles until a read succeeds
+			if backoff == 0 {
+				log.Println(fmt.Sprintf("Receiver, runMainLoop: read failed; %s", err.Error()))
+				backoff = minReadBackoff
+			} else if backoff *= 2; backoff > maxReadBackoff {
+				backoff = maxReadBackoff
+			}
+			select {
+			case <-r.shutdowns:
+				return
+			case <-time.After(backoff):
+			}
+			continue
+		}
+		backoff = 0
+		reply := r.handlePacket(buf[:n], time.Now())
+		if reply != nil {
+			_, err = r.conn.WriteToUDP(reply, from)
+			if err != nil {
+				log.Println(fmt.Sprintf("Receiver, runMainLoop: couldn't reply to poll; %s", err.Error()))
+			}
+		}
+	}
+}
+
+// handlePacket returns the bytes to answer the sender with, if any
+func (r *Receiver) handlePacket(b []byte, now time.Time) []byte {
+	p, err := packet.Unmarshal(b)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err != nil {
+		r.invalidPackets += 1
+		return nil
+	}
+	switch p := p.(type) {
+	case *packet.ArtDMXPacket:
+		r.handleDmx(p, now)
+	case *packet.ArtSyncPacket:
+		r.syncs += 1
+	case *packet.ArtPollPacket:
+		r.polls += 1
+		return r.pollReply()
+	}
+	return nil
+}
+
+// pollReply answers like a node would, so the controller sees the receiver as online
+func (r *Receiver) pollReply() []byte {
+	reply := artnet.ArtPollReplyFromConfig(artnet.NodeConfig{
+		Name:        pollReplyShortName,
+		Description: pollReplyLongName,
+		IP:          r.Addr().IP,
+	})
+	b, err := reply.MarshalBinary()
+	if err != nil {
+		log.Println(fmt.Sprintf("Receiver, pollReply: couldn't marshal reply; %s", err.Error()))
+		return nil
+	}
+	return b
+}
+
+func (r *Receiver) handleDmx(p *packet.ArtDMXPacket, now time.Time) {
+	universe := int(p.Net)<<8 | int(p.SubUni)
+	u, ok := r.universes[universe]
+	if !ok {
+		u = &universeState{windowStart: now}
+		u.stats.Universe = universe
+		r.universes[universe] = u
+	}
+	// a sequence of 0 means the sender isn't sequencing; otherwise it runs 1 to 255 and wraps back to 1
+	if p.Sequence != 0 && u.stats.LastSequence != 0 {
+		expected := u.stats.LastSequence + 1
+		if expected == 0 {
+			expected = 1
+		}
+		if p.Sequence != expected {
+			u.stats.SequenceErrors += 1
+		}
+	}
+	u.stats.LastSequence = p.Sequence
+	u.stats.Packets += 1
+	u.stats.LastPacket = now
+	u.stats.Length = int(p.Length)
+	u.stats.Data = p.Data
+
+	u.windowPackets += 1
+	if elapsed := now.Sub(u.windowStart); elapsed >= rateWindow {
+		u.stats.FramesPerSecond = float64(u.windowPackets) / elapsed.Seconds()
+		u.windowStart = now
+		u.windowPackets = 0
+	}
+
+	if r.capture != nil && r.captureErr == nil {
+		r.captureErr = r.capture.Encode(&captureRecord{
+			TimeUnixNano: now.UnixNano(),
+			Universe:     universe,
+			Sequence:     p.Sequence,
+			Data:         p.Data[:p.Length],
+		})
+		if r.captureErr != nil {
+			log.Println(fmt.Sprintf("Receiver, handleDmx: capture stopped; %s", r.captureErr.Error()))
+		}
+	}
+}
+
+func (r *Receiver) GetUniverse(universe int) (UniverseStats, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if u, ok := r.universes[universe]; ok {
+		return u.stats, true
+	}
+	return UniverseStats{}, false
+}
+
+func (r *Receiver) GetUniverses() []UniverseStats {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	stats := make([]UniverseStats, 0, len(r.universes))
+	for _, u := range r.universes {
+		stats = append(stats, u.stats)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Universe < stats[j].Universe
+	})
+	return stats
+}
+
+func (r *Receiver) GetSyncCount() uint64 {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.syncs
+}
+
+func (r *Receiver) GetPollCount() uint64 {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.polls
+}
+
+func (r *Receiver) GetInvalidPacketCount() uint64 {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.invalidPackets
+}
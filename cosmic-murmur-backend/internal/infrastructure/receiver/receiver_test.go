@@ -0,0 +1,86 @@
+package receiver
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/jsimonetti/go-artnet/packet"
+	"net"
+	"testing"
+	"time"
+)
+
+func sendDmx(t *testing.T, conn net.Conn, universe int, sequence uint8, value byte) {
+	p := &packet.ArtDMXPacket{
+		Sequence: sequence,
+		SubUni:   uint8(universe & 0xFF),
+		Net:      uint8(universe >> 8 & 0xFF),
+	}
+	p.Data[0] = value
+	b, err := p.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = conn.Write(b); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func waitForPackets(t *testing.T, r *Receiver, universe int, packets uint64) UniverseStats {
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if stats, ok := r.GetUniverse(universe); ok && stats.Packets >= packets {
+			return stats
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("universe %d didn't receive %d packets", universe, packets)
+	return UniverseStats{}
+}
+
+func TestReceiver_tracksUniverses(t *testing.T) {
+	var capture bytes.Buffer
+	r, err := NewReceiver("127.0.0.1:0", &capture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Startup()
+	defer r.Shutdown()
+
+	conn, err := net.Dial("udp", r.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	// 3 is skipped, so one sequence error
+	for _, sequence := range []uint8{1, 2, 4} {
+		sendDmx(t, conn, 0x101, sequence, sequence*10)
+		waitForPackets(t, r, 0x101, 1)
+	}
+	stats := waitForPackets(t, r, 0x101, 3)
+	if stats.SequenceErrors != 1 || stats.LastSequence != 4 || stats.Data[0] != 40 {
+		t.Errorf("stats = %+v", stats)
+	}
+
+	// the sequence wraps from 255 back to 1
+	sendDmx(t, conn, 2, 255, 0)
+	waitForPackets(t, r, 2, 1)
+	sendDmx(t, conn, 2, 1, 0)
+	if stats = waitForPackets(t, r, 2, 2); stats.SequenceErrors != 0 {
+		t.Errorf("wrapped sequence counted as an error; stats = %+v", stats)
+	}
+
+	if universes := r.GetUniverses(); len(universes) != 2 || universes[0].Universe != 2 {
+		t.Errorf("universes = %v", universes)
+	}
+
+	r.Shutdown()
+	decoder := json.NewDecoder(&capture)
+	var record captureRecord
+	if err = decoder.Decode(&record); err != nil {
+		t.Fatal(err)
+	}
+	if record.Universe != 0x101 || record.Sequence != 1 || len(record.Data) != 512 || record.Data[0] != 10 {
+		t.Errorf("first capture record = %+v", record)
+	}
+}
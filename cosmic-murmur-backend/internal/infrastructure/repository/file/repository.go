@@ -0,0 +1,643 @@
+package file
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/types"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+/*
+	the file repository keeps every setting in a single json document; writes go to a temp file that is
+	synced and renamed over the original, so there's always a complete document at the path. The previous
+	document is hard linked (or copied) to a backup first, so a corrupted document can fall back to the last
+	good state; a temp file left by a crash before its rename is newer than the backup and tried first
+*/
+
+const schemaVersion = 1
+
+type lightingDocument struct {
+	SegmentDefinition *types.LedSegment `json:"segmentDefinition,omitempty"`
+	SegmentCount      *int              `json:"segmentCount,omitempty"`
+}
+
+type graphicsDocument struct {
+	ReloadOnUpdate *bool                         `json:"reloadOnUpdate,omitempty"`
+	ShaderName     *string                       `json:"shaderName,omitempty"`
+	Frequency      *time.Duration                `json:"frequency,omitempty"`
+	Transition     *types.Transition             `json:"transition,omitempty"`
+	Uniforms       map[string]map[string]float32 `json:"uniforms,omitempty"`
+	Playlists      *[]types.Playlist             `json:"playlists,omitempty"`
+	ActivePlaylist *string                       `json:"activePlaylist,omitempty"`
+}
+
+type controllerDocument struct {
+	LocalAddress    *string               `json:"localAddress,omitempty"`
+	NodeDefinitions types.NodeDefinitions `json:"nodeDefinitions,omitempty"`
+}
+
+type renderDocument struct {
+	Gamma        *float64            `json:"gamma,omitempty"`
+	WhiteBalance *types.WhiteBalance `json:"whiteBalance,omitempty"`
+	Dithering    *bool               `json:"dithering,omitempty"`
+	Brightness   *float64            `json:"brightness,omitempty"`
+	PowerBudget  *types.PowerBudget  `json:"powerBudget,omitempty"`
+}
+
+type scheduleDocument struct {
+	Enabled  *bool                   `json:"enabled,omitempty"`
+	Location *types.GeoLocation      `json:"location,omitempty"`
+	Windows  *[]types.ScheduleWindow `json:"windows,omitempty"`
+	Playlist *[]types.PlaylistEntry  `json:"playlist,omitempty"`
+}
+
+type document struct {
+	SchemaVersion int                `json:"schemaVersion"`
+	Lighting      lightingDocument   `json:"lighting"`
+	Graphics      graphicsDocument   `json:"graphics"`
+	Controller    controllerDocument `json:"controller"`
+	Render        renderDocument     `json:"render"`
+	Schedule      scheduleDocument   `json:"schedule"`
+}
+
+var errUnsupportedVersion = errors.New("unsupported schema version")
+
+// migrations upgrade a raw document from the keyed version to the next one
+var migrations = map[int]func(raw map[string]interface{}) error{}
+
+type Repository struct {
+	path string
+	doc  *document
+	mu   *sync.RWMutex
+}
+
+func NewFileRepository(path string) (*Repository, error) {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return nil, err
+	}
+	r := &Repository{
+		path: path,
+		mu:   &sync.RWMutex{},
+	}
+	doc, err := r.recoverDocument()
+	if err != nil {
+		return nil, err
+	}
+	r.doc = doc
+	return r, nil
+}
+
+func (r *Repository) backupPath() string {
+	return r.path + ".bak"
+}
+
+func (r *Repository) tempPath() string {
+	return r.path + ".tmp"
+}
+
+func (r *Repository) recoverDocument() (*document, error) {
+	var lastErr error
+	for _, p := range []string{r.path, r.tempPath(), r.backupPath()} {
+		doc, err := readDocument(p)
+		if err == nil {
+			if p != r.path {
+				log.Println(fmt.Sprintf("FileRepository, recoverDocument: recovered settings from %s", p))
+				// keep the unreadable document around for inspection rather than rotating it into the backup
+				if _, err = os.Stat(r.path); err == nil {
+					_ = os.Rename(r.path, r.path+".corrupt")
+				}
+				err = r.writeDocument(doc)
+				if err != nil {
+					return nil, err
+				}
+			}
+			return doc, nil
+		} else if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if errors.Is(err, errUnsupportedVersion) {
+			// refuse to clobber settings written by a newer program
+			return nil, err
+		}
+		log.Println(fmt.Sprintf("FileRepository, recoverDocument: couldn't read %s; %s", p, err.Error()))
+		lastErr = err
+	}
+	if lastErr != nil {
+		log.Println("FileRepository, recoverDocument: no readable settings found, starting fresh")
+	}
+	return newDocument(), nil
+}
+
+func newDocument() *document {
+	return &document{
+		SchemaVersion: schemaVersion,
+	}
+}
+
+func readDocument(path string) (*document, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	raw := make(map[string]interface{})
+	err = json.Unmarshal(b, &raw)
+	if err != nil {
+		return nil, err
+	}
+	version, ok := raw["schemaVersion"].(float64)
+	if !ok {
+		return nil, errors.New("document missing schema version")
+	}
+	if int(version) > schemaVersion {
+		return nil, fmt.Errorf("%w %d, expected at most %d", errUnsupportedVersion, int(version), schemaVersion)
+	}
+	for v := int(version); v < schemaVersion; v++ {
+		migrate, ok := migrations[v]
+		if !ok {
+			return nil, errors.New(fmt.Sprintf("no migration from schema version %d", v))
+		}
+		err = migrate(raw)
+		if err != nil {
+			return nil, err
+		}
+		raw["schemaVersion"] = v + 1
+	}
+	b, err = json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	doc := newDocument()
+	err = json.Unmarshal(b, doc)
+	if err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+func (r *Repository) writeDocument(doc *document) error {
+	b, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	tempPath := r.tempPath()
+	f, err := os.OpenFile(tempPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(b)
+	if err == nil {
+		err = f.Sync()
+	}
+	closeErr := f.Close()
+	if err != nil {
+		return err
+	} else if closeErr != nil {
+		return closeErr
+	}
+	if _, err = os.Stat(r.path); err == nil {
+		err = r.backupDocument()
+		if err != nil {
+			return err
+		}
+	}
+	err = os.Rename(tempPath, r.path)
+	if err != nil {
+		return err
+	}
+	return syncDirectory(filepath.Dir(r.path))
+}
+
+// backupDocument keeps the current document as the backup without ever moving it out of the way
+func (r *Repository) backupDocument() error {
+	backupPath := r.backupPath()
+	err := os.Remove(backupPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if os.Link(r.path, backupPath) == nil {
+		return nil
+	}
+	// not every filesystem can hard link
+	b, err := os.ReadFile(r.path)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(backupPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(b)
+	if err == nil {
+		err = f.Sync()
+	}
+	closeErr := f.Close()
+	if err != nil {
+		return err
+	}
+	return closeErr
+}
+
+func syncDirectory(path string) error {
+	d, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	// not every platform supports syncing a directory; the rename itself is still atomic
+	_ = d.Sync()
+	return nil
+}
+
+// update applies fn to a copy of the document and only keeps it once it is safely on disk
+func (r *Repository) update(fn func(doc *document)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	next, err := cloneDocument(r.doc)
+	if err != nil {
+		return err
+	}
+	fn(next)
+	err = r.writeDocument(next)
+	if err != nil {
+		return err
+	}
+	r.doc = next
+	return nil
+}
+
+func cloneDocument(doc *document) (*document, error) {
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	next := newDocument()
+	err = json.Unmarshal(b, next)
+	if err != nil {
+		return nil, err
+	}
+	return next, nil
+}
+
+func (r *Repository) ResetRepository() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	doc := newDocument()
+	err := r.writeDocument(doc)
+	if err != nil {
+		return err
+	}
+	r.doc = doc
+	return nil
+}
+
+func (r *Repository) GetLightingSegmentDefinition() (segment types.LedSegment, ok bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.doc.Lighting.SegmentDefinition != nil {
+		return *r.doc.Lighting.SegmentDefinition, true
+	} else {
+		return types.LedSegment{}, false
+	}
+}
+
+func (r *Repository) SetLightingSegmentDefinition(segment types.LedSegment) error {
+	return r.update(func(doc *document) {
+		doc.Lighting.SegmentDefinition = &segment
+	})
+}
+
+func (r *Repository) GetLightingSegmentCount() (count int, ok bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.doc.Lighting.SegmentCount != nil {
+		return *r.doc.Lighting.SegmentCount, true
+	} else {
+		return -1, false
+	}
+}
+
+func (r *Repository) SetLightingSegmentCount(count int) error {
+	return r.update(func(doc *document) {
+		doc.Lighting.SegmentCount = &count
+	})
+}
+
+func (r *Repository) GetGraphicsReloadOnUpdate() (reloadOnUpdate bool, ok bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.doc.Graphics.ReloadOnUpdate != nil {
+		return *r.doc.Graphics.ReloadOnUpdate, true
+	} else {
+		return false, false
+	}
+}
+
+func (r *Repository) SetGraphicsReloadOnUpdate(reloadOnUpdate bool) error {
+	return r.update(func(doc *document) {
+		doc.Graphics.ReloadOnUpdate = &reloadOnUpdate
+	})
+}
+
+func (r *Repository) GetGraphicsShader() (shaderName string, ok bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.doc.Graphics.ShaderName != nil {
+		return *r.doc.Graphics.ShaderName, true
+	} else {
+		return "", false
+	}
+}
+
+func (r *Repository) SetGraphicsShader(shaderName string) error {
+	return r.update(func(doc *document) {
+		doc.Graphics.ShaderName = &shaderName
+	})
+}
+
+func (r *Repository) GetGraphicsFrequency() (frequency time.Duration, ok bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.doc.Graphics.Frequency != nil {
+		return *r.doc.Graphics.Frequency, true
+	} else {
+		return 0 * time.Millisecond, false
+	}
+}
+
+func (r *Repository) SetGraphicsFrequency(frequency time.Duration) error {
+	return r.update(func(doc *document) {
+		doc.Graphics.Frequency = &frequency
+	})
+}
+
+func (r *Repository) GetGraphicsTransition() (transition types.Transition, ok bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.doc.Graphics.Transition != nil {
+		return *r.doc.Graphics.Transition, true
+	} else {
+		return types.Transition{}, false
+	}
+}
+
+func (r *Repository) SetGraphicsTransition(transition types.Transition) error {
+	return r.update(func(doc *document) {
+		doc.Graphics.Transition = &transition
+	})
+}
+
+func (r *Repository) GetGraphicsUniforms() (values map[string]map[string]float32, ok bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.doc.Graphics.Uniforms != nil {
+		return copyUniformValues(r.doc.Graphics.Uniforms), true
+	} else {
+		return nil, false
+	}
+}
+
+func (r *Repository) SetGraphicsUniforms(values map[string]map[string]float32) error {
+	values = copyUniformValues(values)
+	return r.update(func(doc *document) {
+		doc.Graphics.Uniforms = values
+	})
+}
+
+func copyUniformValues(values map[string]map[string]float32) map[string]map[string]float32 {
+	c := make(map[string]map[string]float32, len(values))
+	for shader, shaderValues := range values {
+		c[shader] = make(map[string]float32, len(shaderValues))
+		for k, v := range shaderValues {
+			c[shader][k] = v
+		}
+	}
+	return c
+}
+
+func (r *Repository) GetGraphicsPlaylists() (playlists []types.Playlist, ok bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.doc.Graphics.Playlists != nil {
+		return append([]types.Playlist(nil), *r.doc.Graphics.Playlists...), true
+	} else {
+		return nil, false
+	}
+}
+
+func (r *Repository) SetGraphicsPlaylists(playlists []types.Playlist) error {
+	playlists = append([]types.Playlist{}, playlists...)
+	return r.update(func(doc *document) {
+		doc.Graphics.Playlists = &playlists
+	})
+}
+
+func (r *Repository) GetGraphicsActivePlaylist() (name string, ok bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.doc.Graphics.ActivePlaylist != nil {
+		return *r.doc.Graphics.ActivePlaylist, true
+	} else {
+		return "", false
+	}
+}
+
+func (r *Repository) SetGraphicsActivePlaylist(name string) error {
+	return r.update(func(doc *document) {
+		doc.Graphics.ActivePlaylist = &name
+	})
+}
+
+func (r *Repository) SetControllerLocalAddress(addr string) error {
+	return r.update(func(doc *document) {
+		doc.Controller.LocalAddress = &addr
+	})
+}
+
+func (r *Repository) GetControllerLocalAddress() (addr string, ok bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.doc.Controller.LocalAddress != nil {
+		return *r.doc.Controller.LocalAddress, true
+	} else {
+		return "", false
+	}
+}
+
+func (r *Repository) SetControllerNodeDefinitions(definitions types.NodeDefinitions) error {
+	return r.update(func(doc *document) {
+		doc.Controller.NodeDefinitions = copyNodeDefinitions(definitions)
+	})
+}
+
+func copyNodeDefinitions(definitions types.NodeDefinitions) types.NodeDefinitions {
+	if definitions == nil {
+		return nil
+	}
+	copied := make(types.NodeDefinitions, len(definitions))
+	for i, d := range definitions {
+		d.Universes = append([]int(nil), d.Universes...)
+		copied[i] = d
+	}
+	return copied
+}
+
+func (r *Repository) GetControllerNodeDefinitions() (definitions types.NodeDefinitions, ok bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.doc.Controller.NodeDefinitions != nil {
+		return copyNodeDefinitions(r.doc.Controller.NodeDefinitions), true
+	} else {
+		return nil, false
+	}
+}
+
+func (r *Repository) GetRenderGamma() (gamma float64, ok bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.doc.Render.Gamma != nil {
+		return *r.doc.Render.Gamma, true
+	} else {
+		return 0, false
+	}
+}
+
+func (r *Repository) SetRenderGamma(gamma float64) error {
+	return r.update(func(doc *document) {
+		doc.Render.Gamma = &gamma
+	})
+}
+
+func (r *Repository) GetRenderWhiteBalance() (whiteBalance types.WhiteBalance, ok bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.doc.Render.WhiteBalance != nil {
+		return *r.doc.Render.WhiteBalance, true
+	} else {
+		return types.WhiteBalance{}, false
+	}
+}
+
+func (r *Repository) SetRenderWhiteBalance(whiteBalance types.WhiteBalance) error {
+	return r.update(func(doc *document) {
+		doc.Render.WhiteBalance = &whiteBalance
+	})
+}
+
+func (r *Repository) GetRenderDithering() (dithering bool, ok bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.doc.Render.Dithering != nil {
+		return *r.doc.Render.Dithering, true
+	} else {
+		return false, false
+	}
+}
+
+func (r *Repository) SetRenderDithering(dithering bool) error {
+	return r.update(func(doc *document) {
+		doc.Render.Dithering = &dithering
+	})
+}
+
+func (r *Repository) GetRenderBrightness() (brightness float64, ok bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.doc.Render.Brightness != nil {
+		return *r.doc.Render.Brightness, true
+	} else {
+		return 0, false
+	}
+}
+
+func (r *Repository) SetRenderBrightness(brightness float64) error {
+	return r.update(func(doc *document) {
+		doc.Render.Brightness = &brightness
+	})
+}
+
+func (r *Repository) GetRenderPowerBudget() (budget types.PowerBudget, ok bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.doc.Render.PowerBudget != nil {
+		return *r.doc.Render.PowerBudget, true
+	} else {
+		return types.PowerBudget{}, false
+	}
+}
+
+func (r *Repository) SetRenderPowerBudget(budget types.PowerBudget) error {
+	return r.update(func(doc *document) {
+		doc.Render.PowerBudget = &budget
+	})
+}
+
+func (r *Repository) GetScheduleEnabled() (enabled bool, ok bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.doc.Schedule.Enabled != nil {
+		return *r.doc.Schedule.Enabled, true
+	} else {
+		return false, false
+	}
+}
+
+func (r *Repository) SetScheduleEnabled(enabled bool) error {
+	return r.update(func(doc *document) {
+		doc.Schedule.Enabled = &enabled
+	})
+}
+
+func (r *Repository) GetScheduleLocation() (location types.GeoLocation, ok bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.doc.Schedule.Location != nil {
+		return *r.doc.Schedule.Location, true
+	} else {
+		return types.GeoLocation{}, false
+	}
+}
+
+func (r *Repository) SetScheduleLocation(location types.GeoLocation) error {
+	return r.update(func(doc *document) {
+		doc.Schedule.Location = &location
+	})
+}
+
+func (r *Repository) GetScheduleWindows() (windows []types.ScheduleWindow, ok bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.doc.Schedule.Windows != nil {
+		return append([]types.ScheduleWindow(nil), *r.doc.Schedule.Windows...), true
+	} else {
+		return nil, false
+	}
+}
+
+func (r *Repository) SetScheduleWindows(windows []types.ScheduleWindow) error {
+	windows = append([]types.ScheduleWindow{}, windows...)
+	return r.update(func(doc *document) {
+		doc.Schedule.Windows = &windows
+	})
+}
+
+func (r *Repository) GetSchedulePlaylist() (playlist []types.PlaylistEntry, ok bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.doc.Schedule.Playlist != nil {
+		return append([]types.PlaylistEntry(nil), *r.doc.Schedule.Playlist...), true
+	} else {
+		return nil, false
+	}
+}
+
+func (r *Repository) SetSchedulePlaylist(playlist []types.PlaylistEntry) error {
+	playlist = append([]types.PlaylistEntry{}, playlist...)
+	return r.update(func(doc *document) {
+		doc.Schedule.Playlist = &playlist
+	})
+}
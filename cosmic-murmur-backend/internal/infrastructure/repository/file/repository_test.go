@@ -0,0 +1,187 @@
+package file
+
+import (
+	"errors"
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/types"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRepository_persistsAcrossInstances(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.json")
+	r, err := NewFileRepository(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := r.GetGraphicsShader(); ok {
+		t.Error("expected no shader in a fresh repository")
+	}
+	segment := types.LedSegment{
+		types.LedUniverse{
+			types.LedString{LedCount: 3, StringCount: 2},
+		},
+	}
+	definitions := types.NodeDefinitions{
+		types.NodeDefinition{Address: "2.0.0.2", Universes: []int{0, 1}},
+	}
+	for _, err = range []error{
+		r.SetGraphicsShader("snake_wipe"),
+		r.SetGraphicsFrequency(20 * time.Millisecond),
+		r.SetGraphicsReloadOnUpdate(false),
+		r.SetLightingSegmentDefinition(segment),
+		r.SetLightingSegmentCount(3),
+		r.SetControllerLocalAddress("2.0.0.1"),
+		r.SetControllerNodeDefinitions(definitions),
+	} {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r, err = NewFileRepository(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if shader, ok := r.GetGraphicsShader(); !ok || shader != "snake_wipe" {
+		t.Errorf("shader = %s, %v", shader, ok)
+	}
+	if frequency, ok := r.GetGraphicsFrequency(); !ok || frequency != 20*time.Millisecond {
+		t.Errorf("frequency = %v, %v", frequency, ok)
+	}
+	if reload, ok := r.GetGraphicsReloadOnUpdate(); !ok || reload {
+		t.Errorf("reloadOnUpdate = %v, %v", reload, ok)
+	}
+	if s, ok := r.GetLightingSegmentDefinition(); !ok || len(s) != 1 || s[0][0].StringCount != 2 {
+		t.Errorf("segment definition = %v, %v", s, ok)
+	}
+	if count, ok := r.GetLightingSegmentCount(); !ok || count != 3 {
+		t.Errorf("segment count = %d, %v", count, ok)
+	}
+	if addr, ok := r.GetControllerLocalAddress(); !ok || addr != "2.0.0.1" {
+		t.Errorf("local address = %s, %v", addr, ok)
+	}
+	if d, ok := r.GetControllerNodeDefinitions(); !ok || len(d) != 1 || d[0].Universes[1] != 1 {
+		t.Errorf("node definitions = %v, %v", d, ok)
+	}
+
+	err = r.ResetRepository()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := r.GetLightingSegmentCount(); ok {
+		t.Error("expected segment count to be cleared by reset")
+	}
+}
+
+func TestRepository_recoversFromCorruptDocument(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.json")
+	r, err := NewFileRepository(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = r.SetGraphicsShader("basic"); err != nil {
+		t.Fatal(err)
+	}
+	// second write rotates the first document into the backup
+	if err = r.SetGraphicsShader("vertical_wipe"); err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(path, []byte(`{"schemaVersion": 1, "graphics": {"shaderNa`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err = NewFileRepository(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if shader, ok := r.GetGraphicsShader(); !ok || shader != "basic" {
+		t.Errorf("shader = %s, %v; expected backup to be recovered", shader, ok)
+	}
+	if _, err = os.Stat(path + ".corrupt"); err != nil {
+		t.Error("expected corrupt document to be kept aside")
+	}
+}
+
+func TestRepository_refusesNewerSchema(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.json")
+	err := os.WriteFile(path, []byte(`{"schemaVersion": 99}`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = NewFileRepository(path)
+	if !errors.Is(err, errUnsupportedVersion) {
+		t.Errorf("err = %v; expected unsupported version", err)
+	}
+}
+
+func TestRepository_recoversFromInterruptedWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.json")
+	r, err := NewFileRepository(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = r.SetGraphicsShader("basic"); err != nil {
+		t.Fatal(err)
+	}
+	if err = r.SetGraphicsShader("vertical_wipe"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = os.Stat(path); err != nil {
+		t.Fatalf("expected the document to stay in place; %v", err)
+	}
+
+	// a crash after the temp file was synced, with the document gone: the temp file is the newest
+	complete := `{"schemaVersion": 1, "graphics": {"shaderName": "snake_wipe"}}`
+	if err = os.WriteFile(path+".tmp", []byte(complete), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	r, err = NewFileRepository(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if shader, _ := r.GetGraphicsShader(); shader != "snake_wipe" {
+		t.Errorf("shader = %s; expected the complete temp file over the backup", shader)
+	}
+
+	// a torn temp file falls through to the backup, the document before the last write
+	if err = r.SetGraphicsShader("horizontal_wipe"); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.WriteFile(path+".tmp", []byte(`{"schemaVersion": 1, "gra`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	r, err = NewFileRepository(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if shader, _ := r.GetGraphicsShader(); shader != "snake_wipe" {
+		t.Errorf("shader = %s; expected the backup", shader)
+	}
+}
+
+func TestRepository_nodeDefinitionsAreCopied(t *testing.T) {
+	r, err := NewFileRepository(filepath.Join(t.TempDir(), "settings.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	definitions := types.NodeDefinitions{{Address: "2.0.0.2", Universes: []int{0, 1}}}
+	if err = r.SetControllerNodeDefinitions(definitions); err != nil {
+		t.Fatal(err)
+	}
+	definitions[0].Universes[0] = 5
+	got, _ := r.GetControllerNodeDefinitions()
+	got[0].Address = "2.0.0.3"
+	got, _ = r.GetControllerNodeDefinitions()
+	if got[0].Address != "2.0.0.2" || got[0].Universes[0] != 0 {
+		t.Errorf("node definitions = %v; expected the repository's own copy", got)
+	}
+}
@@ -13,8 +13,21 @@ var (
 		graphicsReloadOnUpdate:    -1,
 		graphicsShaderName:        "",
 		graphicsFrequency:         nil,
+		graphicsTransition:        nil,
+		graphicsUniforms:          nil,
+		graphicsPlaylists:         nil,
+		graphicsActivePlaylist:    nil,
 		controllerLocalAddress:    "",
 		controllerNodeDefinitions: nil,
+		renderGamma:               nil,
+		renderWhiteBalance:        nil,
+		renderDithering:           -1,
+		renderBrightness:          nil,
+		renderPowerBudget:         nil,
+		scheduleEnabled:           -1,
+		scheduleLocation:          nil,
+		scheduleWindows:           nil,
+		schedulePlaylist:          nil,
 		mu:                        &sync.RWMutex{},
 	}
 )
@@ -25,8 +38,21 @@ type Repository struct {
 	graphicsReloadOnUpdate    int
 	graphicsShaderName        string
 	graphicsFrequency         *time.Duration
+	graphicsTransition        *types.Transition
+	graphicsUniforms          map[string]map[string]float32
+	graphicsPlaylists         *[]types.Playlist
+	graphicsActivePlaylist    *string
 	controllerLocalAddress    string
 	controllerNodeDefinitions types.NodeDefinitions
+	renderGamma               *float64
+	renderWhiteBalance        *types.WhiteBalance
+	renderDithering           int
+	renderBrightness          *float64
+	renderPowerBudget         *types.PowerBudget
+	scheduleEnabled           int
+	scheduleLocation          *types.GeoLocation
+	scheduleWindows           *[]types.ScheduleWindow
+	schedulePlaylist          *[]types.PlaylistEntry
 	mu                        *sync.RWMutex
 }
 
@@ -56,7 +82,7 @@ func (r *Repository) GetLightingSegmentDefinition() (segment types.LedSegment, o
 func (r *Repository) SetLightingSegmentDefinition(segment types.LedSegment) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	*r.lightingSegmentDefinition = segment
+	r.lightingSegmentDefinition = &segment
 	return nil
 }
 
@@ -130,7 +156,87 @@ func (r *Repository) GetGraphicsFrequency() (frequency time.Duration, ok bool) {
 func (r *Repository) SetGraphicsFrequency(frequency time.Duration) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	*r.graphicsFrequency = frequency
+	r.graphicsFrequency = &frequency
+	return nil
+}
+
+func (r *Repository) GetGraphicsTransition() (transition types.Transition, ok bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.graphicsTransition != nil {
+		return *r.graphicsTransition, true
+	} else {
+		return types.Transition{}, false
+	}
+}
+
+func (r *Repository) SetGraphicsTransition(transition types.Transition) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.graphicsTransition = &transition
+	return nil
+}
+
+func (r *Repository) GetGraphicsUniforms() (values map[string]map[string]float32, ok bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.graphicsUniforms != nil {
+		return copyUniformValues(r.graphicsUniforms), true
+	} else {
+		return nil, false
+	}
+}
+
+func (r *Repository) SetGraphicsUniforms(values map[string]map[string]float32) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.graphicsUniforms = copyUniformValues(values)
+	return nil
+}
+
+func copyUniformValues(values map[string]map[string]float32) map[string]map[string]float32 {
+	c := make(map[string]map[string]float32, len(values))
+	for shader, shaderValues := range values {
+		c[shader] = make(map[string]float32, len(shaderValues))
+		for k, v := range shaderValues {
+			c[shader][k] = v
+		}
+	}
+	return c
+}
+
+func (r *Repository) GetGraphicsPlaylists() (playlists []types.Playlist, ok bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.graphicsPlaylists != nil {
+		return append([]types.Playlist(nil), *r.graphicsPlaylists...), true
+	} else {
+		return nil, false
+	}
+}
+
+func (r *Repository) SetGraphicsPlaylists(playlists []types.Playlist) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	playlists = append([]types.Playlist{}, playlists...)
+	r.graphicsPlaylists = &playlists
+	return nil
+}
+
+func (r *Repository) GetGraphicsActivePlaylist() (name string, ok bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.graphicsActivePlaylist != nil {
+		return *r.graphicsActivePlaylist, true
+	} else {
+		return "", false
+	}
+}
+
+func (r *Repository) SetGraphicsActivePlaylist(name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.graphicsActivePlaylist = &name
 	return nil
 }
 
@@ -154,7 +260,7 @@ func (r *Repository) GetControllerLocalAddress() (addr string, ok bool) {
 func (r *Repository) SetControllerNodeDefinitions(definitions types.NodeDefinitions) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	copy(r.controllerNodeDefinitions, definitions)
+	r.controllerNodeDefinitions = append(types.NodeDefinitions(nil), definitions...)
 	return nil
 }
 
@@ -162,9 +268,178 @@ func (r *Repository) GetControllerNodeDefinitions() (definitions types.NodeDefin
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 	if r.controllerNodeDefinitions != nil {
-		copy(definitions, r.controllerNodeDefinitions)
-		return definitions, true
+		return append(types.NodeDefinitions(nil), r.controllerNodeDefinitions...), true
 	} else {
 		return nil, false
 	}
 }
+
+func (r *Repository) GetRenderGamma() (gamma float64, ok bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.renderGamma != nil {
+		return *r.renderGamma, true
+	} else {
+		return 0, false
+	}
+}
+
+func (r *Repository) SetRenderGamma(gamma float64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.renderGamma = &gamma
+	return nil
+}
+
+func (r *Repository) GetRenderWhiteBalance() (whiteBalance types.WhiteBalance, ok bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.renderWhiteBalance != nil {
+		return *r.renderWhiteBalance, true
+	} else {
+		return types.WhiteBalance{}, false
+	}
+}
+
+func (r *Repository) SetRenderWhiteBalance(whiteBalance types.WhiteBalance) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.renderWhiteBalance = &whiteBalance
+	return nil
+}
+
+func (r *Repository) GetRenderDithering() (dithering bool, ok bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.renderDithering == 1 {
+		return true, true
+	} else if r.renderDithering == 0 {
+		return false, true
+	} else {
+		return false, false
+	}
+}
+
+func (r *Repository) SetRenderDithering(dithering bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if dithering {
+		r.renderDithering = 1
+	} else {
+		r.renderDithering = 0
+	}
+	return nil
+}
+
+func (r *Repository) GetRenderBrightness() (brightness float64, ok bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.renderBrightness != nil {
+		return *r.renderBrightness, true
+	} else {
+		return 0, false
+	}
+}
+
+func (r *Repository) SetRenderBrightness(brightness float64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.renderBrightness = &brightness
+	return nil
+}
+
+func (r *Repository) GetRenderPowerBudget() (budget types.PowerBudget, ok bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.renderPowerBudget != nil {
+		budget = *r.renderPowerBudget
+		budget.Segments = append([]types.PowerSegment(nil), budget.Segments...)
+		return budget, true
+	} else {
+		return types.PowerBudget{}, false
+	}
+}
+
+func (r *Repository) SetRenderPowerBudget(budget types.PowerBudget) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	budget.Segments = append([]types.PowerSegment(nil), budget.Segments...)
+	r.renderPowerBudget = &budget
+	return nil
+}
+
+func (r *Repository) GetScheduleEnabled() (enabled bool, ok bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.scheduleEnabled == 1 {
+		return true, true
+	} else if r.scheduleEnabled == 0 {
+		return false, true
+	} else {
+		return false, false
+	}
+}
+
+func (r *Repository) SetScheduleEnabled(enabled bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if enabled {
+		r.scheduleEnabled = 1
+	} else {
+		r.scheduleEnabled = 0
+	}
+	return nil
+}
+
+func (r *Repository) GetScheduleLocation() (location types.GeoLocation, ok bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.scheduleLocation != nil {
+		return *r.scheduleLocation, true
+	} else {
+		return types.GeoLocation{}, false
+	}
+}
+
+func (r *Repository) SetScheduleLocation(location types.GeoLocation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.scheduleLocation = &location
+	return nil
+}
+
+func (r *Repository) GetScheduleWindows() (windows []types.ScheduleWindow, ok bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.scheduleWindows != nil {
+		return append([]types.ScheduleWindow(nil), *r.scheduleWindows...), true
+	} else {
+		return nil, false
+	}
+}
+
+func (r *Repository) SetScheduleWindows(windows []types.ScheduleWindow) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	windows = append([]types.ScheduleWindow{}, windows...)
+	r.scheduleWindows = &windows
+	return nil
+}
+
+func (r *Repository) GetSchedulePlaylist() (playlist []types.PlaylistEntry, ok bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.schedulePlaylist != nil {
+		return append([]types.PlaylistEntry(nil), *r.schedulePlaylist...), true
+	} else {
+		return nil, false
+	}
+}
+
+func (r *Repository) SetSchedulePlaylist(playlist []types.PlaylistEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	playlist = append([]types.PlaylistEntry{}, playlist...)
+	r.schedulePlaylist = &playlist
+	return nil
+}
@@ -9,8 +9,11 @@ type bus struct {
 	graphicsService   domain.GraphicsService
 	lightingService   domain.LightingService
 	controllerService domain.ControllerService
+	renderService     domain.RenderService
+	schedulerService  domain.SchedulerService
 	repo              Repository
 	eventHandler      *eventHandler
+	stream            *eventStream
 	nextEventTraceId  uint64
 }
 
@@ -18,6 +21,7 @@ func NewBus(conf Config, repo Repository) *bus {
 	b := &bus{
 		nextEventTraceId: 0,
 		repo:             repo,
+		stream:           newEventStream(),
 	}
 	b.eventHandler = newEventHandler(b, conf)
 	return b
@@ -35,6 +39,14 @@ func (b *bus) BindControllerService(controllerClient domain.ControllerService) {
 	b.controllerService = controllerClient
 }
 
+func (b *bus) BindRenderService(renderService domain.RenderService) {
+	b.renderService = renderService
+}
+
+func (b *bus) BindSchedulerService(schedulerService domain.SchedulerService) {
+	b.schedulerService = schedulerService
+}
+
 func (b *bus) Startup() error {
 	err := b.eventHandler.startup()
 	if err != nil {
@@ -42,10 +54,13 @@ func (b *bus) Startup() error {
 	}
 	b.controllerService.Startup()
 	b.graphicsService.Startup()
+	b.schedulerService.Startup()
 	return nil
 }
 
 func (b *bus) Shutdown() {
+	// the scheduler reports through the event queue, so it stops while the event loop can still take it
+	b.schedulerService.Shutdown()
 	b.eventHandler.shutdown()
 	b.graphicsService.Shutdown()
 	b.controllerService.Shutdown()
@@ -2,8 +2,10 @@ package service
 
 import (
 	"errors"
+	"fmt"
 	"github.com/polis-interactive/2023-CosmicMurmur/internal/domain"
 	"github.com/polis-interactive/2023-CosmicMurmur/internal/types"
+	"image"
 	"log"
 	"time"
 )
@@ -13,7 +15,7 @@ import (
 */
 
 func (b *bus) GetGridDimensions() *types.Grid {
-	responseChannel := make(chan *types.Grid)
+	responseChannel := make(chan *types.Grid, 1)
 	defaultResponse := &types.Grid{
 		MinX: -1,
 		MaxX: 1,
@@ -46,12 +48,55 @@ func (b *bus) EmitGraphicsCrashed() {
 	}
 }
 
+func (b *bus) EmitGraphicsShadersChanged() {
+	err := tryEnqueueEvent(b, GraphicsShadersChanged, nil)
+	if err != nil {
+		log.Printf("coulnd't enqueue event")
+	}
+}
+
+// EmitShaderError goes straight to the stream, same as EmitNodeError; it's only a report
+func (b *bus) EmitShaderError(shaderName string, err error) {
+	b.stream.publish(b.GetEventTraceId(), domain.StreamEventShaderError, &domain.ShaderError{
+		ShaderName: shaderName,
+		Error:      err.Error(),
+	})
+}
+
+/*
+	ControllerService bus commands
+*/
+
+// EmitNodeError goes straight to the stream instead of through the event queue; nodes are shut down from
+// the event loop, so a node blocking on a full queue could deadlock it
+func (b *bus) EmitNodeError(address string, err error) {
+	b.stream.publish(b.GetEventTraceId(), domain.StreamEventNodeError, &domain.NodeError{
+		Address: address,
+		Error:   err.Error(),
+	})
+}
+
+func (b *bus) EmitNodeHealth(health *domain.NodeHealth) {
+	b.stream.publish(b.GetEventTraceId(), domain.StreamEventNodeHealth, health)
+}
+
+/*
+	SchedulerService bus commands
+*/
+
+func (b *bus) EmitScheduleState(state *domain.ScheduleState) {
+	err := tryEnqueueEvent(b, ScheduleChanged, state)
+	if err != nil {
+		log.Printf("coulnd't enqueue event")
+	}
+}
+
 /*
 	API bus commands
 */
 
 func (b *bus) FetchGraphicsSettings() (*domain.GraphicsSettings, error) {
-	responseChannel := make(chan *domain.GraphicsSettings)
+	responseChannel := make(chan *domain.GraphicsSettings, 1)
 	err := tryEnqueueEvent(b, FetchGraphicsSettings, responseChannel)
 	if err != nil {
 		return nil, err
@@ -63,21 +108,104 @@ func (b *bus) FetchGraphicsSettings() (*domain.GraphicsSettings, error) {
 	return resp, nil
 }
 
-func (b *bus) SetGraphicsSettings(shaderName string, refreshInMs int64, reloadOnUpdate bool) error {
-	responseChannel := make(chan struct{})
+func (b *bus) SetGraphicsSettings(shaderName string, refreshInMs int64, reloadOnUpdate bool, transition *types.Transition) error {
+	responseChannel := make(chan error, 1)
 	err := tryEnqueueEvent(b, SetGraphicsSettings, &setGraphicsSettingsPayload{
 		DispatchChannel: responseChannel, GraphicsFrequency: time.Duration(refreshInMs) * time.Millisecond,
-		ShaderName: shaderName, ReloadOnUpdate: reloadOnUpdate,
+		ShaderName: shaderName, ReloadOnUpdate: reloadOnUpdate, Transition: transition,
+	})
+	if err != nil {
+		return err
+	}
+	return waitForError(b, responseChannel)
+}
+
+func (b *bus) SetGraphicsUniforms(shaderName string, values map[string]float32) error {
+	responseChannel := make(chan error, 1)
+	err := tryEnqueueEvent(b, SetGraphicsUniforms, &setGraphicsUniformsPayload{
+		DispatchChannel: responseChannel, ShaderName: shaderName, Values: values,
+	})
+	if err != nil {
+		return err
+	}
+	return waitForError(b, responseChannel)
+}
+
+func (b *bus) UploadGraphicsShader(name string, frag string, vert string) error {
+	responseChannel := make(chan error, 1)
+	err := tryEnqueueEvent(b, UploadGraphicsShader, &uploadGraphicsShaderPayload{
+		DispatchChannel: responseChannel, Name: name, Frag: frag, Vert: vert,
+	})
+	if err != nil {
+		return err
+	}
+	return waitForError(b, responseChannel)
+}
+
+func (b *bus) DeleteGraphicsShader(name string) error {
+	responseChannel := make(chan error, 1)
+	err := tryEnqueueEvent(b, DeleteGraphicsShader, &deleteGraphicsShaderPayload{
+		DispatchChannel: responseChannel, Name: name,
+	})
+	if err != nil {
+		return err
+	}
+	return waitForError(b, responseChannel)
+}
+
+func (b *bus) RenameGraphicsShader(name string, newName string) error {
+	responseChannel := make(chan error, 1)
+	err := tryEnqueueEvent(b, RenameGraphicsShader, &renameGraphicsShaderPayload{
+		DispatchChannel: responseChannel, Name: name, NewName: newName,
+	})
+	if err != nil {
+		return err
+	}
+	return waitForError(b, responseChannel)
+}
+
+func (b *bus) SetGraphicsPlaylists(playlists []types.Playlist) error {
+	responseChannel := make(chan error, 1)
+	err := tryEnqueueEvent(b, SetGraphicsPlaylists, &setGraphicsPlaylistsPayload{
+		DispatchChannel: responseChannel, Playlists: playlists,
 	})
 	if err != nil {
 		return err
 	}
-	_, err = waitForResponse[struct{}](b, responseChannel)
-	return err
+	return waitForError(b, responseChannel)
+}
+
+func (b *bus) StartGraphicsPlaylist(name string) error {
+	responseChannel := make(chan error, 1)
+	err := tryEnqueueEvent(b, StartGraphicsPlaylist, &startGraphicsPlaylistPayload{
+		DispatchChannel: responseChannel, Name: name,
+	})
+	if err != nil {
+		return err
+	}
+	return waitForError(b, responseChannel)
+}
+
+func (b *bus) StopGraphicsPlaylist() error {
+	responseChannel := make(chan error, 1)
+	err := tryEnqueueEvent(b, StopGraphicsPlaylist, responseChannel)
+	if err != nil {
+		return err
+	}
+	return waitForError(b, responseChannel)
+}
+
+func (b *bus) SkipGraphicsPlaylist() error {
+	responseChannel := make(chan error, 1)
+	err := tryEnqueueEvent(b, SkipGraphicsPlaylist, responseChannel)
+	if err != nil {
+		return err
+	}
+	return waitForError(b, responseChannel)
 }
 
 func (b *bus) FetchLightingSettings() (*domain.LightingSettings, error) {
-	responseChannel := make(chan *domain.LightingSettings)
+	responseChannel := make(chan *domain.LightingSettings, 1)
 	err := tryEnqueueEvent(b, FetchLightingSettings, responseChannel)
 	if err != nil {
 		return nil, err
@@ -90,7 +218,7 @@ func (b *bus) FetchLightingSettings() (*domain.LightingSettings, error) {
 }
 
 func (b *bus) SetLightingSettings(segmentDefinition types.LedSegment, segmentCount int) error {
-	responseChannel := make(chan struct{})
+	responseChannel := make(chan error, 1)
 	err := tryEnqueueEvent(b, SetLightingSettings, &setLightingSettingsPayload{
 		DispatchChannel: responseChannel, SegmentCount: segmentCount,
 		SegmentDefinition: segmentDefinition,
@@ -98,8 +226,156 @@ func (b *bus) SetLightingSettings(segmentDefinition types.LedSegment, segmentCou
 	if err != nil {
 		return err
 	}
-	_, err = waitForResponse[struct{}](b, responseChannel)
-	return err
+	return waitForError(b, responseChannel)
+}
+
+func (b *bus) FetchControllerSettings() (*domain.ControllerSettings, error) {
+	responseChannel := make(chan *domain.ControllerSettings, 1)
+	err := tryEnqueueEvent(b, FetchControllerSettings, responseChannel)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := waitForResponse[*domain.ControllerSettings](b, responseChannel)
+	if err != nil || resp == nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (b *bus) SetControllerSettings(nodeDefinitions types.NodeDefinitions, localAddress string) error {
+	responseChannel := make(chan error, 1)
+	err := tryEnqueueEvent(b, SetControllerSettings, &setControllerSettingsPayload{
+		DispatchChannel: responseChannel, NodeDefinitions: nodeDefinitions,
+		LocalAddress: localAddress,
+	})
+	if err != nil {
+		return err
+	}
+	return waitForError(b, responseChannel)
+}
+
+func (b *bus) FetchRenderSettings() (*domain.RenderSettings, error) {
+	responseChannel := make(chan *domain.RenderSettings, 1)
+	err := tryEnqueueEvent(b, FetchRenderSettings, responseChannel)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := waitForResponse[*domain.RenderSettings](b, responseChannel)
+	if err != nil || resp == nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (b *bus) SetRenderSettings(settings *domain.RenderSettings) error {
+	responseChannel := make(chan error, 1)
+	err := tryEnqueueEvent(b, SetRenderSettings, &setRenderSettingsPayload{
+		DispatchChannel: responseChannel, Settings: *settings,
+	})
+	if err != nil {
+		return err
+	}
+	return waitForError(b, responseChannel)
+}
+
+func (b *bus) FetchScheduleSettings() (*domain.ScheduleSettings, error) {
+	responseChannel := make(chan *domain.ScheduleSettings, 1)
+	err := tryEnqueueEvent(b, FetchScheduleSettings, responseChannel)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := waitForResponse[*domain.ScheduleSettings](b, responseChannel)
+	if err != nil || resp == nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (b *bus) SetScheduleSettings(settings *domain.ScheduleSettings) error {
+	responseChannel := make(chan error, 1)
+	err := tryEnqueueEvent(b, SetScheduleSettings, &setScheduleSettingsPayload{
+		DispatchChannel: responseChannel, Settings: *settings,
+	})
+	if err != nil {
+		return err
+	}
+	return waitForError(b, responseChannel)
+}
+
+func (b *bus) FetchNodeHealth() ([]*domain.NodeHealth, error) {
+	responseChannel := make(chan []*domain.NodeHealth, 1)
+	err := tryEnqueueEvent(b, FetchNodeHealth, responseChannel)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := waitForResponse[[]*domain.NodeHealth](b, responseChannel)
+	if err != nil || resp == nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (b *bus) ProposeNodeDefinitions() (*domain.NodeDefinitionsProposal, error) {
+	responseChannel := make(chan *domain.NodeDefinitionsProposal, 1)
+	err := tryEnqueueEvent(b, ProposeNodeDefinitions, responseChannel)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := waitForResponse[*domain.NodeDefinitionsProposal](b, responseChannel)
+	if err != nil || resp == nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (b *bus) AcceptNodeDefinitions() error {
+	responseChannel := make(chan error, 1)
+	err := tryEnqueueEvent(b, AcceptNodeDefinitions, responseChannel)
+	if err != nil {
+		return err
+	}
+	return waitForError(b, responseChannel)
+}
+
+func (b *bus) ResetApplication() error {
+	responseChannel := make(chan error, 1)
+	err := tryEnqueueEvent(b, ResetApplication, responseChannel)
+	if err != nil {
+		return err
+	}
+	return waitForError(b, responseChannel)
+}
+
+func (b *bus) FetchStatus() (*domain.ApplicationStatus, error) {
+	responseChannel := make(chan *domain.ApplicationStatus, 1)
+	err := tryEnqueueEvent(b, FetchStatus, responseChannel)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := waitForResponse[*domain.ApplicationStatus](b, responseChannel)
+	if err != nil || resp == nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (b *bus) FetchPreview(lightsOnly bool) (*image.RGBA, error) {
+	responseChannel := make(chan *image.RGBA, 1)
+	err := tryEnqueueEvent(b, FetchPreview, &fetchPreviewPayload{
+		DispatchChannel: responseChannel, LightsOnly: lightsOnly,
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp, err := waitForResponse[*image.RGBA](b, responseChannel)
+	if err != nil || resp == nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (b *bus) Subscribe() (events <-chan *domain.StreamEvent, unsubscribe func()) {
+	return b.stream.subscribe()
 }
 
 /*
@@ -119,16 +395,21 @@ func tryEnqueueEvent(b *bus, e eventType, payload interface{}) error {
 		}
 		return nil
 	} else {
-		return errors.New("event queue closed")
+		return fmt.Errorf("%w: event queue closed", domain.ErrUnavailable)
 	}
 }
 
+/*
+	response channels are buffered so a handler finishing after the caller has already timed out doesn't
+	block the event loop on a send nobody will receive
+*/
+
 func waitForResponse[T any](b *bus, responseChan chan T) (t T, err error) {
 	eh := b.eventHandler
 	select {
 	case _, ok := <-eh.shutdowns:
 		if !ok {
-			return t, errors.New("eventloop shutdown")
+			return t, fmt.Errorf("%w: eventloop shutdown", domain.ErrUnavailable)
 		} else {
 			return t, errors.New("illegal state")
 		}
@@ -136,9 +417,18 @@ func waitForResponse[T any](b *bus, responseChan chan T) (t T, err error) {
 		if ok {
 			return resp, nil
 		} else {
-			return t, errors.New("eventloop closed connection")
+			return t, fmt.Errorf("%w: eventloop closed connection", domain.ErrUnavailable)
 		}
 	case <-time.After(eh.eventBusyTimeout):
-		return t, errors.New("eventloop not responding")
+		return t, fmt.Errorf("%w: eventloop not responding", domain.ErrUnavailable)
+	}
+}
+
+// waitForError waits on commands whose handler reports the outcome of the event rather than a value
+func waitForError(b *bus, responseChan chan error) error {
+	resp, err := waitForResponse[error](b, responseChan)
+	if err != nil {
+		return err
 	}
+	return resp
 }
@@ -20,6 +20,14 @@ type eventHandler struct {
 
 	eventQueue     chan *event
 	eventQueueLock *sync.RWMutex
+
+	// only touched from the event loop
+	framesRendered  uint64
+	graphicsCrashes uint64
+	lastFrame       time.Time
+	lastStatusSent  time.Time
+	// schedule is the last state the scheduler reported that got applied; nil until it first reports
+	schedule *domain.ScheduleState
 }
 
 func newEventHandler(b *bus, conf Config) *eventHandler {
@@ -146,15 +154,60 @@ func (e *eventHandler) handleEvent(eventInstance *event) {
 		e.UpdateRenderFromGraphics(eventInstance)
 	case GraphicsCrashed:
 		e.ClearGraphics(eventInstance)
+	case GraphicsShadersChanged:
+		e.PublishGraphicsShaders(eventInstance)
+	// scheduler bus
+	case ScheduleChanged:
+		e.ApplySchedule(eventInstance, eventInstance.Payload.(*domain.ScheduleState))
 	// api calls
 	case FetchGraphicsSettings:
 		e.FetchGraphicsSettings(eventInstance, eventInstance.Payload.(chan *domain.GraphicsSettings))
 	case SetGraphicsSettings:
 		e.SetGraphicsSettings(eventInstance, eventInstance.Payload.(*setGraphicsSettingsPayload))
+	case SetGraphicsUniforms:
+		e.SetGraphicsUniforms(eventInstance, eventInstance.Payload.(*setGraphicsUniformsPayload))
+	case UploadGraphicsShader:
+		e.UploadGraphicsShader(eventInstance, eventInstance.Payload.(*uploadGraphicsShaderPayload))
+	case DeleteGraphicsShader:
+		e.DeleteGraphicsShader(eventInstance, eventInstance.Payload.(*deleteGraphicsShaderPayload))
+	case RenameGraphicsShader:
+		e.RenameGraphicsShader(eventInstance, eventInstance.Payload.(*renameGraphicsShaderPayload))
+	case SetGraphicsPlaylists:
+		e.SetGraphicsPlaylists(eventInstance, eventInstance.Payload.(*setGraphicsPlaylistsPayload))
+	case StartGraphicsPlaylist:
+		e.StartGraphicsPlaylist(eventInstance, eventInstance.Payload.(*startGraphicsPlaylistPayload))
+	case StopGraphicsPlaylist:
+		e.StopGraphicsPlaylist(eventInstance, eventInstance.Payload.(chan error))
+	case SkipGraphicsPlaylist:
+		e.SkipGraphicsPlaylist(eventInstance, eventInstance.Payload.(chan error))
 	case FetchLightingSettings:
 		e.FetchLightingSettings(eventInstance, eventInstance.Payload.(chan *domain.LightingSettings))
 	case SetLightingSettings:
 		e.SetLightingSettings(eventInstance, eventInstance.Payload.(*setLightingSettingsPayload))
+	case FetchControllerSettings:
+		e.FetchControllerSettings(eventInstance, eventInstance.Payload.(chan *domain.ControllerSettings))
+	case SetControllerSettings:
+		e.SetControllerSettings(eventInstance, eventInstance.Payload.(*setControllerSettingsPayload))
+	case FetchRenderSettings:
+		e.FetchRenderSettings(eventInstance, eventInstance.Payload.(chan *domain.RenderSettings))
+	case SetRenderSettings:
+		e.SetRenderSettings(eventInstance, eventInstance.Payload.(*setRenderSettingsPayload))
+	case FetchScheduleSettings:
+		e.FetchScheduleSettings(eventInstance, eventInstance.Payload.(chan *domain.ScheduleSettings))
+	case SetScheduleSettings:
+		e.SetScheduleSettings(eventInstance, eventInstance.Payload.(*setScheduleSettingsPayload))
+	case FetchNodeHealth:
+		e.FetchNodeHealth(eventInstance, eventInstance.Payload.(chan []*domain.NodeHealth))
+	case ProposeNodeDefinitions:
+		e.ProposeNodeDefinitions(eventInstance, eventInstance.Payload.(chan *domain.NodeDefinitionsProposal))
+	case AcceptNodeDefinitions:
+		e.AcceptNodeDefinitions(eventInstance, eventInstance.Payload.(chan error))
+	case ResetApplication:
+		e.ResetApplication(eventInstance, eventInstance.Payload.(chan error))
+	case FetchStatus:
+		e.FetchStatus(eventInstance, eventInstance.Payload.(chan *domain.ApplicationStatus))
+	case FetchPreview:
+		e.FetchPreview(eventInstance, eventInstance.Payload.(*fetchPreviewPayload))
 	}
 
 	if l := log.Debug(); l.Enabled() {
@@ -189,14 +242,59 @@ func (e *eventHandler) closeoutEvent(eventInstance *event) {
 		return
 	case GraphicsCrashed:
 		return
+	case GraphicsShadersChanged:
+		return
+	// scheduler bus
+	case ScheduleChanged:
+		return
 	// api calls
 	case FetchGraphicsSettings:
 		close(eventInstance.Payload.(chan *domain.GraphicsSettings))
 	case SetGraphicsSettings:
 		close(eventInstance.Payload.(*setGraphicsSettingsPayload).DispatchChannel)
+	case SetGraphicsUniforms:
+		close(eventInstance.Payload.(*setGraphicsUniformsPayload).DispatchChannel)
+	case UploadGraphicsShader:
+		close(eventInstance.Payload.(*uploadGraphicsShaderPayload).DispatchChannel)
+	case DeleteGraphicsShader:
+		close(eventInstance.Payload.(*deleteGraphicsShaderPayload).DispatchChannel)
+	case RenameGraphicsShader:
+		close(eventInstance.Payload.(*renameGraphicsShaderPayload).DispatchChannel)
+	case SetGraphicsPlaylists:
+		close(eventInstance.Payload.(*setGraphicsPlaylistsPayload).DispatchChannel)
+	case StartGraphicsPlaylist:
+		close(eventInstance.Payload.(*startGraphicsPlaylistPayload).DispatchChannel)
+	case StopGraphicsPlaylist:
+		close(eventInstance.Payload.(chan error))
+	case SkipGraphicsPlaylist:
+		close(eventInstance.Payload.(chan error))
 	case FetchLightingSettings:
 		close(eventInstance.Payload.(chan *domain.LightingSettings))
 	case SetLightingSettings:
 		close(eventInstance.Payload.(*setLightingSettingsPayload).DispatchChannel)
+	case FetchControllerSettings:
+		close(eventInstance.Payload.(chan *domain.ControllerSettings))
+	case SetControllerSettings:
+		close(eventInstance.Payload.(*setControllerSettingsPayload).DispatchChannel)
+	case FetchRenderSettings:
+		close(eventInstance.Payload.(chan *domain.RenderSettings))
+	case SetRenderSettings:
+		close(eventInstance.Payload.(*setRenderSettingsPayload).DispatchChannel)
+	case FetchScheduleSettings:
+		close(eventInstance.Payload.(chan *domain.ScheduleSettings))
+	case SetScheduleSettings:
+		close(eventInstance.Payload.(*setScheduleSettingsPayload).DispatchChannel)
+	case FetchNodeHealth:
+		close(eventInstance.Payload.(chan []*domain.NodeHealth))
+	case ProposeNodeDefinitions:
+		close(eventInstance.Payload.(chan *domain.NodeDefinitionsProposal))
+	case AcceptNodeDefinitions:
+		close(eventInstance.Payload.(chan error))
+	case ResetApplication:
+		close(eventInstance.Payload.(chan error))
+	case FetchStatus:
+		close(eventInstance.Payload.(chan *domain.ApplicationStatus))
+	case FetchPreview:
+		close(eventInstance.Payload.(*fetchPreviewPayload).DispatchChannel)
 	}
 }
@@ -1,7 +1,9 @@
 package service
 
 import (
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/domain"
 	"github.com/polis-interactive/2023-CosmicMurmur/internal/types"
+	"image"
 	"time"
 )
 
@@ -11,11 +13,33 @@ const (
 	RequestGridDimensions eventType = iota
 	GraphicsCrashed
 	GraphicsReady
+	ScheduleChanged
+	GraphicsShadersChanged
 
 	FetchGraphicsSettings
 	SetGraphicsSettings
+	SetGraphicsUniforms
+	UploadGraphicsShader
+	DeleteGraphicsShader
+	RenameGraphicsShader
+	SetGraphicsPlaylists
+	StartGraphicsPlaylist
+	StopGraphicsPlaylist
+	SkipGraphicsPlaylist
 	FetchLightingSettings
 	SetLightingSettings
+	FetchControllerSettings
+	SetControllerSettings
+	FetchRenderSettings
+	SetRenderSettings
+	FetchScheduleSettings
+	SetScheduleSettings
+	FetchNodeHealth
+	ProposeNodeDefinitions
+	AcceptNodeDefinitions
+	ResetApplication
+	FetchStatus
+	FetchPreview
 )
 
 func (s eventType) String() string {
@@ -26,14 +50,58 @@ func (s eventType) String() string {
 		return "Graphics Crashed"
 	case GraphicsReady:
 		return "Graphics Ready"
+	case ScheduleChanged:
+		return "Schedule Changed"
+	case GraphicsShadersChanged:
+		return "Graphics Shaders Changed"
 	case FetchGraphicsSettings:
 		return "Fetch Settings, Graphics"
 	case SetGraphicsSettings:
 		return "Set Settings, Graphics"
+	case SetGraphicsUniforms:
+		return "Set Uniforms, Graphics"
+	case UploadGraphicsShader:
+		return "Upload Shader, Graphics"
+	case DeleteGraphicsShader:
+		return "Delete Shader, Graphics"
+	case RenameGraphicsShader:
+		return "Rename Shader, Graphics"
+	case SetGraphicsPlaylists:
+		return "Set Playlists, Graphics"
+	case StartGraphicsPlaylist:
+		return "Start Playlist, Graphics"
+	case StopGraphicsPlaylist:
+		return "Stop Playlist, Graphics"
+	case SkipGraphicsPlaylist:
+		return "Skip Playlist, Graphics"
 	case FetchLightingSettings:
 		return "Fetch Settings, Lighting"
 	case SetLightingSettings:
 		return "Set Settings, lighting"
+	case FetchControllerSettings:
+		return "Fetch Settings, Controller"
+	case SetControllerSettings:
+		return "Set Settings, Controller"
+	case FetchRenderSettings:
+		return "Fetch Settings, Render"
+	case SetRenderSettings:
+		return "Set Settings, Render"
+	case FetchScheduleSettings:
+		return "Fetch Settings, Schedule"
+	case SetScheduleSettings:
+		return "Set Settings, Schedule"
+	case FetchNodeHealth:
+		return "Fetch Node Health"
+	case ProposeNodeDefinitions:
+		return "Propose Node Definitions"
+	case AcceptNodeDefinitions:
+		return "Accept Node Definitions"
+	case ResetApplication:
+		return "Reset Application"
+	case FetchStatus:
+		return "Fetch Status"
+	case FetchPreview:
+		return "Fetch Preview"
 
 	}
 	return "UNHANDLED_EVENT"
@@ -48,14 +116,70 @@ type event struct {
 }
 
 type setGraphicsSettingsPayload struct {
-	DispatchChannel   chan struct{}
+	DispatchChannel   chan error
 	ShaderName        string
 	GraphicsFrequency time.Duration
 	ReloadOnUpdate    bool
+	Transition        *types.Transition
+}
+
+type setGraphicsUniformsPayload struct {
+	DispatchChannel chan error
+	ShaderName      string
+	Values          map[string]float32
+}
+
+type uploadGraphicsShaderPayload struct {
+	DispatchChannel chan error
+	Name            string
+	Frag            string
+	Vert            string
+}
+
+type deleteGraphicsShaderPayload struct {
+	DispatchChannel chan error
+	Name            string
+}
+
+type renameGraphicsShaderPayload struct {
+	DispatchChannel chan error
+	Name            string
+	NewName         string
+}
+
+type setGraphicsPlaylistsPayload struct {
+	DispatchChannel chan error
+	Playlists       []types.Playlist
+}
+
+type startGraphicsPlaylistPayload struct {
+	DispatchChannel chan error
+	Name            string
 }
 
 type setLightingSettingsPayload struct {
-	DispatchChannel   chan struct{}
+	DispatchChannel   chan error
 	SegmentDefinition types.LedSegment
 	SegmentCount      int
 }
+
+type setControllerSettingsPayload struct {
+	DispatchChannel chan error
+	NodeDefinitions types.NodeDefinitions
+	LocalAddress    string
+}
+
+type setRenderSettingsPayload struct {
+	DispatchChannel chan error
+	Settings        domain.RenderSettings
+}
+
+type setScheduleSettingsPayload struct {
+	DispatchChannel chan error
+	Settings        domain.ScheduleSettings
+}
+
+type fetchPreviewPayload struct {
+	DispatchChannel chan *image.RGBA
+	LightsOnly      bool
+}
@@ -1,10 +1,13 @@
 package service
 
 import (
+	"fmt"
 	"github.com/polis-interactive/2023-CosmicMurmur/internal/domain"
 	"github.com/polis-interactive/2023-CosmicMurmur/internal/types"
 	"github.com/rs/zerolog/log"
+	"image"
 	"sync"
+	"time"
 )
 
 func (e *eventHandler) RetrieveGridDimensions(eventInstance *event, dispatchChannel chan *types.Grid) {
@@ -26,9 +29,20 @@ func (e *eventHandler) UpdateRenderFromGraphics(eventInstance *event) {
 		Str("method", "UpdateRenderFromGraphics").Uint64("trace", eventInstance.TraceId).
 		Msg("updating renderer")
 
+	// outside show hours the nodes hold the blackout the schedule sent them
+	if !e.isShowOn() {
+		return
+	}
+
 	pb, gMuPreRLocked := e.b.graphicsService.GetPb()
+	if pb == nil {
+		// graphics went down after queueing the frame
+		gMuPreRLocked.RUnlock()
+		return
+	}
 	lightUniverses := e.b.lightingService.GetLightUniverses()
 
+	frame := e.framesRendered
 	wg := &sync.WaitGroup{}
 	for universe, lights := range lightUniverses {
 		universeBuffer, ok := e.b.controllerService.GetUniverseBuffer(universe)
@@ -40,17 +54,31 @@ func (e *eventHandler) UpdateRenderFromGraphics(eventInstance *event) {
 			defer func() {
 				wg.Done()
 			}()
-			for _, l := range lights {
-				c := pb.GetPixel(&l.Position)
-				universeBuffer[l.Pixel*3] = c.R
-				universeBuffer[l.Pixel*3+1] = c.G
-				universeBuffer[l.Pixel*3+2] = c.B
+			colors := make([]types.Color16, len(lights))
+			for i, l := range lights {
+				colors[i] = e.b.renderService.Calibrate(pb.GetPixel(&l.Position), l.Format, universe+l.Pixel, frame)
+			}
+			// the limiter needs the whole universe's draw before it can scale any of it
+			e.b.renderService.LimitPower(universe, lights, colors)
+			for i, l := range lights {
+				l.Format.Pack(universeBuffer[l.Channel:], colors[i])
 			}
 			e.b.controllerService.SendUniverseUpdate(universe)
 		}(universe, lights)
 	}
 	wg.Wait()
 	gMuPreRLocked.RUnlock()
+	// every universe of the frame is queued; nodes holding for a sync can output it now
+	e.b.controllerService.SendFrameSync()
+
+	e.framesRendered += 1
+	e.lastFrame = time.Now()
+
+	// frames come in far too often to stream each one; subscribers get a periodic status instead
+	if e.lastFrame.Sub(e.lastStatusSent) >= statusStreamPeriod {
+		e.lastStatusSent = e.lastFrame
+		e.b.stream.publish(eventInstance.TraceId, domain.StreamEventStatus, e.getStatus())
+	}
 }
 
 func (e *eventHandler) ClearGraphics(eventInstance *event) {
@@ -59,7 +87,84 @@ func (e *eventHandler) ClearGraphics(eventInstance *event) {
 		Str("method", "ClearGraphics").Uint64("trace", eventInstance.TraceId).
 		Msg("clearing graphics")
 
+	e.graphicsCrashes += 1
 	e.b.controllerService.BlackoutNodes()
+	e.b.stream.publish(eventInstance.TraceId, domain.StreamEventGraphicsCrashed, e.getStatus())
+}
+
+// PublishGraphicsShaders lets subscribers know the shader list changed on disk
+func (e *eventHandler) PublishGraphicsShaders(eventInstance *event) {
+	log.Trace().
+		Str("package", "service").Str("struct", "eventHandler").
+		Str("method", "PublishGraphicsShaders").Uint64("trace", eventInstance.TraceId).
+		Msg("publishing graphics shaders")
+
+	e.publishGraphicsSettings(eventInstance)
+}
+
+func (e *eventHandler) isShowOn() bool {
+	return e.schedule == nil || e.schedule.ShowOn
+}
+
+func (e *eventHandler) ApplySchedule(eventInstance *event, state *domain.ScheduleState) {
+	log.Trace().
+		Str("package", "service").Str("struct", "eventHandler").
+		Str("method", "ApplySchedule").Uint64("trace", eventInstance.TraceId).
+		Msg("applying schedule")
+
+	last := e.schedule
+	if last != nil && *last == *state {
+		return
+	}
+	applied := *state
+	if !state.ShowOn && e.isShowOn() {
+		log.Info().
+			Str("package", "service").Str("struct", "eventHandler").
+			Str("method", "ApplySchedule").Uint64("trace", eventInstance.TraceId).
+			Msg("outside of show hours; blacking out")
+		e.b.controllerService.BlackoutNodes()
+	}
+	if state.ShaderName != "" && (last == nil || last.ShaderName != state.ShaderName) {
+		err := e.switchShader(eventInstance, state.ShaderName)
+		if err != nil {
+			log.Warn().
+				Str("package", "service").Str("struct", "eventHandler").
+				Str("method", "ApplySchedule").Uint64("trace", eventInstance.TraceId).
+				Err(err).Msgf("couldn't switch to scheduled shader %s", state.ShaderName)
+			// leave the switch outstanding so the scheduler's next report retries it
+			applied.ShaderName = ""
+			if last != nil {
+				applied.ShaderName = last.ShaderName
+			}
+		}
+	}
+	e.schedule = &applied
+	if last == nil || *last != applied {
+		e.b.stream.publish(eventInstance.TraceId, domain.StreamEventScheduleState, &applied)
+	}
+}
+
+// switchShader changes the running shader the same way the api would, keeping the other graphics settings
+func (e *eventHandler) switchShader(eventInstance *event, shaderName string) error {
+	settings, err := e.b.graphicsService.GetSettings()
+	if err != nil {
+		return err
+	}
+	if settings.RunningShader == shaderName {
+		return nil
+	}
+	err = e.b.graphicsService.SetSettings(&domain.GraphicsSettableSettings{
+		ShaderName:     shaderName,
+		Frequency:      settings.Frequency,
+		ReloadOnUpdate: settings.ReloadOnUpdate,
+	})
+	if err != nil {
+		return err
+	}
+	if settings, err = e.b.graphicsService.GetSettings(); err == nil {
+		e.b.stream.publish(eventInstance.TraceId, domain.StreamEventGraphicsSettings, settings)
+	}
+	return nil
 }
 
 func (e *eventHandler) FetchGraphicsSettings(eventInstance *event, dispatchChannel chan *domain.GraphicsSettings) {
@@ -92,18 +197,185 @@ func (e *eventHandler) SetGraphicsSettings(eventInstance *event, payload *setGra
 		ShaderName:     payload.ShaderName,
 		Frequency:      payload.GraphicsFrequency,
 		ReloadOnUpdate: payload.ReloadOnUpdate,
+		Transition:     payload.Transition,
 	})
 	if err != nil {
 		log.Warn().
 			Str("package", "service").Str("struct", "eventHandler").
 			Str("method", "SetGraphicsSettings").Uint64("trace", eventInstance.TraceId).
 			Err(err).Msg("error setting graphics settings")
-		close(payload.DispatchChannel)
-		return
+	} else if settings, err := e.b.graphicsService.GetSettings(); err == nil {
+		e.b.stream.publish(eventInstance.TraceId, domain.StreamEventGraphicsSettings, settings)
+	}
+
+	payload.DispatchChannel <- err
+	// dispatch channel should be garbage collected after command returns the result to api
+}
+
+func (e *eventHandler) publishGraphicsSettings(eventInstance *event) {
+	if settings, err := e.b.graphicsService.GetSettings(); err == nil {
+		e.b.stream.publish(eventInstance.TraceId, domain.StreamEventGraphicsSettings, settings)
+	}
+}
+
+func (e *eventHandler) SetGraphicsUniforms(eventInstance *event, payload *setGraphicsUniformsPayload) {
+	log.Trace().
+		Str("package", "service").Str("struct", "eventHandler").
+		Str("method", "SetGraphicsUniforms").Uint64("trace", eventInstance.TraceId).
+		Msg("setting graphics uniforms")
+
+	err := e.b.graphicsService.SetUniforms(payload.ShaderName, payload.Values)
+	if err != nil {
+		log.Warn().
+			Str("package", "service").Str("struct", "eventHandler").
+			Str("method", "SetGraphicsUniforms").Uint64("trace", eventInstance.TraceId).
+			Err(err).Msg("error setting graphics uniforms")
+	} else {
+		e.publishGraphicsSettings(eventInstance)
+	}
+
+	payload.DispatchChannel <- err
+	// dispatch channel should be garbage collected after command returns the result to api
+}
+
+func (e *eventHandler) UploadGraphicsShader(eventInstance *event, payload *uploadGraphicsShaderPayload) {
+	log.Trace().
+		Str("package", "service").Str("struct", "eventHandler").
+		Str("method", "UploadGraphicsShader").Uint64("trace", eventInstance.TraceId).
+		Msg("uploading graphics shader")
+
+	err := e.b.graphicsService.UploadShader(payload.Name, payload.Frag, payload.Vert)
+	if err != nil {
+		log.Warn().
+			Str("package", "service").Str("struct", "eventHandler").
+			Str("method", "UploadGraphicsShader").Uint64("trace", eventInstance.TraceId).
+			Err(err).Msg("error uploading graphics shader")
+	} else {
+		e.publishGraphicsSettings(eventInstance)
+	}
+
+	payload.DispatchChannel <- err
+	// dispatch channel should be garbage collected after command returns the result to api
+}
+
+func (e *eventHandler) DeleteGraphicsShader(eventInstance *event, payload *deleteGraphicsShaderPayload) {
+	log.Trace().
+		Str("package", "service").Str("struct", "eventHandler").
+		Str("method", "DeleteGraphicsShader").Uint64("trace", eventInstance.TraceId).
+		Msg("deleting graphics shader")
+
+	err := e.b.graphicsService.DeleteShader(payload.Name)
+	if err != nil {
+		log.Warn().
+			Str("package", "service").Str("struct", "eventHandler").
+			Str("method", "DeleteGraphicsShader").Uint64("trace", eventInstance.TraceId).
+			Err(err).Msg("error deleting graphics shader")
+	} else {
+		e.publishGraphicsSettings(eventInstance)
+	}
+
+	payload.DispatchChannel <- err
+	// dispatch channel should be garbage collected after command returns the result to api
+}
+
+func (e *eventHandler) RenameGraphicsShader(eventInstance *event, payload *renameGraphicsShaderPayload) {
+	log.Trace().
+		Str("package", "service").Str("struct", "eventHandler").
+		Str("method", "RenameGraphicsShader").Uint64("trace", eventInstance.TraceId).
+		Msg("renaming graphics shader")
+
+	err := e.b.graphicsService.RenameShader(payload.Name, payload.NewName)
+	if err != nil {
+		log.Warn().
+			Str("package", "service").Str("struct", "eventHandler").
+			Str("method", "RenameGraphicsShader").Uint64("trace", eventInstance.TraceId).
+			Err(err).Msg("error renaming graphics shader")
+	} else {
+		e.publishGraphicsSettings(eventInstance)
+	}
+
+	payload.DispatchChannel <- err
+	// dispatch channel should be garbage collected after command returns the result to api
+}
+
+func (e *eventHandler) SetGraphicsPlaylists(eventInstance *event, payload *setGraphicsPlaylistsPayload) {
+	log.Trace().
+		Str("package", "service").Str("struct", "eventHandler").
+		Str("method", "SetGraphicsPlaylists").Uint64("trace", eventInstance.TraceId).
+		Msg("setting graphics playlists")
+
+	err := e.b.graphicsService.SetPlaylists(payload.Playlists)
+	if err != nil {
+		log.Warn().
+			Str("package", "service").Str("struct", "eventHandler").
+			Str("method", "SetGraphicsPlaylists").Uint64("trace", eventInstance.TraceId).
+			Err(err).Msg("error setting graphics playlists")
+	} else {
+		e.publishGraphicsSettings(eventInstance)
 	}
 
-	payload.DispatchChannel <- struct{}{}
-	// dispatch channel should be garbage collected after command returns success to api
+	payload.DispatchChannel <- err
+	// dispatch channel should be garbage collected after command returns the result to api
+}
+
+func (e *eventHandler) StartGraphicsPlaylist(eventInstance *event, payload *startGraphicsPlaylistPayload) {
+	log.Trace().
+		Str("package", "service").Str("struct", "eventHandler").
+		Str("method", "StartGraphicsPlaylist").Uint64("trace", eventInstance.TraceId).
+		Msg("starting graphics playlist")
+
+	err := e.b.graphicsService.StartPlaylist(payload.Name)
+	if err != nil {
+		log.Warn().
+			Str("package", "service").Str("struct", "eventHandler").
+			Str("method", "StartGraphicsPlaylist").Uint64("trace", eventInstance.TraceId).
+			Err(err).Msg("error starting graphics playlist")
+	} else {
+		e.publishGraphicsSettings(eventInstance)
+	}
+
+	payload.DispatchChannel <- err
+	// dispatch channel should be garbage collected after command returns the result to api
+}
+
+func (e *eventHandler) StopGraphicsPlaylist(eventInstance *event, dispatchChannel chan error) {
+	log.Trace().
+		Str("package", "service").Str("struct", "eventHandler").
+		Str("method", "StopGraphicsPlaylist").Uint64("trace", eventInstance.TraceId).
+		Msg("stopping graphics playlist")
+
+	err := e.b.graphicsService.StopPlaylist()
+	if err != nil {
+		log.Warn().
+			Str("package", "service").Str("struct", "eventHandler").
+			Str("method", "StopGraphicsPlaylist").Uint64("trace", eventInstance.TraceId).
+			Err(err).Msg("error stopping graphics playlist")
+	} else {
+		e.publishGraphicsSettings(eventInstance)
+	}
+
+	dispatchChannel <- err
+	// dispatch channel should be garbage collected after command returns the result to api
+}
+
+func (e *eventHandler) SkipGraphicsPlaylist(eventInstance *event, dispatchChannel chan error) {
+	log.Trace().
+		Str("package", "service").Str("struct", "eventHandler").
+		Str("method", "SkipGraphicsPlaylist").Uint64("trace", eventInstance.TraceId).
+		Msg("skipping graphics playlist item")
+
+	err := e.b.graphicsService.SkipPlaylist()
+	if err != nil {
+		log.Warn().
+			Str("package", "service").Str("struct", "eventHandler").
+			Str("method", "SkipGraphicsPlaylist").Uint64("trace", eventInstance.TraceId).
+			Err(err).Msg("error skipping graphics playlist item")
+	} else {
+		e.publishGraphicsSettings(eventInstance)
+	}
+
+	dispatchChannel <- err
+	// dispatch channel should be garbage collected after command returns the result to api
 }
 
 func (e *eventHandler) FetchLightingSettings(eventInstance *event, dispatchChannel chan *domain.LightingSettings) {
@@ -131,15 +403,246 @@ func (e *eventHandler) SetLightingSettings(eventInstance *event, payload *setLig
 			Str("package", "service").Str("struct", "eventHandler").
 			Str("method", "SetLightingSettings").Uint64("trace", eventInstance.TraceId).
 			Err(err).Msg("error setting lighting settings")
+		payload.DispatchChannel <- err
+		return
+	}
+
+	// the pixel buffer is sized from the grid, so graphics has to come back up against the new layout
+	e.b.graphicsService.Reset()
+	e.b.stream.publish(eventInstance.TraceId, domain.StreamEventLightingSettings, e.b.lightingService.GetSettings())
+
+	payload.DispatchChannel <- nil
+	// dispatch channel should be garbage collected after command returns the result to api
+}
+
+func (e *eventHandler) FetchControllerSettings(eventInstance *event, dispatchChannel chan *domain.ControllerSettings) {
+	log.Trace().
+		Str("package", "service").Str("struct", "eventHandler").
+		Str("method", "FetchControllerSettings").Uint64("trace", eventInstance.TraceId).
+		Msg("fetching controller settings")
+	settings := e.b.controllerService.GetSettings()
+	dispatchChannel <- settings
+	// dispatch channel should be garbage collected after command returns settings to api
+}
+
+func (e *eventHandler) SetControllerSettings(eventInstance *event, payload *setControllerSettingsPayload) {
+	log.Trace().
+		Str("package", "service").Str("struct", "eventHandler").
+		Str("method", "SetControllerSettings").Uint64("trace", eventInstance.TraceId).
+		Msg("setting controller settings")
+
+	err := e.b.controllerService.SetSettings(&domain.ControllerSettings{
+		NodeDefinitions: payload.NodeDefinitions,
+		LocalAddress:    payload.LocalAddress,
+	})
+	if err != nil {
+		log.Warn().
+			Str("package", "service").Str("struct", "eventHandler").
+			Str("method", "SetControllerSettings").Uint64("trace", eventInstance.TraceId).
+			Err(err).Msg("error setting controller settings")
+	} else {
+		e.b.stream.publish(
+			eventInstance.TraceId, domain.StreamEventControllerSettings, e.b.controllerService.GetSettings(),
+		)
+	}
+
+	payload.DispatchChannel <- err
+	// dispatch channel should be garbage collected after command returns the result to api
+}
+
+func (e *eventHandler) FetchRenderSettings(eventInstance *event, dispatchChannel chan *domain.RenderSettings) {
+	log.Trace().
+		Str("package", "service").Str("struct", "eventHandler").
+		Str("method", "FetchRenderSettings").Uint64("trace", eventInstance.TraceId).
+		Msg("fetching render settings")
+	settings := e.b.renderService.GetSettings()
+	dispatchChannel <- settings
+	// dispatch channel should be garbage collected after command returns settings to api
+}
+
+func (e *eventHandler) SetRenderSettings(eventInstance *event, payload *setRenderSettingsPayload) {
+	log.Trace().
+		Str("package", "service").Str("struct", "eventHandler").
+		Str("method", "SetRenderSettings").Uint64("trace", eventInstance.TraceId).
+		Msg("setting render settings")
+
+	err := e.b.renderService.SetSettings(&payload.Settings)
+	if err != nil {
+		log.Warn().
+			Str("package", "service").Str("struct", "eventHandler").
+			Str("method", "SetRenderSettings").Uint64("trace", eventInstance.TraceId).
+			Err(err).Msg("error setting render settings")
+	} else {
+		e.b.stream.publish(eventInstance.TraceId, domain.StreamEventRenderSettings, e.b.renderService.GetSettings())
+	}
+
+	payload.DispatchChannel <- err
+	// dispatch channel should be garbage collected after command returns the result to api
+}
+
+func (e *eventHandler) FetchScheduleSettings(eventInstance *event, dispatchChannel chan *domain.ScheduleSettings) {
+	log.Trace().
+		Str("package", "service").Str("struct", "eventHandler").
+		Str("method", "FetchScheduleSettings").Uint64("trace", eventInstance.TraceId).
+		Msg("fetching schedule settings")
+	settings := e.b.schedulerService.GetSettings()
+	dispatchChannel <- settings
+	// dispatch channel should be garbage collected after command returns settings to api
+}
+
+func (e *eventHandler) SetScheduleSettings(eventInstance *event, payload *setScheduleSettingsPayload) {
+	log.Trace().
+		Str("package", "service").Str("struct", "eventHandler").
+		Str("method", "SetScheduleSettings").Uint64("trace", eventInstance.TraceId).
+		Msg("setting schedule settings")
+
+	err := e.checkPlaylistShaders(payload.Settings.Playlist)
+	if err == nil {
+		err = e.b.schedulerService.SetSettings(&payload.Settings)
+	}
+	if err != nil {
+		log.Warn().
+			Str("package", "service").Str("struct", "eventHandler").
+			Str("method", "SetScheduleSettings").Uint64("trace", eventInstance.TraceId).
+			Err(err).Msg("error setting schedule settings")
+	} else {
+		e.b.stream.publish(
+			eventInstance.TraceId, domain.StreamEventScheduleSettings, e.b.schedulerService.GetSettings(),
+		)
+	}
+
+	payload.DispatchChannel <- err
+	// dispatch channel should be garbage collected after command returns the result to api
+}
+
+// checkPlaylistShaders catches a misspelled shader up front rather than at the hour it's scheduled; with
+// graphics down there's no shader list to check against, so the playlist is taken as is
+func (e *eventHandler) checkPlaylistShaders(playlist []types.PlaylistEntry) error {
+	settings, err := e.b.graphicsService.GetSettings()
+	if err != nil {
+		return nil
+	}
+	shaders := make(map[string]struct{}, len(settings.Shaders))
+	for _, shader := range settings.Shaders {
+		shaders[shader] = struct{}{}
+	}
+	for _, entry := range playlist {
+		if _, ok := shaders[entry.ShaderName]; !ok {
+			return fmt.Errorf("%w: Shader %s", domain.ErrNotFound, entry.ShaderName)
+		}
+	}
+	return nil
+}
+
+func (e *eventHandler) FetchNodeHealth(eventInstance *event, dispatchChannel chan []*domain.NodeHealth) {
+	log.Trace().
+		Str("package", "service").Str("struct", "eventHandler").
+		Str("method", "FetchNodeHealth").Uint64("trace", eventInstance.TraceId).
+		Msg("fetching node health")
+	health := e.b.controllerService.GetNodeHealth()
+	dispatchChannel <- health
+	// dispatch channel should be garbage collected after command returns health to api
+}
+
+func (e *eventHandler) ProposeNodeDefinitions(
+	eventInstance *event, dispatchChannel chan *domain.NodeDefinitionsProposal,
+) {
+	log.Trace().
+		Str("package", "service").Str("struct", "eventHandler").
+		Str("method", "ProposeNodeDefinitions").Uint64("trace", eventInstance.TraceId).
+		Msg("proposing node definitions")
+
+	// only universes with lights on them need a node
+	requiredUniverses := make([]int, 0)
+	for universe, lights := range e.b.lightingService.GetLightUniverses() {
+		if len(lights) > 0 {
+			requiredUniverses = append(requiredUniverses, universe)
+		}
+	}
+	dispatchChannel <- e.b.controllerService.ProposeNodeDefinitions(requiredUniverses)
+	// dispatch channel should be garbage collected after command returns the proposal to api
+}
+
+func (e *eventHandler) AcceptNodeDefinitions(eventInstance *event, dispatchChannel chan error) {
+	log.Trace().
+		Str("package", "service").Str("struct", "eventHandler").
+		Str("method", "AcceptNodeDefinitions").Uint64("trace", eventInstance.TraceId).
+		Msg("accepting proposed node definitions")
+
+	err := e.b.controllerService.AcceptNodeDefinitionsProposal()
+	if err != nil {
+		log.Warn().
+			Str("package", "service").Str("struct", "eventHandler").
+			Str("method", "AcceptNodeDefinitions").Uint64("trace", eventInstance.TraceId).
+			Err(err).Msg("error accepting node definitions")
+	} else {
+		e.b.stream.publish(
+			eventInstance.TraceId, domain.StreamEventControllerSettings, e.b.controllerService.GetSettings(),
+		)
+	}
+
+	dispatchChannel <- err
+	// dispatch channel should be garbage collected after command returns the result to api
+}
+
+func (e *eventHandler) FetchStatus(eventInstance *event, dispatchChannel chan *domain.ApplicationStatus) {
+	log.Trace().
+		Str("package", "service").Str("struct", "eventHandler").
+		Str("method", "FetchStatus").Uint64("trace", eventInstance.TraceId).
+		Msg("fetching status")
+
+	dispatchChannel <- e.getStatus()
+	// dispatch channel should be garbage collected after command returns status to api
+}
+
+func (e *eventHandler) getStatus() *domain.ApplicationStatus {
+	status := &domain.ApplicationStatus{
+		GraphicsRunning: false,
+		RunningShader:   "",
+		FramesRendered:  e.framesRendered,
+		GraphicsCrashes: e.graphicsCrashes,
+		LastFrame:       e.lastFrame,
+		PowerSegments:   e.b.renderService.GetPowerStatus(),
+		ShowOn:          e.isShowOn(),
+	}
+	settings, err := e.b.graphicsService.GetSettings()
+	if err == nil {
+		status.GraphicsRunning = true
+		status.RunningShader = settings.RunningShader
+	}
+	return status
+}
+
+func (e *eventHandler) FetchPreview(eventInstance *event, payload *fetchPreviewPayload) {
+	log.Trace().
+		Str("package", "service").Str("struct", "eventHandler").
+		Str("method", "FetchPreview").Uint64("trace", eventInstance.TraceId).
+		Msg("fetching preview")
+
+	pb, gMuPreRLocked := e.b.graphicsService.GetPb()
+	if pb == nil {
+		gMuPreRLocked.RUnlock()
+		log.Warn().
+			Str("package", "service").Str("struct", "eventHandler").
+			Str("method", "FetchPreview").Uint64("trace", eventInstance.TraceId).
+			Msg("graphics isn't running; no frame to preview")
 		close(payload.DispatchChannel)
 		return
 	}
+	// only copy the frame while holding the lock; encoding is left to the caller
+	var img *image.RGBA
+	if payload.LightsOnly {
+		img = pb.ToLightsImage(e.b.lightingService.GetLightUniverses())
+	} else {
+		img = pb.ToImage()
+	}
+	gMuPreRLocked.RUnlock()
 
-	payload.DispatchChannel <- struct{}{}
-	// dispatch channel should be garbage collected after command returns success to api
+	payload.DispatchChannel <- img
+	// dispatch channel should be garbage collected after command returns the preview to api
 }
 
-func (e *eventHandler) ResetApplication(eventInstance *event, dispatchChan chan struct{}) {
+func (e *eventHandler) ResetApplication(eventInstance *event, dispatchChan chan error) {
 	log.Trace().
 		Str("package", "service").Str("struct", "eventHandler").
 		Str("method", "ResetApplication").Uint64("trace", eventInstance.TraceId).
@@ -151,9 +654,16 @@ func (e *eventHandler) ResetApplication(eventInstance *event, dispatchChan chan
 			Str("package", "service").Str("struct", "eventHandler").
 			Str("method", "ResetApplication").Uint64("trace", eventInstance.TraceId).
 			Err(err).Msg("couldn't reset repository")
-		close(dispatchChan)
+		dispatchChan <- err
 		return
 	}
+	/*
+		the reset is committed once the repository is; restarting the services below can take longer than the
+		caller waits on the bus, so it's answered now rather than timing out on a reset that went through.
+		Clients that need to know when the services are back listen for StreamEventApplicationReset
+	*/
+	dispatchChan <- nil
+	// dispatch channel should be garbage collected after command returns the result to api
 	/*
 		we could technically pump the queue here, but more things could just get queued in the meantime; instead,
 		we are going to rely on busyTimeout to force listeners of events to timeout before shutting them down and
@@ -164,14 +674,23 @@ func (e *eventHandler) ResetApplication(eventInstance *event, dispatchChan chan
 	e.eventQueue = nil
 	e.eventQueueLock.Unlock()
 	// shutdown services; rely on the busyTimeout for them to return on response channels
+	e.b.schedulerService.Shutdown()
 	e.b.graphicsService.Shutdown()
 	e.b.controllerService.Shutdown()
 	// garbage collect old events
+	e.eventQueueLock.Lock()
 	e.eventQueue = make(chan *event, e.eventQueueSize)
+	e.eventQueueLock.Unlock()
 	// reconfig
 	e.b.lightingService.SetupLightingService()
+	e.b.renderService.SetupRenderService()
 	e.b.controllerService.SetupControllerService()
+	e.b.schedulerService.SetupSchedulerService()
+	// the scheduler reports again on startup; everything it asks for has to be applied afresh
+	e.schedule = nil
 	// start program loops back up
 	e.b.controllerService.Startup()
 	e.b.graphicsService.Startup()
+	e.b.schedulerService.Startup()
+	e.b.stream.publish(eventInstance.TraceId, domain.StreamEventApplicationReset, nil)
 }
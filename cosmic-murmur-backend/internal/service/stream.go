@@ -0,0 +1,72 @@
+package service
+
+import (
+	"github.com/polis-interactive/2023-CosmicMurmur/internal/domain"
+	"github.com/rs/zerolog/log"
+	"sync"
+	"time"
+)
+
+const (
+	subscriberBufferSize = 64
+	statusStreamPeriod   = 1 * time.Second
+)
+
+/*
+	eventStream fans bus activity out to live subscribers; publishing never blocks, so a slow client just
+	misses events instead of holding up the event loop or a node
+*/
+
+type eventStream struct {
+	mu               *sync.Mutex
+	subscribers      map[uint64]chan *domain.StreamEvent
+	nextSubscriberId uint64
+}
+
+func newEventStream() *eventStream {
+	return &eventStream{
+		mu:          &sync.Mutex{},
+		subscribers: make(map[uint64]chan *domain.StreamEvent),
+	}
+}
+
+func (s *eventStream) subscribe() (<-chan *domain.StreamEvent, func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := s.nextSubscriberId
+	s.nextSubscriberId += 1
+	ch := make(chan *domain.StreamEvent, subscriberBufferSize)
+	s.subscribers[id] = ch
+	return ch, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if _, ok := s.subscribers[id]; ok {
+			delete(s.subscribers, id)
+			close(ch)
+		}
+	}
+}
+
+func (s *eventStream) publish(traceId uint64, name domain.StreamEventName, payload interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.subscribers) == 0 {
+		return
+	}
+	streamEvent := &domain.StreamEvent{
+		Name:    name,
+		TraceId: traceId,
+		Time:    time.Now(),
+		Payload: payload,
+	}
+	for id, ch := range s.subscribers {
+		select {
+		case ch <- streamEvent:
+		default:
+			log.Debug().
+				Str("package", "service").Str("struct", "eventStream").
+				Str("method", "publish").Uint64("trace", traceId).Uint64("subscriber", id).
+				Msg("subscriber is behind; dropping event")
+		}
+	}
+}
@@ -1,6 +1,10 @@
 package types
 
-import "unsafe"
+import (
+	"image"
+	"image/color"
+	"unsafe"
+)
 
 type Color struct {
 	R uint8
@@ -9,12 +13,32 @@ type Color struct {
 	W uint8
 }
 
+// Color16 is a calibrated output color; the extra bits carry gamma and dithering precision down to the pack
+type Color16 struct {
+	R uint16
+	G uint16
+	B uint16
+}
+
+// ToColor16 stretches each channel over the full range, v * 257 being v repeated in both bytes
+func (c Color) ToColor16() Color16 {
+	return Color16{R: uint16(c.R) * 257, G: uint16(c.G) * 257, B: uint16(c.B) * 257}
+}
+
+// WhiteBalance scales each channel of the output, 0 - 1, to pull the leds' white point toward neutral
+type WhiteBalance struct {
+	R float64
+	G float64
+	B float64
+}
+
 func (c *Color) ToBits() (out uint32) {
 	return uint32(c.W)<<24 | uint32(c.R)<<16 | uint32(c.G)<<8 | uint32(c.B)
 }
 
 type PixelBuffer struct {
 	width  int
+	height int
 	minX   int
 	minY   int
 	stride int
@@ -24,6 +48,7 @@ type PixelBuffer struct {
 func NewPixelBuffer(width, height, minX, minY, stride int) *PixelBuffer {
 	return &PixelBuffer{
 		width:  width,
+		height: height,
 		minX:   minX,
 		minY:   minY,
 		stride: stride,
@@ -52,3 +77,64 @@ func (pb *PixelBuffer) BlackOut() {
 		pb.buffer[i] = Color{}
 	}
 }
+
+func (pb *PixelBuffer) Width() int {
+	return pb.width
+}
+
+func (pb *PixelBuffer) Height() int {
+	return pb.height
+}
+
+// ToImage copies the buffer into an image; the buffer's first row is the bottom of the frame (as read back
+// from opengl), so rows are flipped to get the right way up
+func (pb *PixelBuffer) ToImage() *image.RGBA {
+	height := pb.Height()
+	img := image.NewRGBA(image.Rect(0, 0, pb.width, height))
+	for y := 0; y < height; y++ {
+		row := pb.buffer[y*pb.width : (y+1)*pb.width]
+		for x, c := range row {
+			img.SetRGBA(x, height-1-y, color.RGBA{R: c.R, G: c.G, B: c.B, A: 255})
+		}
+	}
+	return img
+}
+
+// ToLightsImage draws only the pixels sampled for lights, at the same size and orientation as ToImage
+func (pb *PixelBuffer) ToLightsImage(lightUniverses [][]*Light) *image.RGBA {
+	height := pb.Height()
+	img := image.NewRGBA(image.Rect(0, 0, pb.width, height))
+	for i := range img.Pix {
+		if i%4 == 3 {
+			img.Pix[i] = 255
+		}
+	}
+	for _, lights := range lightUniverses {
+		for _, l := range lights {
+			c := pb.GetPixel(&l.Position)
+			startX := (l.Position.X - pb.minX) * pb.stride
+			startY := (l.Position.Y - pb.minY) * pb.stride
+			for y := startY; y < startY+pb.stride; y++ {
+				for x := startX; x < startX+pb.stride; x++ {
+					img.SetRGBA(x, height-1-y, color.RGBA{R: c.R, G: c.G, B: c.B, A: 255})
+				}
+			}
+		}
+	}
+	return img
+}
+
+// PowerSegment is a run of a universe's pixels fed from one power injection point
+type PowerSegment struct {
+	Universe     int
+	FirstPixel   int
+	PixelCount   int
+	MaxMilliamps float64
+}
+
+// PowerBudget estimates draw as MilliampsPerChannel for every channel at full; without segments, or without a
+// per channel draw, nothing is limited
+type PowerBudget struct {
+	MilliampsPerChannel float64
+	Segments            []PowerSegment
+}
@@ -0,0 +1,32 @@
+package types
+
+import (
+	"image"
+	"testing"
+)
+
+func TestPixelBuffer_ToImage(t *testing.T) {
+	// a 3x2 grid at 7 pixels a light
+	pb := NewPixelBuffer(21, 14, 0, 0, 7)
+	*pb.GetPixelPointer(&Point{X: 1, Y: 0}) = Color{R: 255}
+
+	img := pb.ToImage()
+	if img.Bounds() != image.Rect(0, 0, 21, 14) {
+		t.Fatalf("bounds = %v", img.Bounds())
+	}
+	// the buffer's first row is the bottom of the image
+	if c := img.RGBAAt(7, 13); c.R != 255 {
+		t.Errorf("light's pixel = %v", c)
+	}
+
+	lights := pb.ToLightsImage([][]*Light{{{Position: Point{X: 1, Y: 0}}}})
+	if lights.Bounds() != img.Bounds() {
+		t.Fatalf("lights bounds = %v", lights.Bounds())
+	}
+	if c := lights.RGBAAt(13, 7); c.R != 255 {
+		t.Errorf("top of the light's block = %v", c)
+	}
+	if c := lights.RGBAAt(13, 6); c.R != 0 {
+		t.Errorf("above the light's block = %v", c)
+	}
+}
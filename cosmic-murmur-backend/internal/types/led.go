@@ -5,6 +5,7 @@ import "fmt"
 type LedString struct {
 	LedCount    int
 	StringCount int
+	PixelFormat PixelFormat
 }
 
 type LedUniverse []LedString
@@ -14,7 +15,10 @@ type LedSegment []LedUniverse
 type Light struct {
 	Position Point
 	Pixel    int
-	Color    Color
+	// Channel is where the light's first channel sits in its universe, Format how many follow and in what order
+	Channel int
+	Format  PixelFormat
+	Color   Color
 }
 
 func (l *Light) Print() string {
@@ -1,8 +1,30 @@
 package types
 
+type NodeProtocol string
+
+const (
+	// ProtocolArtNet is also what an empty protocol means, so older settings keep working
+	ProtocolArtNet NodeProtocol = "artnet"
+	ProtocolSacn   NodeProtocol = "sacn"
+)
+
 type NodeDefinition struct {
 	Address   string
 	Universes []int
+	// Port overrides the protocol's port when non-zero, e.g. to target a local receiver
+	Port int
+	// Sync holds the node's output until a sync packet arrives, so all of its universes change together
+	Sync bool
+	// Protocol picks the wire protocol; the fields below only apply to sACN
+	Protocol NodeProtocol
+	// Multicast sends each universe to its sACN multicast group instead of to Address
+	Multicast bool
+	// Priority is the sACN source priority, 0 - 200; 0 uses the default of 100
+	Priority int
+	// SyncUniverse is the sACN universe sync packets go out on; required when Sync is set
+	SyncUniverse int
+	// SourceName is shown by receivers; empty uses the application name
+	SourceName string
 }
 
 type NodeDefinitions []NodeDefinition
@@ -0,0 +1,67 @@
+package types
+
+// PixelFormat is the channel order (and width) a string of leds expects; empty is RGB
+type PixelFormat string
+
+const (
+	PixelFormatRGB   PixelFormat = "RGB"
+	PixelFormatGRB   PixelFormat = "GRB"
+	PixelFormatBGR   PixelFormat = "BGR"
+	PixelFormatRGBW  PixelFormat = "RGBW"
+	PixelFormatGRBW  PixelFormat = "GRBW"
+	PixelFormatRGB16 PixelFormat = "RGB16"
+)
+
+func (f PixelFormat) IsValid() bool {
+	switch f {
+	case "", PixelFormatRGB, PixelFormatGRB, PixelFormatBGR, PixelFormatRGBW, PixelFormatGRBW, PixelFormatRGB16:
+		return true
+	}
+	return false
+}
+
+// ChannelCount is how many dmx channels one pixel takes
+func (f PixelFormat) ChannelCount() int {
+	switch f {
+	case PixelFormatRGBW, PixelFormatGRBW:
+		return 4
+	case PixelFormatRGB16:
+		return 6
+	}
+	return 3
+}
+
+// Pack writes c into the start of channels; 8 bit formats take the high byte, white formats pull the shared
+// part of r, g and b onto the white channel, 16 bit formats are big endian
+func (f PixelFormat) Pack(channels []byte, c Color16) {
+	switch f {
+	case PixelFormatGRB:
+		channels[0], channels[1], channels[2] = uint8(c.G>>8), uint8(c.R>>8), uint8(c.B>>8)
+	case PixelFormatBGR:
+		channels[0], channels[1], channels[2] = uint8(c.B>>8), uint8(c.G>>8), uint8(c.R>>8)
+	case PixelFormatRGBW, PixelFormatGRBW:
+		w := c.R
+		if c.G < w {
+			w = c.G
+		}
+		if c.B < w {
+			w = c.B
+		}
+		r, g, b := c.R-w, c.G-w, c.B-w
+		if f == PixelFormatGRBW {
+			r, g = g, r
+		}
+		channels[0], channels[1], channels[2], channels[3] = uint8(r>>8), uint8(g>>8), uint8(b>>8), uint8(w>>8)
+	case PixelFormatRGB16:
+		channels[0], channels[1] = uint8(c.R>>8), uint8(c.R)
+		channels[2], channels[3] = uint8(c.G>>8), uint8(c.G)
+		channels[4], channels[5] = uint8(c.B>>8), uint8(c.B)
+	default:
+		channels[0], channels[1], channels[2] = uint8(c.R>>8), uint8(c.G>>8), uint8(c.B>>8)
+	}
+}
+
+// IsWide is true for formats that output every bit of a Color16; there's nothing for dithering to do there
+func (f PixelFormat) IsWide() bool {
+	return f == PixelFormatRGB16
+}
@@ -0,0 +1,33 @@
+package types
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPixelFormat_Pack(t *testing.T) {
+	c := Color{R: 200, G: 100, B: 50}
+	cases := map[PixelFormat][]byte{
+		"":               {200, 100, 50},
+		PixelFormatGRB:   {100, 200, 50},
+		PixelFormatBGR:   {50, 100, 200},
+		PixelFormatRGBW:  {150, 50, 0, 50},
+		PixelFormatGRBW:  {50, 150, 0, 50},
+		PixelFormatRGB16: {200, 200, 100, 100, 50, 50},
+	}
+	for f, expected := range cases {
+		channels := make([]byte, f.ChannelCount())
+		f.Pack(channels, c.ToColor16())
+		if !bytes.Equal(channels, expected) {
+			t.Errorf("%q packed to %v; expected %v", f, channels, expected)
+		}
+	}
+}
+
+func TestPixelFormat_PackWide(t *testing.T) {
+	channels := make([]byte, 6)
+	PixelFormatRGB16.Pack(channels, Color16{R: 0x1234, G: 0xABCD, B: 0x00FF})
+	if !bytes.Equal(channels, []byte{0x12, 0x34, 0xAB, 0xCD, 0x00, 0xFF}) {
+		t.Errorf("packed to %x", channels)
+	}
+}
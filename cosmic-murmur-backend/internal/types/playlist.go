@@ -0,0 +1,36 @@
+package types
+
+import "time"
+
+type PlaylistMode string
+
+const (
+	PlaylistSequential PlaylistMode = "sequential"
+	PlaylistShuffle    PlaylistMode = "shuffle"
+)
+
+func (m PlaylistMode) IsValid() bool {
+	switch m {
+	case PlaylistSequential, PlaylistShuffle:
+		return true
+	}
+	return false
+}
+
+// PlaylistItem runs ShaderName for Duration. A zero Frequency keeps the graphics frequency, Uniforms override
+// the shader's uniforms while the item runs, and a nil Transition switches in with the graphics transition
+type PlaylistItem struct {
+	ShaderName string
+	Duration   time.Duration
+	Frequency  time.Duration
+	Uniforms   map[string]float32
+	Transition *Transition
+}
+
+// Playlist cycles through its items until stopped; unlike the schedule's playlist, it runs on durations
+// rather than times of day. Shuffle reorders the items every time through
+type Playlist struct {
+	Name  string
+	Mode  PlaylistMode
+	Items []PlaylistItem
+}
@@ -0,0 +1,41 @@
+package types
+
+type ScheduleAnchor string
+
+const (
+	AnchorClock   ScheduleAnchor = "clock"
+	AnchorSunrise ScheduleAnchor = "sunrise"
+	AnchorSunset  ScheduleAnchor = "sunset"
+)
+
+func (a ScheduleAnchor) IsValid() bool {
+	switch a {
+	case AnchorClock, AnchorSunrise, AnchorSunset:
+		return true
+	}
+	return false
+}
+
+// ScheduleTime is a time of day; for clock anchors Minutes counts from local midnight, for sun anchors it's an
+// offset from that day's sunrise / sunset
+type ScheduleTime struct {
+	Anchor  ScheduleAnchor
+	Minutes int
+}
+
+// ScheduleWindow is a daily span the show is on for; an Off at or before On runs past midnight
+type ScheduleWindow struct {
+	On  ScheduleTime
+	Off ScheduleTime
+}
+
+// PlaylistEntry switches to ShaderName at Start every day, until the next entry starts
+type PlaylistEntry struct {
+	Start      ScheduleTime
+	ShaderName string
+}
+
+type GeoLocation struct {
+	Latitude  float64
+	Longitude float64
+}
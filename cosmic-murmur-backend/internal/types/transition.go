@@ -0,0 +1,49 @@
+package types
+
+import (
+	"math"
+	"time"
+)
+
+type Easing string
+
+const (
+	EasingLinear Easing = "linear"
+	EasingIn     Easing = "easeIn"
+	EasingOut    Easing = "easeOut"
+	EasingInOut  Easing = "easeInOut"
+)
+
+func (e Easing) IsValid() bool {
+	switch e {
+	case EasingLinear, EasingIn, EasingOut, EasingInOut:
+		return true
+	}
+	return false
+}
+
+// Apply maps linear progress through a transition, clamped to 0..1, onto the easing's (cubic) curve
+func (e Easing) Apply(p float64) float64 {
+	p = math.Min(math.Max(p, 0.0), 1.0)
+	switch e {
+	case EasingIn:
+		return p * p * p
+	case EasingOut:
+		return 1.0 - math.Pow(1.0-p, 3.0)
+	case EasingInOut:
+		if p < 0.5 {
+			return 4.0 * p * p * p
+		}
+		return 1.0 - math.Pow(2.0-2.0*p, 3.0)/2.0
+	default:
+		return p
+	}
+}
+
+// Transition blends the outgoing shader into the incoming one over Duration; a zero duration cuts. With a
+// MaskShader, wherever the mask lights up switches over early, so e.g. the wipes sweep the new shader in
+type Transition struct {
+	Duration   time.Duration
+	Easing     Easing
+	MaskShader string
+}
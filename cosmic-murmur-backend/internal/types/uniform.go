@@ -0,0 +1,22 @@
+package types
+
+// UniformTypeFloat is the only type shaders can declare for now; the renderer passes every uniform as a float
+const UniformTypeFloat = "float"
+
+// ShaderUniform is a uniform a shader declared as settable, e.g. `uniform float speed; // @range 0 10 2`
+type ShaderUniform struct {
+	Name    string
+	Type    string
+	Min     float32
+	Max     float32
+	Default float32
+}
+
+func (u ShaderUniform) Clamp(v float32) float32 {
+	if v < u.Min {
+		return u.Min
+	} else if v > u.Max {
+		return u.Max
+	}
+	return v
+}